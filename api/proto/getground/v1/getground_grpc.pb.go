@@ -0,0 +1,563 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.12
+// source: getground/v1/getground.proto
+
+package getgroundv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// GuestServiceClient is the client API for GuestService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GuestServiceClient interface {
+	CreateGuest(ctx context.Context, in *CreateGuestRequest, opts ...grpc.CallOption) (*Guest, error)
+	GetGuest(ctx context.Context, in *GetGuestRequest, opts ...grpc.CallOption) (*Guest, error)
+	ListGuests(ctx context.Context, in *ListGuestsRequest, opts ...grpc.CallOption) (*ListGuestsResponse, error)
+}
+
+type guestServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGuestServiceClient(cc grpc.ClientConnInterface) GuestServiceClient {
+	return &guestServiceClient{cc}
+}
+
+func (c *guestServiceClient) CreateGuest(ctx context.Context, in *CreateGuestRequest, opts ...grpc.CallOption) (*Guest, error) {
+	out := new(Guest)
+	err := c.cc.Invoke(ctx, "/getground.v1.GuestService/CreateGuest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *guestServiceClient) GetGuest(ctx context.Context, in *GetGuestRequest, opts ...grpc.CallOption) (*Guest, error) {
+	out := new(Guest)
+	err := c.cc.Invoke(ctx, "/getground.v1.GuestService/GetGuest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *guestServiceClient) ListGuests(ctx context.Context, in *ListGuestsRequest, opts ...grpc.CallOption) (*ListGuestsResponse, error) {
+	out := new(ListGuestsResponse)
+	err := c.cc.Invoke(ctx, "/getground.v1.GuestService/ListGuests", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GuestServiceServer is the server API for GuestService service.
+// All implementations must embed UnimplementedGuestServiceServer
+// for forward compatibility
+type GuestServiceServer interface {
+	CreateGuest(context.Context, *CreateGuestRequest) (*Guest, error)
+	GetGuest(context.Context, *GetGuestRequest) (*Guest, error)
+	ListGuests(context.Context, *ListGuestsRequest) (*ListGuestsResponse, error)
+	mustEmbedUnimplementedGuestServiceServer()
+}
+
+// UnimplementedGuestServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedGuestServiceServer struct {
+}
+
+func (UnimplementedGuestServiceServer) CreateGuest(context.Context, *CreateGuestRequest) (*Guest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGuest not implemented")
+}
+func (UnimplementedGuestServiceServer) GetGuest(context.Context, *GetGuestRequest) (*Guest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGuest not implemented")
+}
+func (UnimplementedGuestServiceServer) ListGuests(context.Context, *ListGuestsRequest) (*ListGuestsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGuests not implemented")
+}
+func (UnimplementedGuestServiceServer) mustEmbedUnimplementedGuestServiceServer() {}
+
+// UnsafeGuestServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GuestServiceServer will
+// result in compilation errors.
+type UnsafeGuestServiceServer interface {
+	mustEmbedUnimplementedGuestServiceServer()
+}
+
+func RegisterGuestServiceServer(s grpc.ServiceRegistrar, srv GuestServiceServer) {
+	s.RegisterService(&GuestService_ServiceDesc, srv)
+}
+
+func _GuestService_CreateGuest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGuestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GuestServiceServer).CreateGuest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/getground.v1.GuestService/CreateGuest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GuestServiceServer).CreateGuest(ctx, req.(*CreateGuestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GuestService_GetGuest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGuestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GuestServiceServer).GetGuest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/getground.v1.GuestService/GetGuest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GuestServiceServer).GetGuest(ctx, req.(*GetGuestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GuestService_ListGuests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGuestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GuestServiceServer).ListGuests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/getground.v1.GuestService/ListGuests",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GuestServiceServer).ListGuests(ctx, req.(*ListGuestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GuestService_ServiceDesc is the grpc.ServiceDesc for GuestService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GuestService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "getground.v1.GuestService",
+	HandlerType: (*GuestServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateGuest",
+			Handler:    _GuestService_CreateGuest_Handler,
+		},
+		{
+			MethodName: "GetGuest",
+			Handler:    _GuestService_GetGuest_Handler,
+		},
+		{
+			MethodName: "ListGuests",
+			Handler:    _GuestService_ListGuests_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "getground/v1/getground.proto",
+}
+
+// TableServiceClient is the client API for TableService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TableServiceClient interface {
+	CreateTable(ctx context.Context, in *CreateTableRequest, opts ...grpc.CallOption) (*Table, error)
+	GetTable(ctx context.Context, in *GetTableRequest, opts ...grpc.CallOption) (*Table, error)
+	GetEmptySeats(ctx context.Context, in *GetEmptySeatsRequest, opts ...grpc.CallOption) (*GetEmptySeatsResponse, error)
+}
+
+type tableServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTableServiceClient(cc grpc.ClientConnInterface) TableServiceClient {
+	return &tableServiceClient{cc}
+}
+
+func (c *tableServiceClient) CreateTable(ctx context.Context, in *CreateTableRequest, opts ...grpc.CallOption) (*Table, error) {
+	out := new(Table)
+	err := c.cc.Invoke(ctx, "/getground.v1.TableService/CreateTable", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tableServiceClient) GetTable(ctx context.Context, in *GetTableRequest, opts ...grpc.CallOption) (*Table, error) {
+	out := new(Table)
+	err := c.cc.Invoke(ctx, "/getground.v1.TableService/GetTable", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tableServiceClient) GetEmptySeats(ctx context.Context, in *GetEmptySeatsRequest, opts ...grpc.CallOption) (*GetEmptySeatsResponse, error) {
+	out := new(GetEmptySeatsResponse)
+	err := c.cc.Invoke(ctx, "/getground.v1.TableService/GetEmptySeats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TableServiceServer is the server API for TableService service.
+// All implementations must embed UnimplementedTableServiceServer
+// for forward compatibility
+type TableServiceServer interface {
+	CreateTable(context.Context, *CreateTableRequest) (*Table, error)
+	GetTable(context.Context, *GetTableRequest) (*Table, error)
+	GetEmptySeats(context.Context, *GetEmptySeatsRequest) (*GetEmptySeatsResponse, error)
+	mustEmbedUnimplementedTableServiceServer()
+}
+
+// UnimplementedTableServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedTableServiceServer struct {
+}
+
+func (UnimplementedTableServiceServer) CreateTable(context.Context, *CreateTableRequest) (*Table, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTable not implemented")
+}
+func (UnimplementedTableServiceServer) GetTable(context.Context, *GetTableRequest) (*Table, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTable not implemented")
+}
+func (UnimplementedTableServiceServer) GetEmptySeats(context.Context, *GetEmptySeatsRequest) (*GetEmptySeatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEmptySeats not implemented")
+}
+func (UnimplementedTableServiceServer) mustEmbedUnimplementedTableServiceServer() {}
+
+// UnsafeTableServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TableServiceServer will
+// result in compilation errors.
+type UnsafeTableServiceServer interface {
+	mustEmbedUnimplementedTableServiceServer()
+}
+
+func RegisterTableServiceServer(s grpc.ServiceRegistrar, srv TableServiceServer) {
+	s.RegisterService(&TableService_ServiceDesc, srv)
+}
+
+func _TableService_CreateTable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TableServiceServer).CreateTable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/getground.v1.TableService/CreateTable",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TableServiceServer).CreateTable(ctx, req.(*CreateTableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TableService_GetTable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TableServiceServer).GetTable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/getground.v1.TableService/GetTable",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TableServiceServer).GetTable(ctx, req.(*GetTableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TableService_GetEmptySeats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEmptySeatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TableServiceServer).GetEmptySeats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/getground.v1.TableService/GetEmptySeats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TableServiceServer).GetEmptySeats(ctx, req.(*GetEmptySeatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TableService_ServiceDesc is the grpc.ServiceDesc for TableService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TableService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "getground.v1.TableService",
+	HandlerType: (*TableServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateTable",
+			Handler:    _TableService_CreateTable_Handler,
+		},
+		{
+			MethodName: "GetTable",
+			Handler:    _TableService_GetTable_Handler,
+		},
+		{
+			MethodName: "GetEmptySeats",
+			Handler:    _TableService_GetEmptySeats_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "getground/v1/getground.proto",
+}
+
+// GuestListServiceClient is the client API for GuestListService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GuestListServiceClient interface {
+	// CheckIn seats a guest and their accompanying guests at the first table with enough seats
+	CheckIn(ctx context.Context, in *CheckInRequest, opts ...grpc.CallOption) (*CheckInResponse, error)
+	// Leave checks a guest out, their table is freed
+	Leave(ctx context.Context, in *LeaveRequest, opts ...grpc.CallOption) (*LeaveResponse, error)
+	ListOccupiedTables(ctx context.Context, in *ListOccupiedTablesRequest, opts ...grpc.CallOption) (*ListOccupiedTablesResponse, error)
+	// WatchOccupancy streams the occupancy of the venue, a new snapshot is sent whenever it changes
+	WatchOccupancy(ctx context.Context, in *WatchOccupancyRequest, opts ...grpc.CallOption) (GuestListService_WatchOccupancyClient, error)
+}
+
+type guestListServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGuestListServiceClient(cc grpc.ClientConnInterface) GuestListServiceClient {
+	return &guestListServiceClient{cc}
+}
+
+func (c *guestListServiceClient) CheckIn(ctx context.Context, in *CheckInRequest, opts ...grpc.CallOption) (*CheckInResponse, error) {
+	out := new(CheckInResponse)
+	err := c.cc.Invoke(ctx, "/getground.v1.GuestListService/CheckIn", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *guestListServiceClient) Leave(ctx context.Context, in *LeaveRequest, opts ...grpc.CallOption) (*LeaveResponse, error) {
+	out := new(LeaveResponse)
+	err := c.cc.Invoke(ctx, "/getground.v1.GuestListService/Leave", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *guestListServiceClient) ListOccupiedTables(ctx context.Context, in *ListOccupiedTablesRequest, opts ...grpc.CallOption) (*ListOccupiedTablesResponse, error) {
+	out := new(ListOccupiedTablesResponse)
+	err := c.cc.Invoke(ctx, "/getground.v1.GuestListService/ListOccupiedTables", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *guestListServiceClient) WatchOccupancy(ctx context.Context, in *WatchOccupancyRequest, opts ...grpc.CallOption) (GuestListService_WatchOccupancyClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GuestListService_ServiceDesc.Streams[0], "/getground.v1.GuestListService/WatchOccupancy", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &guestListServiceWatchOccupancyClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GuestListService_WatchOccupancyClient interface {
+	Recv() (*Occupancy, error)
+	grpc.ClientStream
+}
+
+type guestListServiceWatchOccupancyClient struct {
+	grpc.ClientStream
+}
+
+func (x *guestListServiceWatchOccupancyClient) Recv() (*Occupancy, error) {
+	m := new(Occupancy)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// GuestListServiceServer is the server API for GuestListService service.
+// All implementations must embed UnimplementedGuestListServiceServer
+// for forward compatibility
+type GuestListServiceServer interface {
+	// CheckIn seats a guest and their accompanying guests at the first table with enough seats
+	CheckIn(context.Context, *CheckInRequest) (*CheckInResponse, error)
+	// Leave checks a guest out, their table is freed
+	Leave(context.Context, *LeaveRequest) (*LeaveResponse, error)
+	ListOccupiedTables(context.Context, *ListOccupiedTablesRequest) (*ListOccupiedTablesResponse, error)
+	// WatchOccupancy streams the occupancy of the venue, a new snapshot is sent whenever it changes
+	WatchOccupancy(*WatchOccupancyRequest, GuestListService_WatchOccupancyServer) error
+	mustEmbedUnimplementedGuestListServiceServer()
+}
+
+// UnimplementedGuestListServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedGuestListServiceServer struct {
+}
+
+func (UnimplementedGuestListServiceServer) CheckIn(context.Context, *CheckInRequest) (*CheckInResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckIn not implemented")
+}
+func (UnimplementedGuestListServiceServer) Leave(context.Context, *LeaveRequest) (*LeaveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Leave not implemented")
+}
+func (UnimplementedGuestListServiceServer) ListOccupiedTables(context.Context, *ListOccupiedTablesRequest) (*ListOccupiedTablesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOccupiedTables not implemented")
+}
+func (UnimplementedGuestListServiceServer) WatchOccupancy(*WatchOccupancyRequest, GuestListService_WatchOccupancyServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchOccupancy not implemented")
+}
+func (UnimplementedGuestListServiceServer) mustEmbedUnimplementedGuestListServiceServer() {}
+
+// UnsafeGuestListServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GuestListServiceServer will
+// result in compilation errors.
+type UnsafeGuestListServiceServer interface {
+	mustEmbedUnimplementedGuestListServiceServer()
+}
+
+func RegisterGuestListServiceServer(s grpc.ServiceRegistrar, srv GuestListServiceServer) {
+	s.RegisterService(&GuestListService_ServiceDesc, srv)
+}
+
+func _GuestListService_CheckIn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckInRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GuestListServiceServer).CheckIn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/getground.v1.GuestListService/CheckIn",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GuestListServiceServer).CheckIn(ctx, req.(*CheckInRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GuestListService_Leave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GuestListServiceServer).Leave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/getground.v1.GuestListService/Leave",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GuestListServiceServer).Leave(ctx, req.(*LeaveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GuestListService_ListOccupiedTables_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOccupiedTablesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GuestListServiceServer).ListOccupiedTables(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/getground.v1.GuestListService/ListOccupiedTables",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GuestListServiceServer).ListOccupiedTables(ctx, req.(*ListOccupiedTablesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GuestListService_WatchOccupancy_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchOccupancyRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GuestListServiceServer).WatchOccupancy(m, &guestListServiceWatchOccupancyServer{stream})
+}
+
+type GuestListService_WatchOccupancyServer interface {
+	Send(*Occupancy) error
+	grpc.ServerStream
+}
+
+type guestListServiceWatchOccupancyServer struct {
+	grpc.ServerStream
+}
+
+func (x *guestListServiceWatchOccupancyServer) Send(m *Occupancy) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// GuestListService_ServiceDesc is the grpc.ServiceDesc for GuestListService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GuestListService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "getground.v1.GuestListService",
+	HandlerType: (*GuestListServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CheckIn",
+			Handler:    _GuestListService_CheckIn_Handler,
+		},
+		{
+			MethodName: "Leave",
+			Handler:    _GuestListService_Leave_Handler,
+		},
+		{
+			MethodName: "ListOccupiedTables",
+			Handler:    _GuestListService_ListOccupiedTables_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchOccupancy",
+			Handler:       _GuestListService_WatchOccupancy_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "getground/v1/getground.proto",
+}
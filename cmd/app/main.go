@@ -5,10 +5,15 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	// the venue timezone must load even on images without a zoneinfo database
+	_ "time/tzdata"
 
 	"github.com/eazygood/getground-app/cmd/app/server"
 	"github.com/eazygood/getground-app/internal/config"
 	"github.com/eazygood/getground-app/internal/infrastructure/log"
+	"github.com/eazygood/getground-app/internal/infrastructure/tracing"
+	"github.com/eazygood/getground-app/internal/validator"
+	"github.com/eazygood/getground-app/internal/venue"
 	logger "github.com/sirupsen/logrus"
 )
 
@@ -19,6 +24,23 @@ func main() {
 	}
 
 	log.Init(cfg.Log)
+	validator.Init(cfg.Venue)
+
+	if err := venue.Init(cfg.Venue); err != nil {
+		logger.WithError(err).Fatal("failed to load venue timezone")
+	}
+
+	shutdownTracing, err := tracing.Init(context.Background(), cfg.Tracing)
+	if err != nil {
+		logger.WithError(err).Fatal("failed to init tracing")
+	}
+
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.WithError(err).Error("failed to flush traces")
+		}
+	}()
+
 	server.Start(contextWithTermSignal(), *cfg)
 	logger.Info("server started")
 }
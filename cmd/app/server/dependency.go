@@ -1,43 +1,281 @@
 package server
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
 	"github.com/eazygood/getground-app/internal/api/controller"
+	"github.com/eazygood/getground-app/internal/api/graphql"
+	"github.com/eazygood/getground-app/internal/api/rpc"
 	"github.com/eazygood/getground-app/internal/config"
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
 	"github.com/eazygood/getground-app/internal/core/service"
+	"github.com/eazygood/getground-app/internal/core/service/cached"
+	serviceInstrumented "github.com/eazygood/getground-app/internal/core/service/instrumented"
+	"github.com/eazygood/getground-app/internal/core/service/notifying"
+	"github.com/eazygood/getground-app/internal/core/service/overbooking"
+	"github.com/eazygood/getground-app/internal/infrastructure/cache"
 	mysql "github.com/eazygood/getground-app/internal/infrastructure/db"
+	"github.com/eazygood/getground-app/internal/infrastructure/health"
+	"github.com/eazygood/getground-app/internal/infrastructure/mail"
+	"github.com/eazygood/getground-app/internal/infrastructure/metrics"
+	"github.com/eazygood/getground-app/internal/infrastructure/outbox"
+	"github.com/eazygood/getground-app/internal/infrastructure/webhook"
+	"github.com/eazygood/getground-app/internal/infrastructure/worker"
+	companionRepository "github.com/eazygood/getground-app/internal/repository/companion"
 	"github.com/eazygood/getground-app/internal/repository/guest"
 	"github.com/eazygood/getground-app/internal/repository/guestlist"
+	"github.com/eazygood/getground-app/internal/repository/instrumented"
+	outboxRepository "github.com/eazygood/getground-app/internal/repository/outbox"
+	"github.com/eazygood/getground-app/internal/repository/reminder"
+	"github.com/eazygood/getground-app/internal/repository/search"
 	"github.com/eazygood/getground-app/internal/repository/table"
+	webhookRepository "github.com/eazygood/getground-app/internal/repository/webhook"
+	"github.com/eazygood/getground-app/internal/venue"
+	"google.golang.org/grpc"
+	"gorm.io/gorm"
 )
 
 type Dependecy struct {
 	guestController     controller.GuestController
 	tableController     controller.TableController
 	guestListController controller.GuestListController
+	webhookController   controller.WebhookController
+	checkinController   controller.CheckinController
+	companionController controller.CompanionController
+	mailController      controller.MailController
+	reportController    controller.ReportController
+	docsController      controller.DocsController
+	graphqlHandler      http.Handler
+	healthChecker       *health.HealthChecker
+	// grpcServer is nil when the gRPC API is disabled
+	grpcServer *grpc.Server
+	// workers run in the background for the lifetime of the server
+	workers []runner
+}
+
+type runner interface {
+	Run(ctx context.Context)
 }
 
-func initDependencies(cfg *config.App) (*Dependecy, error) {
+func initDependencies(ctx context.Context, cfg *config.App) (*Dependecy, error) {
 	// db connection
-	db := mysql.InitDb(cfg)
+	db, err := mysql.InitDb(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// readiness probes
+	healthChecker := health.NewHealthChecker(cfg.Server.Health.Timeout)
+	healthChecker.Register("mysql", health.PingDatabase(db))
 
 	// repositories
-	guestRepository := guest.NewMysqlGuestAdapter(db)
-	tableRepository := table.NewMysqlTableAdapter(db)
-	guestListRepository := guestlist.NewMysqlGuestListAdapter(db)
+	guestRepository := instrumented.NewGuestRepository(guest.NewMysqlGuestAdapter(db))
+	tableRepository := instrumented.NewTableRepository(table.NewMysqlTableAdapter(db))
+	guestListRepository := instrumented.NewGuestListRepository(guestlist.NewMysqlGuestListAdapter(db))
+	webhookRepo := instrumented.NewWebhookRepository(webhookRepository.NewMysqlWebhookAdapter(db))
+	outboxRepo := instrumented.NewOutboxRepository(outboxRepository.NewMysqlOutboxAdapter(db))
+	companionRepo := instrumented.NewCompanionRepository(companionRepository.NewMysqlCompanionAdapter(db))
+	reminderRepo := instrumented.NewReminderRepository(reminder.NewMysqlReminderAdapter(db))
+
+	guestSearcher, err := newGuestSearcher(cfg.Search, db, guestRepository)
+	if err != nil {
+		return nil, err
+	}
 
 	// services
-	guestService := service.NewGuestService(guestRepository)
-	tableService := service.NewTableService(tableRepository)
-	guestListService := service.NewGuestListService(guestListRepository)
+	guestService := serviceInstrumented.NewGuestService(service.NewGuestService(guestRepository))
+	tableService := serviceInstrumented.NewTableService(service.NewTableService(tableRepository))
+	guestListService := serviceInstrumented.NewGuestListService(service.NewGuestListService(guestListRepository))
+	overbookingPolicy := domain.OverbookingPolicy{Ratio: cfg.Overbooking.Ratio, MinSamples: cfg.Overbooking.MinSamples}
+	reportService := serviceInstrumented.NewReportService(service.NewReportService(
+		guestRepository,
+		guestListRepository,
+		companionRepo,
+		tableRepository,
+		overbookingPolicy,
+	))
+	companionService := serviceInstrumented.NewCompanionService(service.NewCompanionService(companionRepo))
+	guestSearchService := serviceInstrumented.NewGuestSearchService(service.NewGuestSearchService(instrumented.NewGuestSearcher(guestSearcher)))
+	webhookService := serviceInstrumented.NewWebhookService(service.NewWebhookService(
+		webhookRepo,
+		webhook.NewHTTPSender(cfg.Webhooks.Timeout, cfg.Webhooks.AllowPrivateNetworks),
+		service.WebhookRetry{
+			MaxAttempts:    cfg.Webhooks.MaxAttempts,
+			InitialBackoff: cfg.Webhooks.InitialBackoff,
+			MaxBackoff:     cfg.Webhooks.MaxBackoff,
+			BatchSize:      cfg.Webhooks.BatchSize,
+		},
+	))
+
+	if err := checkTicketSecret(cfg.Tickets.Secret); err != nil {
+		return nil, err
+	}
+
+	ticketService := service.NewTicketService([]byte(cfg.Tickets.Secret), cfg.Tickets.Event)
+
+	mailService, err := newMailService(cfg.Mail, guestRepository, tableRepository, reminderRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	// events of the outbox, written with the guest and table mutations and published by a worker
+	sinks, err := outbox.NewSinks(cfg.Outbox.Sinks, webhookService)
+	if err != nil {
+		return nil, err
+	}
+
+	outboxService := serviceInstrumented.NewOutboxService(service.NewOutboxService(outboxRepo, sinks, service.OutboxRetry{
+		InitialBackoff: cfg.Outbox.InitialBackoff,
+		MaxBackoff:     cfg.Outbox.MaxBackoff,
+		BatchSize:      cfg.Outbox.BatchSize,
+	}))
+
+	// cache of the seat aggregates
+	store, err := cache.NewStore(cfg.Cache)
+	if err != nil {
+		return nil, err
+	}
+
+	if store != nil {
+		if redis, ok := store.(*cache.Redis); ok {
+			healthChecker.Register("redis", redis.Ping)
+		}
+
+		guestService = cached.NewGuestService(guestService, store)
+		tableService = cached.NewTableService(tableService, store)
+		guestListService = cached.NewGuestListService(guestListService, store)
+		companionService = cached.NewCompanionService(companionService, store)
+	}
+
+	// the occupancy is derived from the seat aggregates, it is checked once the mutations went through every other layer
+	occupancy := notifying.NewOccupancy(cfg.Webhooks.OccupancyThreshold, tableService, guestListService, outboxService)
+	guestService = notifying.NewGuestService(guestService, occupancy)
+	tableService = notifying.NewTableService(tableService, occupancy)
+	companionService = notifying.NewCompanionService(companionService, occupancy)
+
+	// the forecast is checked before a guest accepts or takes a seat held for the accepted parties,
+	// one acceptance or check-in at a time
+	admissions := &sync.Mutex{}
+	if overbookingPolicy.Enabled() {
+		guestService = overbooking.NewGuestService(guestService, reportService, admissions)
+		guestListService = overbooking.NewGuestListService(guestListService, guestService, reportService)
+	}
+
+	// metrics
+	if err := metrics.Register(metrics.NewOccupancyCollector(guestService, tableService, guestListService)); err != nil {
+		return nil, err
+	}
+
+	// a check-in goes through the services as they are wrapped, whichever API it comes from
+	checkinService := service.NewCheckinService(guestService, tableService, guestListService)
+	if overbookingPolicy.Enabled() {
+		checkinService = overbooking.NewCheckinService(checkinService, admissions)
+	}
+	checkinService = serviceInstrumented.NewCheckinService(checkinService)
 
 	// controllers
-	guestController := controller.NewGuestController(guestService)
+	guestController := controller.NewGuestController(guestService, guestSearchService)
 	tableController := controller.NewTableController(tableService, guestService)
-	guestLisController := controller.NewGuestListController(guestService, tableService, guestListService)
+	guestLisController := controller.NewGuestListController(guestListService, checkinService)
+
+	var grpcServer *grpc.Server
+	if cfg.Server.Grpc.Enabled {
+		grpcServer = rpc.NewServer(cfg.Server.Grpc, cfg.Database.ReadYourWrites, guestService, tableService, guestListService, checkinService)
+	}
 
 	return &Dependecy{
 		guestController:     guestController,
 		tableController:     tableController,
 		guestListController: guestLisController,
+		webhookController:   controller.NewWebhookController(webhookService),
+		reportController:    controller.NewReportController(reportService),
+		checkinController:   controller.NewCheckinController(guestService, checkinService, ticketService, cfg.Tickets.QRSize),
+		companionController: controller.NewCompanionController(guestService, companionService),
+		mailController:      controller.NewMailController(guestService, mailService),
+		docsController:      controller.NewDocsController(),
+		graphqlHandler:      graphql.NewHandler(guestService, tableService),
+		healthChecker:       healthChecker,
+		grpcServer:          grpcServer,
+		workers: []runner{
+			worker.NewPoller("outbox", outboxService.Dispatch, cfg.Outbox.PollInterval),
+			worker.NewPoller("webhooks", webhookService.DeliverDue, cfg.Webhooks.PollInterval),
+			worker.NewPoller("reminders", mailService.SendDueReminders, cfg.Mail.PollInterval),
+		},
 	}, nil
 }
+
+// minTicketSecretLength is the size of the HMAC-SHA256 output, a shorter key weakens the signature of the tickets
+const minTicketSecretLength = 32
+
+// placeholderTicketSecrets were shipped as examples, whoever read them can forge tickets
+var placeholderTicketSecrets = []string{"change-me-in-every-environment"}
+
+// checkTicketSecret refuses the secrets anyone could guess, a forged ticket checks its holder in as any guest
+func checkTicketSecret(secret string) error {
+	if secret == "" {
+		return fmt.Errorf("tickets secret is not configured, set TICKETS_SECRET")
+	}
+
+	for _, placeholder := range placeholderTicketSecrets {
+		if secret == placeholder {
+			return fmt.Errorf("tickets secret is the published placeholder, set TICKETS_SECRET to a secret of your own")
+		}
+	}
+
+	if len(secret) < minTicketSecretLength {
+		return fmt.Errorf("tickets secret must be at least %d bytes long, it is %d", minTicketSecretLength, len(secret))
+	}
+
+	return nil
+}
+
+func newGuestSearcher(cfg config.Search, db *gorm.DB, guests port.GuestRepository) (port.GuestSearcher, error) {
+	switch cfg.Adapter {
+	case "", "fulltext":
+		return search.NewMysqlGuestSearchAdapter(db), nil
+	case "memory":
+		return search.NewMemoryGuestSearchAdapter(guests), nil
+	default:
+		return nil, fmt.Errorf("unknown search adapter %q", cfg.Adapter)
+	}
+}
+
+func newMailService(
+	cfg config.Mail,
+	guests port.GuestRepository,
+	tables port.TableRepository,
+	reminders port.ReminderRepository,
+) (port.MailService, error) {
+	mailer, err := mail.NewMailer(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := mail.NewTemplates(cfg.Templates)
+	if err != nil {
+		return nil, err
+	}
+
+	// without an event date nothing is ever due
+	var eventDate time.Time
+	if cfg.EventDate != "" {
+		if eventDate, err = venue.ParseTime(cfg.EventDate); err != nil {
+			return nil, fmt.Errorf("invalid event date: %w", err)
+		}
+	}
+
+	return serviceInstrumented.NewMailService(service.NewMailService(guests, tables, reminders, mailer, templates, service.ReminderSchedule{
+		Event:          cfg.Event,
+		EventDate:      eventDate,
+		Before:         cfg.Reminders,
+		BatchSize:      cfg.BatchSize,
+		MaxAttempts:    cfg.MaxAttempts,
+		InitialBackoff: cfg.InitialBackoff,
+		MaxBackoff:     cfg.MaxBackoff,
+	})), nil
+}
@@ -0,0 +1,15 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckTicketSecret(t *testing.T) {
+	require.ErrorContains(t, checkTicketSecret(""), "not configured")
+	require.ErrorContains(t, checkTicketSecret("change-me-in-every-environment"), "placeholder")
+	require.ErrorContains(t, checkTicketSecret(strings.Repeat("s", 31)), "at least 32 bytes")
+	require.NoError(t, checkTicketSecret(strings.Repeat("s", 32)))
+}
@@ -0,0 +1,256 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eazygood/getground-app/internal/config"
+	"github.com/eazygood/getground-app/internal/errors"
+	"github.com/eazygood/getground-app/internal/infrastructure/log"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	rateLimitLimitHeader     = "X-RateLimit-Limit"
+	rateLimitRemainingHeader = "X-RateLimit-Remaining"
+	rateLimitResetHeader     = "X-RateLimit-Reset"
+
+	// how often the in-memory store forgets the buckets that are full again
+	rateLimitSweepInterval = time.Minute
+)
+
+// RateLimitStore keeps the token buckets, in memory by default. Another store, e.g. one shared
+// by every instance, only has to implement Take.
+type RateLimitStore interface {
+	// Take removes a token from the bucket of key, refilled according to budget
+	Take(ctx context.Context, key string, budget config.RateLimitBudget) (RateLimitDecision, error)
+}
+
+type RateLimitDecision struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// RetryAfter is how long until a token is available again, when the request is not allowed
+	RetryAfter time.Duration
+	// Reset is how long until the bucket is full again
+	Reset time.Duration
+}
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+	// fullAt is when the bucket is refilled, afterwards it is the same as a new one
+	fullAt time.Time
+}
+
+type memoryRateLimitStore struct {
+	mu         sync.Mutex
+	buckets    map[string]*bucket
+	maxBuckets int
+	lastSweep  time.Time
+	now        func() time.Time
+}
+
+// NewMemoryRateLimitStore keeps up to maxBuckets buckets, without a bound when it is not positive
+func NewMemoryRateLimitStore(maxBuckets int) RateLimitStore {
+	return &memoryRateLimitStore{
+		buckets:    make(map[string]*bucket),
+		maxBuckets: maxBuckets,
+		lastSweep:  time.Now(),
+		now:        time.Now,
+	}
+}
+
+func (s *memoryRateLimitStore) Take(_ context.Context, key string, budget config.RateLimitBudget) (RateLimitDecision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	burst := float64(budget.Burst)
+	rate := float64(budget.Requests) / budget.Period.Seconds()
+
+	b, ok := s.buckets[key]
+	if !ok {
+		s.makeRoom(now)
+		b = &bucket{tokens: burst, updatedAt: now}
+		s.buckets[key] = b
+	}
+
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.updatedAt).Seconds()*rate)
+	b.updatedAt = now
+
+	decision := RateLimitDecision{Limit: budget.Burst}
+	if b.tokens >= 1 {
+		b.tokens--
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = seconds((1 - b.tokens) / rate)
+	}
+
+	decision.Remaining = int(b.tokens)
+	decision.Reset = seconds((burst - b.tokens) / rate)
+	b.fullAt = now.Add(decision.Reset)
+
+	s.sweep(now)
+
+	return decision, nil
+}
+
+// sweep forgets the buckets idle long enough to be full again
+func (s *memoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+
+	s.lastSweep = now
+	for key, b := range s.buckets {
+		if !now.Before(b.fullAt) {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+// makeRoom forgets the bucket closest to full when there are maxBuckets already, a forgotten bucket
+// starts full again, which is the least a client can gain from it
+func (s *memoryRateLimitStore) makeRoom(now time.Time) {
+	if s.maxBuckets <= 0 || len(s.buckets) < s.maxBuckets {
+		return
+	}
+
+	s.lastSweep = time.Time{}
+	s.sweep(now)
+
+	for len(s.buckets) >= s.maxBuckets {
+		var fullest string
+		for key, b := range s.buckets {
+			if fullest == "" || b.fullAt.Before(s.buckets[fullest].fullAt) {
+				fullest = key
+			}
+		}
+
+		delete(s.buckets, fullest)
+	}
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+// rateLimit throttles every client, identified by its API key when it is a known one or else its IP, with a token
+// bucket per budget: reads and writes have their own, and routes listed in the configuration have a dedicated one
+func rateLimit(cfg config.RateLimit, store RateLimitStore) gin.HandlerFunc {
+	apiKeys := make(map[string]bool, len(cfg.APIKeys))
+	for _, apiKey := range cfg.APIKeys {
+		apiKeys[digest(apiKey)] = true
+	}
+
+	routes := make(map[string]config.RateLimitBudget, len(cfg.Routes))
+	for route, budget := range cfg.Routes {
+		routes[routeKey(route)] = normalizeBudget(budget)
+	}
+
+	read := normalizeBudget(cfg.Read)
+	write := normalizeBudget(cfg.Write)
+
+	return func(request *gin.Context) {
+		route := request.FullPath()
+		if route == "" {
+			request.Next()
+			return
+		}
+
+		name := routeKey(request.Request.Method + " " + route)
+		budget, ok := routes[name]
+		if !ok {
+			name, budget = "read", read
+			if isWrite(request.Request.Method) {
+				name, budget = "write", write
+			}
+		}
+
+		if budget.Requests <= 0 {
+			request.Next()
+			return
+		}
+
+		decision, err := store.Take(request, clientKey(request, cfg.APIKeyHeader, apiKeys)+"|"+name, budget)
+		if err != nil {
+			// the limiter protects the API, it must not take it down with it
+			log.FromContext(request).Warnf("failed to apply rate limit: %v", err)
+			request.Next()
+			return
+		}
+
+		request.Header(rateLimitLimitHeader, strconv.Itoa(decision.Limit))
+		request.Header(rateLimitRemainingHeader, strconv.Itoa(decision.Remaining))
+		request.Header(rateLimitResetHeader, strconv.Itoa(ceilSeconds(decision.Reset)))
+
+		if !decision.Allowed {
+			apiError := errors.NewApiError(errors.TooManyRequests, fmt.Errorf("rate limit of %s requests exceeded", name))
+
+			log.FromContext(request).WithField("code", apiError.Code).Warn(apiError.Message)
+			request.Header("Retry-After", strconv.Itoa(ceilSeconds(decision.RetryAfter)))
+			request.AbortWithStatusJSON(apiError.Code, apiError)
+			return
+		}
+
+		request.Next()
+	}
+}
+
+// clientKey identifies the client by a digest of its API key, so the keys are not kept in the store. A key that
+// is not one of apiKeys counts for nothing, or a client sending a new one every time would always have tokens.
+func clientKey(request *gin.Context, apiKeyHeader string, apiKeys map[string]bool) string {
+	if apiKeyHeader != "" {
+		if apiKey := request.GetHeader(apiKeyHeader); apiKey != "" {
+			if key := digest(apiKey); apiKeys[key] {
+				return "key:" + key
+			}
+		}
+	}
+
+	return "ip:" + request.ClientIP()
+}
+
+func digest(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:])
+}
+
+// routeKey normalizes "GET /guests" the way the configuration loader lowercases map keys
+func routeKey(route string) string {
+	return strings.ToLower(strings.Join(strings.Fields(route), " "))
+}
+
+func normalizeBudget(budget config.RateLimitBudget) config.RateLimitBudget {
+	if budget.Period <= 0 {
+		budget.Period = time.Second
+	}
+
+	if budget.Burst < budget.Requests {
+		budget.Burst = budget.Requests
+	}
+
+	return budget
+}
+
+func isWrite(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	default:
+		return true
+	}
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
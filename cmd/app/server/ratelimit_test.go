@@ -0,0 +1,197 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eazygood/getground-app/internal/config"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type RateLimitSuite struct {
+	suite.Suite
+	*require.Assertions
+	now    time.Time
+	store  *memoryRateLimitStore
+	router *gin.Engine
+}
+
+func TestRateLimitSuite(t *testing.T) {
+	suite.Run(t, new(RateLimitSuite))
+}
+
+func (r *RateLimitSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	r.Assertions = require.New(r.T())
+
+	r.now = time.Now()
+	r.store = NewMemoryRateLimitStore(0).(*memoryRateLimitStore)
+	r.store.now = func() time.Time { return r.now }
+	r.store.lastSweep = r.now
+
+	r.router = r.newRouter(r.store)
+}
+
+func (r *RateLimitSuite) newRouter(store RateLimitStore) *gin.Engine {
+	cfg := config.RateLimit{
+		Enabled:      true,
+		APIKeyHeader: "X-API-Key",
+		APIKeys:      []string{"dashboard", "script"},
+		Read:         config.RateLimitBudget{Requests: 2, Period: time.Second},
+		Write:        config.RateLimitBudget{Requests: 1, Period: time.Second},
+		Routes: map[string]config.RateLimitBudget{
+			"get /tables/empty_seats": {Requests: 1, Period: time.Minute},
+		},
+	}
+
+	router := gin.New()
+	router.GET("/livez", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.Use(rateLimit(cfg, store))
+	router.GET("/guests", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.POST("/guests", func(c *gin.Context) { c.Status(http.StatusCreated) })
+	router.GET("/tables/empty_seats", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	return router
+}
+
+func (r *RateLimitSuite) serve(method string, path string, apiKey string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	if apiKey != "" {
+		req.Header.Set("X-API-Key", apiKey)
+	}
+
+	w := httptest.NewRecorder()
+	r.router.ServeHTTP(w, req)
+
+	return w
+}
+
+func (r *RateLimitSuite) TestLimitsReads() {
+	w := r.serve(http.MethodGet, "/guests", "")
+	r.Equal(http.StatusOK, w.Code)
+	r.Equal("2", w.Header().Get(rateLimitLimitHeader))
+	r.Equal("1", w.Header().Get(rateLimitRemainingHeader))
+
+	r.Equal(http.StatusOK, r.serve(http.MethodGet, "/guests", "").Code)
+
+	w = r.serve(http.MethodGet, "/guests", "")
+	r.Equal(http.StatusTooManyRequests, w.Code)
+	r.Equal("1", w.Header().Get("Retry-After"))
+	r.Equal("0", w.Header().Get(rateLimitRemainingHeader))
+	r.JSONEq(`{"code":429,"message":"rate limit of read requests exceeded"}`, w.Body.String())
+}
+
+func (r *RateLimitSuite) TestRefillsOverTime() {
+	r.Equal(http.StatusCreated, r.serve(http.MethodPost, "/guests", "").Code)
+	r.Equal(http.StatusTooManyRequests, r.serve(http.MethodPost, "/guests", "").Code)
+
+	r.now = r.now.Add(time.Second)
+
+	r.Equal(http.StatusCreated, r.serve(http.MethodPost, "/guests", "").Code)
+}
+
+func (r *RateLimitSuite) TestWritesHaveTheirOwnBudget() {
+	r.Equal(http.StatusCreated, r.serve(http.MethodPost, "/guests", "").Code)
+	r.Equal(http.StatusTooManyRequests, r.serve(http.MethodPost, "/guests", "").Code)
+
+	r.Equal(http.StatusOK, r.serve(http.MethodGet, "/guests", "").Code)
+}
+
+func (r *RateLimitSuite) TestRouteBudget() {
+	w := r.serve(http.MethodGet, "/tables/empty_seats", "")
+	r.Equal(http.StatusOK, w.Code)
+	r.Equal("60", w.Header().Get(rateLimitResetHeader))
+
+	w = r.serve(http.MethodGet, "/tables/empty_seats", "")
+	r.Equal(http.StatusTooManyRequests, w.Code)
+	r.Equal("60", w.Header().Get("Retry-After"))
+
+	r.Equal(http.StatusOK, r.serve(http.MethodGet, "/guests", "").Code)
+}
+
+func (r *RateLimitSuite) TestKeysByAPIKey() {
+	r.Equal(http.StatusCreated, r.serve(http.MethodPost, "/guests", "dashboard").Code)
+	r.Equal(http.StatusTooManyRequests, r.serve(http.MethodPost, "/guests", "dashboard").Code)
+
+	r.Equal(http.StatusCreated, r.serve(http.MethodPost, "/guests", "script").Code)
+	r.Equal(http.StatusCreated, r.serve(http.MethodPost, "/guests", "").Code)
+}
+
+func (r *RateLimitSuite) TestUnknownAPIKeysShareTheIPBucket() {
+	r.Equal(http.StatusCreated, r.serve(http.MethodPost, "/guests", "made-up-1").Code)
+	r.Equal(http.StatusTooManyRequests, r.serve(http.MethodPost, "/guests", "made-up-2").Code)
+	r.Equal(http.StatusTooManyRequests, r.serve(http.MethodPost, "/guests", "").Code)
+}
+
+func (r *RateLimitSuite) TestIgnoresForwardedForFromUntrustedProxies() {
+	r.NoError(r.router.SetTrustedProxies(nil))
+
+	for i, ip := range []string{"203.0.113.1", "203.0.113.2"} {
+		req := httptest.NewRequest(http.MethodPost, "/guests", nil)
+		req.Header.Set("X-Forwarded-For", ip)
+		w := httptest.NewRecorder()
+		r.router.ServeHTTP(w, req)
+
+		if i == 0 {
+			r.Equal(http.StatusCreated, w.Code)
+		} else {
+			r.Equal(http.StatusTooManyRequests, w.Code)
+		}
+	}
+}
+
+func (r *RateLimitSuite) TestBoundsTheBuckets() {
+	r.store.maxBuckets = 2
+
+	r.serve(http.MethodGet, "/tables/empty_seats", "")
+	r.serve(http.MethodGet, "/guests", "")
+	r.serve(http.MethodPost, "/guests", "")
+
+	// the read bucket is the closest to full, the empty seats one needs a minute
+	r.Len(r.store.buckets, 2)
+	r.Contains(r.store.buckets, "ip:192.0.2.1|get /tables/empty_seats")
+	r.Contains(r.store.buckets, "ip:192.0.2.1|write")
+}
+
+func (r *RateLimitSuite) TestSkipsRoutesRegisteredBefore() {
+	for i := 0; i < 5; i++ {
+		w := r.serve(http.MethodGet, "/livez", "")
+		r.Equal(http.StatusOK, w.Code)
+		r.Empty(w.Header().Get(rateLimitLimitHeader))
+	}
+}
+
+func (r *RateLimitSuite) TestSweepsFullBuckets() {
+	r.serve(http.MethodGet, "/guests", "")
+	r.now = r.now.Add(rateLimitSweepInterval / 2)
+	r.serve(http.MethodGet, "/tables/empty_seats", "")
+	r.Len(r.store.buckets, 2)
+
+	r.now = r.now.Add(rateLimitSweepInterval / 2)
+	r.serve(http.MethodPost, "/guests", "")
+
+	// the read bucket refilled within a second, the empty seats one needs a minute
+	r.Len(r.store.buckets, 2)
+	r.Contains(r.store.buckets, "ip:192.0.2.1|get /tables/empty_seats")
+	r.Contains(r.store.buckets, "ip:192.0.2.1|write")
+}
+
+type failingRateLimitStore struct{}
+
+func (failingRateLimitStore) Take(context.Context, string, config.RateLimitBudget) (RateLimitDecision, error) {
+	return RateLimitDecision{}, errors.New("store unavailable")
+}
+
+func (r *RateLimitSuite) TestFailingStoreLetsRequestsThrough() {
+	r.router = r.newRouter(failingRateLimitStore{})
+
+	for i := 0; i < 3; i++ {
+		r.Equal(http.StatusCreated, r.serve(http.MethodPost, "/guests", "").Code)
+	}
+}
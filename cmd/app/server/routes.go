@@ -5,15 +5,40 @@ import "github.com/gin-gonic/gin"
 func initRoutes(router *gin.Engine, dependency *Dependecy) {
 	router.POST("/guests", dependency.guestController.Create)
 	router.PUT("/guests/:guest_id", dependency.guestController.Update)
+	router.PATCH("/guests/:guest_id", dependency.guestController.Patch)
 	router.GET("/guests/:guest_id", dependency.guestController.GetById)
 	router.GET("/guests", dependency.guestController.GetList)
+	router.GET("/guests/search", dependency.guestController.Search)
 	router.DELETE("/guests/:guest_id", dependency.guestController.Delete)
+	router.POST("/guests/:guest_id/restore", dependency.guestController.Restore)
+	router.POST("/guests/:guest_id/rsvp", dependency.guestController.RSVP)
+	router.POST("/guests/:guest_id/no_show", dependency.guestController.NoShow)
+	router.GET("/guests/:guest_id/ticket.png", dependency.checkinController.Ticket)
+	router.GET("/guests/:guest_id/companions", dependency.companionController.GetList)
+	router.POST("/guests/:guest_id/companions", dependency.companionController.Add)
+	router.DELETE("/guests/:guest_id/companions/:companion_id", dependency.companionController.Remove)
+	router.POST("/guests/:guest_id/emails", dependency.mailController.Send)
+
+	router.POST("/checkin/scan", dependency.checkinController.Scan)
 
 	router.POST("/guestlist", dependency.guestListController.Create)
 	router.GET("/guestlist", dependency.guestListController.GetList)
 
 	router.POST("/tables/", dependency.tableController.Create)
 	router.PUT("/tables/:table_id", dependency.tableController.Update)
+	router.PATCH("/tables/:table_id", dependency.tableController.Patch)
+	router.GET("/tables/:table_id", dependency.tableController.GetById)
 	router.GET("/tables/empty_seats", dependency.tableController.GetEmptySeats)
 	router.DELETE("/tables/:table_id", dependency.tableController.Delete)
+	router.POST("/tables/:table_id/restore", dependency.tableController.Restore)
+
+	router.GET("/reports/dietary", dependency.reportController.Dietary)
+	router.GET("/reports/capacity", dependency.reportController.Capacity)
+	router.GET("/reports/forecast", dependency.reportController.Forecast)
+
+	router.POST("/webhooks", dependency.webhookController.Create)
+	router.GET("/webhooks", dependency.webhookController.GetList)
+	router.GET("/webhooks/:webhook_id", dependency.webhookController.GetById)
+	router.DELETE("/webhooks/:webhook_id", dependency.webhookController.Delete)
+	router.GET("/webhooks/:webhook_id/deliveries", dependency.webhookController.GetDeliveries)
 }
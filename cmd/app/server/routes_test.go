@@ -0,0 +1,60 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/eazygood/getground-app/internal/api/controller"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+var ginParam = regexp.MustCompile(`:(\w+)`)
+
+func TestRoutesAreDocumented(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	initRoutes(router, &Dependecy{
+		guestController:     controller.NewGuestController(nil, nil),
+		tableController:     controller.NewTableController(nil, nil),
+		guestListController: controller.NewGuestListController(nil, nil),
+		webhookController:   controller.NewWebhookController(nil),
+		checkinController:   controller.NewCheckinController(nil, nil, nil, 0),
+		reportController:    controller.NewReportController(nil),
+		companionController: controller.NewCompanionController(nil, nil),
+		mailController:      controller.NewMailController(nil, nil),
+	})
+
+	doc, err := controller.OpenAPI()
+	require.NoError(t, err)
+
+	documented := 0
+	for _, item := range doc.Paths {
+		documented += len(item.Operations())
+	}
+
+	routes := router.Routes()
+	for _, route := range routes {
+		path := ginParam.ReplaceAllString(route.Path, "{$1}")
+
+		item, ok := doc.Paths[path]
+		require.True(t, ok, "%s %s is missing from the OpenAPI document", route.Method, route.Path)
+		require.NotNil(t, item.GetOperation(route.Method), "%s %s is missing from the OpenAPI document", route.Method, route.Path)
+	}
+
+	require.Equal(t, len(routes), documented, "the OpenAPI document has operations no route serves")
+}
+
+func TestDocsAreServed(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.StaticFS("/docs/assets", controller.SwaggerAssets)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/assets/swagger-ui-bundle.js", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotZero(t, w.Body.Len())
+}
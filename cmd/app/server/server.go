@@ -2,28 +2,62 @@ package server
 
 import (
 	"context"
+	"net"
 	"net/http"
+	"sync"
+	"time"
 
+	"github.com/eazygood/getground-app/internal/api/controller"
 	"github.com/eazygood/getground-app/internal/config"
+	mysql "github.com/eazygood/getground-app/internal/infrastructure/db"
+	"github.com/eazygood/getground-app/internal/infrastructure/health"
+	"github.com/eazygood/getground-app/internal/infrastructure/log"
+	"github.com/eazygood/getground-app/internal/infrastructure/metrics"
+	"github.com/eazygood/getground-app/internal/infrastructure/tracing"
 	"github.com/gin-gonic/gin"
 	logger "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
 )
 
 func Start(ctx context.Context, cfg config.App) {
-	dependencies, err := initDependencies(&cfg)
+	dependencies, err := initDependencies(ctx, &cfg)
 	if err != nil {
 		panic(err)
 	}
 
 	router := gin.New()
-	router.GET("/health", func(request *gin.Context) { request.String(http.StatusOK, "pong") })
+	// the client IP limits the rate of requests, it is only taken from X-Forwarded-For behind a known proxy
+	if err := router.SetTrustedProxies(cfg.Server.Http.TrustedProxies); err != nil {
+		panic(err)
+	}
+
+	// lets services reach values of the request context, such as the request scoped logger,
+	// through the *gin.Context controllers hand them
+	router.ContextWithFallback = true
+	router.Use(tracing.Middleware(), log.Middleware(), metrics.Middleware())
+	if cfg.Database.ReadYourWrites {
+		router.Use(mysql.TrackWrites())
+	}
+	router.GET("/livez", dependencies.healthChecker.Liveness)
+	router.GET("/readyz", dependencies.healthChecker.Readiness)
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
+	router.GET("/openapi.json", dependencies.docsController.OpenAPI)
+	router.GET("/docs", dependencies.docsController.SwaggerUI)
+	router.StaticFS("/docs/assets", controller.SwaggerAssets)
+
+	// probes, scrapes and docs are registered above, out of reach of the limiter
+	if cfg.Server.RateLimit.Enabled {
+		router.Use(rateLimit(cfg.Server.RateLimit, NewMemoryRateLimitStore(cfg.Server.RateLimit.MaxBuckets)))
+	}
 
 	initRoutes(router, dependencies)
+	// described by its own schema rather than the OpenAPI document
+	router.POST("/graphql", gin.WrapH(dependencies.graphqlHandler))
 
-	run(ctx, router, cfg.Server)
+	run(ctx, router, dependencies.grpcServer, dependencies.workers, cfg.Server, dependencies.healthChecker)
 }
 
-func run(ctx context.Context, router *gin.Engine, cfg config.Server) {
+func run(ctx context.Context, router *gin.Engine, grpcServer *grpc.Server, workers []runner, cfg config.Server, healthChecker *health.HealthChecker) {
 	logger.Info(cfg.Http.Host + ":" + cfg.Http.Port)
 	srv := &http.Server{
 		Addr:    cfg.Http.Host + ":" + cfg.Http.Port,
@@ -36,13 +70,68 @@ func run(ctx context.Context, router *gin.Engine, cfg config.Server) {
 		}
 	}()
 
+	if grpcServer != nil {
+		listener, err := net.Listen("tcp", cfg.Grpc.Host+":"+cfg.Grpc.Port)
+		if err != nil {
+			logger.Fatalf("failed to listen for grpc: %s\n", err)
+		}
+
+		logger.Info("grpc " + listener.Addr().String())
+		go func() {
+			if err := grpcServer.Serve(listener); err != nil {
+				logger.Fatalf("failed to start grpc server: %s\n", err)
+			}
+		}()
+	}
+
+	// workers get their own context, they keep going until the servers stopped taking requests
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	var running sync.WaitGroup
+	for _, w := range workers {
+		running.Add(1)
+		go func(w runner) {
+			defer running.Done()
+			w.Run(workerCtx)
+		}(w)
+	}
+
 	<-ctx.Done()
 
+	// fail readiness first and keep serving for a while, so that traffic is moved away
+	// before the listener is closed
+	healthChecker.Drain()
+	time.Sleep(cfg.Http.DrainDelay)
+
 	shutdownCtx, cancelFn := context.WithTimeout(context.Background(), cfg.Http.ShutdownTimeout)
 	defer cancelFn()
 	if err := srv.Shutdown(shutdownCtx); err != nil {
 		logger.Fatal("server shutdown: ", err)
 	}
 
+	// occupancy streams only end with their client, so the grpc server goes last
+	if grpcServer != nil {
+		stopGrpc(shutdownCtx, grpcServer)
+	}
+
+	stopWorkers()
+	running.Wait()
+
 	logger.Info("server exiting")
 }
+
+// stopGrpc lets the in-flight calls finish, the streams that are still open when ctx is done are cut
+func stopGrpc(ctx context.Context, server *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		server.Stop()
+	}
+}
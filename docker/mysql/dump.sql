@@ -6,19 +6,114 @@ CREATE TABLE IF NOT EXISTS `database`.`guests` (
 	`id` INT NOT NULL auto_increment,
 	`name` VARCHAR(255),
 	`accompanying_guests` SMALLINT,
+	-- the accompanying guests who are not named as companions
+	`unnamed_guests` SMALLINT NOT NULL DEFAULT 0,
 	`time_arrived` TIMESTAMP NULL DEFAULT NULL,
 	`is_arrived` BOOLEAN DEFAULT false,
-	PRIMARY KEY (`id`)
+	`email` VARCHAR(254) NOT NULL DEFAULT '',
+	`phone` VARCHAR(32) NOT NULL DEFAULT '',
+	`company` VARCHAR(100) NOT NULL DEFAULT '',
+	`dietary` SET('vegetarian', 'vegan', 'pescatarian', 'gluten_free', 'dairy_free', 'nut_allergy', 'halal', 'kosher') NOT NULL DEFAULT '',
+	`dietary_notes` VARCHAR(500) NOT NULL DEFAULT '',
+	`accessibility` VARCHAR(500) NOT NULL DEFAULT '',
+	`notes` VARCHAR(1000) NOT NULL DEFAULT '',
+	`status` ENUM('invited', 'accepted', 'declined', 'tentative', 'arrived', 'left', 'no_show') NOT NULL DEFAULT 'invited',
+	`party_size` SMALLINT NOT NULL DEFAULT 0,
+	`responded_at` TIMESTAMP NULL DEFAULT NULL,
+	`version` INT NOT NULL DEFAULT 1,
+	`deleted_at` TIMESTAMP NULL DEFAULT NULL,
+	PRIMARY KEY (`id`),
+	INDEX `idx_guests_deleted_at` (`deleted_at`),
+	INDEX `idx_guests_company` (`company`),
+	INDEX `idx_guests_status` (`status`),
+	FULLTEXT INDEX `idx_guests_name` (`name`) WITH PARSER ngram
+) ENGINE InnoDB DEFAULT CHARSET = `utf8`;
+
+-- companions are the named accompanying guests of a guest
+CREATE TABLE IF NOT EXISTS `database`.`companions` (
+	`id` INT NOT NULL auto_increment,
+	`guest_id` INT NOT NULL,
+	`name` VARCHAR(100) NOT NULL,
+	`dietary` SET('vegetarian', 'vegan', 'pescatarian', 'gluten_free', 'dairy_free', 'nut_allergy', 'halal', 'kosher') NOT NULL DEFAULT '',
+	`dietary_notes` VARCHAR(500) NOT NULL DEFAULT '',
+	`is_arrived` BOOLEAN NOT NULL DEFAULT false,
+	`left_at` TIMESTAMP NULL DEFAULT NULL,
+	PRIMARY KEY (`id`),
+	INDEX `idx_companions_guest_id` (`guest_id`),
+	CONSTRAINT `fk_companion_guest` FOREIGN KEY (`guest_id`) REFERENCES `database`.`guests`(`id`) ON DELETE CASCADE
+) ENGINE InnoDB DEFAULT CHARSET = `utf8`;
+
+-- guest_reminders records the reminders sent, so every instance sends each of them once, and the failed ones
+-- with when they are attempted again
+CREATE TABLE IF NOT EXISTS `database`.`guest_reminders` (
+	`guest_id` INT NOT NULL,
+	`due_at` TIMESTAMP NOT NULL,
+	`attempts` INT NOT NULL DEFAULT 0,
+	`sent_at` TIMESTAMP NULL DEFAULT NULL,
+	`next_attempt_at` TIMESTAMP NULL DEFAULT NULL,
+	`last_error` TEXT NULL,
+	PRIMARY KEY (`guest_id`, `due_at`),
+	CONSTRAINT `fk_reminder_guest` FOREIGN KEY (`guest_id`) REFERENCES `database`.`guests`(`id`) ON DELETE CASCADE
 ) ENGINE InnoDB DEFAULT CHARSET = `utf8`;
 
 CREATE TABLE IF NOT EXISTS `database`.`tables` (
 	`id` INT NOT NULL auto_increment,
 	`seats` SMALLINT DEFAULT 0,
 	`guest_id` INT NULL ,
+	`version` INT NOT NULL DEFAULT 1,
+	`deleted_at` TIMESTAMP NULL DEFAULT NULL,
 	PRIMARY KEY (`id`),
+	INDEX `idx_tables_deleted_at` (`deleted_at`),
 	CONSTRAINT `fk_guest` FOREIGN KEY (`guest_id`) REFERENCES `database`.`guests`(`id`) ON DELETE SET NULL ON UPDATE SET NULL
 ) ENGINE InnoDB DEFAULT CHARSET = `utf8`;
 
+-- events of the guest and table mutations, written in their transaction and published by the outbox worker
+CREATE TABLE IF NOT EXISTS `database`.`outbox` (
+	`id` BIGINT NOT NULL auto_increment,
+	`event_id` CHAR(32) NOT NULL,
+	`event_type` VARCHAR(64) NOT NULL,
+	`payload` JSON NOT NULL,
+	`attempts` INT NOT NULL DEFAULT 0,
+	`next_attempt_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	`last_error` TEXT NULL,
+	`created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	`published_at` TIMESTAMP NULL DEFAULT NULL,
+	PRIMARY KEY (`id`),
+	UNIQUE KEY `uq_outbox_event` (`event_id`),
+	INDEX `idx_outbox_pending` (`published_at`, `next_attempt_at`)
+) ENGINE InnoDB DEFAULT CHARSET = `utf8`;
+
+CREATE TABLE IF NOT EXISTS `database`.`webhooks` (
+	`id` INT NOT NULL auto_increment,
+	`url` VARCHAR(2048) NOT NULL,
+	`secret` VARCHAR(128) NOT NULL,
+	`event_types` TEXT NOT NULL,
+	`created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	`deleted_at` TIMESTAMP NULL DEFAULT NULL,
+	PRIMARY KEY (`id`),
+	INDEX `idx_webhooks_deleted_at` (`deleted_at`)
+) ENGINE InnoDB DEFAULT CHARSET = `utf8`;
+
+-- the outbox of the webhooks, pending rows are attempted by the dispatcher and kept as the delivery history
+CREATE TABLE IF NOT EXISTS `database`.`webhook_deliveries` (
+	`id` BIGINT NOT NULL auto_increment,
+	`webhook_id` INT NOT NULL,
+	`event_id` CHAR(32) NOT NULL,
+	`event_type` VARCHAR(64) NOT NULL,
+	`payload` JSON NOT NULL,
+	`status` VARCHAR(16) NOT NULL DEFAULT 'pending',
+	`attempts` INT NOT NULL DEFAULT 0,
+	`next_attempt_at` TIMESTAMP NULL DEFAULT NULL,
+	`last_error` TEXT NULL,
+	`response_status` SMALLINT NOT NULL DEFAULT 0,
+	`created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	`delivered_at` TIMESTAMP NULL DEFAULT NULL,
+	PRIMARY KEY (`id`),
+	UNIQUE KEY `uq_webhook_deliveries_event` (`webhook_id`, `event_id`),
+	INDEX `idx_webhook_deliveries_due` (`status`, `next_attempt_at`),
+	CONSTRAINT `fk_webhook` FOREIGN KEY (`webhook_id`) REFERENCES `database`.`webhooks`(`id`)
+) ENGINE InnoDB DEFAULT CHARSET = `utf8`;
+
 -- CREATE TABLE `guestlist` (
 --   `guest_id` INT,
 --   `table_id` INT
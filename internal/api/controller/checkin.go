@@ -0,0 +1,118 @@
+package controller
+
+import (
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	"github.com/eazygood/getground-app/internal/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/skip2/go-qrcode"
+)
+
+type CheckinController interface {
+	Ticket(request *gin.Context)
+	Scan(request *gin.Context)
+}
+
+type CheckinScanRequest struct {
+	// Token is the content of the QR code of the ticket
+	Token              string `json:"token" validate:"required"`
+	AccompanyingGuests int    `json:"accompanying_guests" validate:"min=0,party_size"`
+}
+
+// CheckinResponse tells the door staff where the party of the guest is seated
+type CheckinResponse struct {
+	GuestID            int64  `json:"guest_id"`
+	Name               string `json:"name"`
+	TableID            int64  `json:"table_id"`
+	AccompanyingGuests uint16 `json:"accompanying_guests"`
+}
+
+type checkinController struct {
+	guestService   port.GuestService
+	checkinService port.CheckinService
+	ticketService  port.TicketService
+	qrSize         int
+}
+
+func NewCheckinController(guest port.GuestService, checkin port.CheckinService, ticket port.TicketService, qrSize int) CheckinController {
+	return &checkinController{
+		guestService:   guest,
+		checkinService: checkin,
+		ticketService:  ticket,
+		qrSize:         qrSize,
+	}
+}
+
+// Ticket renders the ticket of a guest as a PNG QR code of its token
+func (c *checkinController) Ticket(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("guest_id"))
+
+	if err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
+		return
+	}
+
+	guest, err := c.guestService.GetById(ctx, int64(id))
+	if err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.NotFound, err))
+		return
+	}
+
+	png, err := qrcode.Encode(c.ticketService.Issue(guest.ID), qrcode.Medium, c.qrSize)
+	if err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
+		return
+	}
+
+	ctx.Data(http.StatusOK, "image/png", png)
+}
+
+// Scan checks in the guest a scanned ticket was issued to, the same way as the guest list does.
+// A ticket is used once: it is rejected while its guest is seated and after they left.
+func (c *checkinController) Scan(ctx *gin.Context) {
+	body := &CheckinScanRequest{}
+	if !bindJSON(ctx, body) {
+		return
+	}
+
+	guestID, err := c.ticketService.Verify(body.Token)
+	if err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.Unprocessable, errors.NewValidationError(errors.FieldError{
+			Field:   "token",
+			Rule:    "ticket",
+			Message: "token is not a valid ticket",
+		})))
+
+		return
+	}
+
+	table, err := c.checkinService.CheckIn(ctx, guestID, uint16(body.AccompanyingGuests))
+
+	var checkedIn *domain.CheckedInError
+	if stderrors.As(err, &checkedIn) {
+		state := "they are seated"
+		if checkedIn.Left {
+			state = "they left"
+		}
+
+		logAndAbort(ctx, errors.NewApiError(errors.Conflict, fmt.Errorf("ticket of guest %v was already used, %s", guestID, state)))
+		return
+	}
+
+	if err != nil {
+		abortWithoutTable(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, CheckinResponse{
+		GuestID:            guestID,
+		Name:               table.Guest.Name,
+		TableID:            table.ID,
+		AccompanyingGuests: table.Guest.AccompanyingGuests,
+	})
+}
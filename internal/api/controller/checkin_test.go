@@ -0,0 +1,150 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/eazygood/getground-app/internal/api/controller/testutil"
+	"github.com/eazygood/getground-app/internal/core/domain"
+	mockPort "github.com/eazygood/getground-app/mocks/core/port"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type CheckinControllerSuite struct {
+	suite.Suite
+	*require.Assertions
+	ctrl               *gomock.Controller
+	mockGuestService   *mockPort.MockGuestService
+	mockCheckinService *mockPort.MockCheckinService
+	mockTicketService  *mockPort.MockTicketService
+	checkinController  CheckinController
+}
+
+func TestCheckinControllerSuite(t *testing.T) {
+	suite.Run(t, new(CheckinControllerSuite))
+}
+
+func (g *CheckinControllerSuite) SetupTest() {
+	g.Assertions = require.New(g.T())
+	g.ctrl = gomock.NewController(g.T())
+	g.mockGuestService = mockPort.NewMockGuestService(g.ctrl)
+	g.mockCheckinService = mockPort.NewMockCheckinService(g.ctrl)
+	g.mockTicketService = mockPort.NewMockTicketService(g.ctrl)
+	g.checkinController = NewCheckinController(g.mockGuestService, g.mockCheckinService, g.mockTicketService, 128)
+}
+
+func (g *CheckinControllerSuite) TearDownTest() {
+	g.ctrl.Finish()
+}
+
+func (g *CheckinControllerSuite) TestTicket() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonGet(c, gin.Params{{Key: "guest_id", Value: "1"}}, url.Values{})
+
+	g.mockGuestService.EXPECT().GetById(c, int64(1)).Return(&domain.Guest{ID: 1, Name: "Simon"}, nil).Times(1)
+	g.mockTicketService.EXPECT().Issue(int64(1)).Return("1.signature").Times(1)
+
+	g.checkinController.Ticket(c)
+
+	g.EqualValues(http.StatusOK, w.Code)
+	g.Equal("image/png", w.Header().Get("Content-Type"))
+
+	img, err := png.Decode(bytes.NewReader(w.Body.Bytes()))
+	g.NoError(err)
+	g.Equal(128, img.Bounds().Dx())
+}
+
+func (g *CheckinControllerSuite) TestTicketUnknownGuest() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonGet(c, gin.Params{{Key: "guest_id", Value: "9"}}, url.Values{})
+
+	g.mockGuestService.EXPECT().GetById(c, int64(9)).Return(nil, errors.New("record not found by id: 9")).Times(1)
+
+	g.checkinController.Ticket(c)
+
+	g.EqualValues(http.StatusNotFound, w.Code)
+}
+
+func (g *CheckinControllerSuite) TestScan() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonPost(c, CheckinScanRequest{Token: "1.signature", AccompanyingGuests: 2})
+
+	guestID := int64(1)
+	table := domain.Table{ID: 2, Seats: 4, GuestID: &guestID, Version: 4, Guest: domain.Guest{ID: 1, Name: "Simon", AccompanyingGuests: 2, IsArrived: true}}
+
+	g.mockTicketService.EXPECT().Verify("1.signature").Return(int64(1), nil).Times(1)
+	g.mockCheckinService.EXPECT().CheckIn(c, guestID, uint16(2)).Return(&table, nil).Times(1)
+
+	g.checkinController.Scan(c)
+
+	g.EqualValues(http.StatusOK, w.Code)
+
+	got := CheckinResponse{}
+	g.NoError(json.Unmarshal(w.Body.Bytes(), &got))
+	g.Equal(CheckinResponse{GuestID: 1, Name: "Simon", TableID: 2, AccompanyingGuests: 2}, got)
+}
+
+func (g *CheckinControllerSuite) TestScanInvalidToken() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonPost(c, CheckinScanRequest{Token: "1.forged"})
+
+	g.mockTicketService.EXPECT().Verify("1.forged").Return(int64(0), errors.New("invalid ticket")).Times(1)
+
+	g.checkinController.Scan(c)
+
+	g.EqualValues(http.StatusUnprocessableEntity, w.Code)
+
+	wantJson := `{"code":422,"message":"validation failed","errors":[` +
+		`{"field":"token","rule":"ticket","message":"token is not a valid ticket"}]}`
+	g.Equal(wantJson, w.Body.String())
+}
+
+func (g *CheckinControllerSuite) TestScanReplayedAfterGuestLeft() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonPost(c, CheckinScanRequest{Token: "1.signature"})
+
+	g.mockTicketService.EXPECT().Verify("1.signature").Return(int64(1), nil).Times(1)
+	g.mockCheckinService.EXPECT().CheckIn(c, int64(1), uint16(0)).
+		Return(nil, fmt.Errorf("check in guest: %w", &domain.CheckedInError{GuestID: 1, Left: true})).Times(1)
+
+	g.checkinController.Scan(c)
+
+	g.EqualValues(http.StatusConflict, w.Code)
+	g.Equal(`{"code":409,"message":"ticket of guest 1 was already used, they left"}`, w.Body.String())
+}
+
+func (g *CheckinControllerSuite) TestScanReplayedWhileSeated() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonPost(c, CheckinScanRequest{Token: "1.signature"})
+
+	g.mockTicketService.EXPECT().Verify("1.signature").Return(int64(1), nil).Times(1)
+	g.mockCheckinService.EXPECT().CheckIn(c, int64(1), uint16(0)).
+		Return(nil, fmt.Errorf("check in guest: %w", &domain.CheckedInError{GuestID: 1})).Times(1)
+
+	g.checkinController.Scan(c)
+
+	g.EqualValues(http.StatusConflict, w.Code)
+	g.Equal(`{"code":409,"message":"ticket of guest 1 was already used, they are seated"}`, w.Body.String())
+}
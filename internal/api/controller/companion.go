@@ -0,0 +1,121 @@
+package controller
+
+import (
+	stderrors "errors"
+	"net/http"
+	"strconv"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	"github.com/eazygood/getground-app/internal/errors"
+	"github.com/gin-gonic/gin"
+)
+
+type CompanionController interface {
+	GetList(request *gin.Context)
+	Add(request *gin.Context)
+	Remove(request *gin.Context)
+}
+
+type CompanionRequest struct {
+	Name         string   `json:"name" validate:"required,notblank,max=100"`
+	Dietary      []string `json:"dietary,omitempty" validate:"dive,dietary"`
+	DietaryNotes string   `json:"dietary_notes,omitempty" validate:"max=500"`
+}
+
+type companionController struct {
+	guestService     port.GuestService
+	companionService port.CompanionService
+}
+
+func NewCompanionController(guest port.GuestService, companion port.CompanionService) CompanionController {
+	return &companionController{
+		guestService:     guest,
+		companionService: companion,
+	}
+}
+
+// GetList lists the companions of a guest
+func (c *companionController) GetList(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("guest_id"))
+
+	if err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
+		return
+	}
+
+	if _, err := c.guestService.GetById(ctx, int64(id)); err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.NotFound, err))
+		return
+	}
+
+	companions, err := c.companionService.GetList(ctx, int64(id))
+	if err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, companions)
+}
+
+// Add names one more person the guest brings, refusing them when the party would outgrow the guest's table
+func (c *companionController) Add(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("guest_id"))
+
+	if err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
+		return
+	}
+
+	body := &CompanionRequest{}
+	if !bindJSON(ctx, body) {
+		return
+	}
+
+	companion, err := c.companionService.Add(ctx, &domain.Companion{
+		GuestID:      int64(id),
+		Name:         body.Name,
+		Dietary:      body.Dietary,
+		DietaryNotes: body.DietaryNotes,
+	})
+
+	if err != nil {
+		var tooLarge *domain.PartyTooLargeError
+		switch {
+		case stderrors.As(err, &tooLarge):
+			logAndAbort(ctx, errors.NewApiError(errors.Conflict, err))
+		case stderrors.Is(err, domain.ErrNotFound):
+			logAndAbort(ctx, errors.NewApiError(errors.NotFound, err))
+		default:
+			logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
+		}
+
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, companion)
+}
+
+// Remove drops a companion of a guest, their accompanying guests shrink by one
+func (c *companionController) Remove(ctx *gin.Context) {
+	guestID, err := strconv.Atoi(ctx.Param("guest_id"))
+
+	if err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("companion_id"))
+
+	if err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
+		return
+	}
+
+	if err := c.companionService.Remove(ctx, int64(guestID), int64(id)); err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.NotFound, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse)
+}
@@ -0,0 +1,147 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/eazygood/getground-app/internal/api/controller/testutil"
+	"github.com/eazygood/getground-app/internal/core/domain"
+	mockPort "github.com/eazygood/getground-app/mocks/core/port"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddCompanion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	companionService := mockPort.NewMockCompanionService(ctrl)
+
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+	testutil.MockJsonPost(c, CompanionRequest{Name: "Carl", Dietary: []string{domain.DietaryHalal}})
+	c.Params = gin.Params{{Key: "guest_id", Value: "4"}}
+
+	companionService.EXPECT().Add(c, &domain.Companion{GuestID: 4, Name: "Carl", Dietary: domain.Dietary{domain.DietaryHalal}}).
+		Return(&domain.Companion{ID: 7, GuestID: 4, Name: "Carl", Dietary: domain.Dietary{domain.DietaryHalal}}, nil).Times(1)
+
+	NewCompanionController(mockPort.NewMockGuestService(ctrl), companionService).Add(c)
+
+	require.EqualValues(t, http.StatusCreated, w.Code)
+	require.Equal(t, `{"id":7,"guest_id":4,"name":"Carl","dietary":["halal"],"is_arrived":false,"left_at":null}`, w.Body.String())
+}
+
+func TestAddCompanionValidationFailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+	testutil.MockJsonPost(c, CompanionRequest{Name: " ", Dietary: []string{"carnivore"}})
+	c.Params = gin.Params{{Key: "guest_id", Value: "4"}}
+
+	NewCompanionController(mockPort.NewMockGuestService(ctrl), mockPort.NewMockCompanionService(ctrl)).Add(c)
+
+	require.EqualValues(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestAddCompanionToUnknownGuest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	companionService := mockPort.NewMockCompanionService(ctrl)
+
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+	testutil.MockJsonPost(c, CompanionRequest{Name: "Carl"})
+	c.Params = gin.Params{{Key: "guest_id", Value: "4"}}
+
+	companionService.EXPECT().Add(c, &domain.Companion{GuestID: 4, Name: "Carl"}).
+		Return(nil, fmt.Errorf("add companion: %w by id: 4", domain.ErrNotFound)).Times(1)
+
+	NewCompanionController(mockPort.NewMockGuestService(ctrl), companionService).Add(c)
+
+	require.EqualValues(t, http.StatusNotFound, w.Code)
+}
+
+func TestGetCompanions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	guestService := mockPort.NewMockGuestService(ctrl)
+	companionService := mockPort.NewMockCompanionService(ctrl)
+
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+	testutil.MockJsonGet(c, gin.Params{{Key: "guest_id", Value: "4"}}, url.Values{})
+
+	guestService.EXPECT().GetById(c, int64(4)).Return(&domain.Guest{ID: 4, Name: "Ada"}, nil).Times(1)
+	companionService.EXPECT().GetList(c, int64(4)).Return([]*domain.Companion{{ID: 7, GuestID: 4, Name: "Carl", IsArrived: true}}, nil).Times(1)
+
+	NewCompanionController(guestService, companionService).GetList(c)
+
+	require.EqualValues(t, http.StatusOK, w.Code)
+	require.Equal(t, `[{"id":7,"guest_id":4,"name":"Carl","is_arrived":true,"left_at":null}]`, w.Body.String())
+}
+
+func TestRemoveCompanion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	companionService := mockPort.NewMockCompanionService(ctrl)
+
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+	testutil.MockJsonDelete(c, gin.Params{{Key: "guest_id", Value: "4"}, {Key: "companion_id", Value: "7"}})
+
+	companionService.EXPECT().Remove(c, int64(4), int64(7)).Return(nil).Times(1)
+
+	NewCompanionController(mockPort.NewMockGuestService(ctrl), companionService).Remove(c)
+
+	require.EqualValues(t, http.StatusOK, w.Code)
+	require.Equal(t, `{"message":"success"}`, w.Body.String())
+}
+
+func TestRemoveUnknownCompanion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	companionService := mockPort.NewMockCompanionService(ctrl)
+
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+	testutil.MockJsonDelete(c, gin.Params{{Key: "guest_id", Value: "5"}, {Key: "companion_id", Value: "7"}})
+
+	companionService.EXPECT().Remove(c, int64(5), int64(7)).Return(fmt.Errorf("remove companion: record not found by id: 7")).Times(1)
+
+	NewCompanionController(mockPort.NewMockGuestService(ctrl), companionService).Remove(c)
+
+	require.EqualValues(t, http.StatusNotFound, w.Code)
+}
+
+func TestAddCompanionBeyondTableSeats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	companionService := mockPort.NewMockCompanionService(ctrl)
+
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+	testutil.MockJsonPost(c, CompanionRequest{Name: "Carl"})
+	c.Params = gin.Params{{Key: "guest_id", Value: "4"}}
+
+	companionService.EXPECT().Add(c, &domain.Companion{GuestID: 4, Name: "Carl"}).
+		Return(nil, fmt.Errorf("add companion: %w", &domain.PartyTooLargeError{Party: 5, Seats: 4, TableID: 2})).Times(1)
+
+	NewCompanionController(mockPort.NewMockGuestService(ctrl), companionService).Add(c)
+
+	require.EqualValues(t, http.StatusConflict, w.Code)
+}
+
+func TestAddCompanionFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	companionService := mockPort.NewMockCompanionService(ctrl)
+
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+	testutil.MockJsonPost(c, CompanionRequest{Name: "Carl"})
+	c.Params = gin.Params{{Key: "guest_id", Value: "4"}}
+
+	companionService.EXPECT().Add(c, &domain.Companion{GuestID: 4, Name: "Carl"}).
+		Return(nil, fmt.Errorf("add companion: failed to get table of guest (4) connection refused")).Times(1)
+
+	NewCompanionController(mockPort.NewMockGuestService(ctrl), companionService).Add(c)
+
+	require.EqualValues(t, http.StatusInternalServerError, w.Code)
+}
@@ -0,0 +1,41 @@
+package controller
+
+import (
+	_ "embed"
+	"net/http"
+
+	"github.com/eazygood/getground-app/internal/errors"
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+// SwaggerAssets serves the bundled Swagger UI scripts and styles loaded by the docs page
+var SwaggerAssets http.FileSystem = swaggerFiles.HTTP
+
+//go:embed swagger.html
+var swaggerPage []byte
+
+type DocsController interface {
+	OpenAPI(request *gin.Context)
+	SwaggerUI(request *gin.Context)
+}
+
+type docsController struct{}
+
+func NewDocsController() DocsController {
+	return &docsController{}
+}
+
+func (d *docsController) OpenAPI(request *gin.Context) {
+	doc, err := OpenAPI()
+	if err != nil {
+		logAndAbort(request, errors.NewApiError(errors.Internal, err))
+		return
+	}
+
+	request.JSON(http.StatusOK, doc)
+}
+
+func (d *docsController) SwaggerUI(request *gin.Context) {
+	request.Data(http.StatusOK, "text/html; charset=utf-8", swaggerPage)
+}
@@ -1,28 +1,75 @@
 package controller
 
 import (
+	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/eazygood/getground-app/internal/core/domain"
 	"github.com/eazygood/getground-app/internal/core/port"
 	"github.com/eazygood/getground-app/internal/errors"
+	v "github.com/eazygood/getground-app/internal/validator"
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	// the names are indexed in pairs of characters, a shorter query matches nothing
+	minSearchLength    = 2
+	defaultSearchLimit = 10
+	maxSearchLimit     = 50
+)
+
 type GuestController interface {
 	Create(request *gin.Context)
 	Update(request *gin.Context)
+	Patch(request *gin.Context)
 	Delete(request *gin.Context)
+	Restore(request *gin.Context)
 	GetById(request *gin.Context)
 	GetList(request *gin.Context)
+	Search(request *gin.Context)
+	RSVP(request *gin.Context)
+	NoShow(request *gin.Context)
+}
+
+// RSVPRequest is the answer of a guest to their invitation
+type RSVPRequest struct {
+	Status string `json:"status" validate:"required,oneof=accepted declined tentative"`
+	// AccompanyingGuests are the people the guest expects to bring, ignored when they decline
+	AccompanyingGuests int `json:"accompanying_guests" validate:"min=0,party_size"`
 }
 
 type GuestRequest struct {
-	Name               string `json:"name"`
-	AccompanyingGuests uint16 `json:"accompanying_guests"`
-	TimeArrived        string `json:"time_arrived,omitempty"`
+	Name               string `json:"name" validate:"required,notblank,max=100"`
+	AccompanyingGuests uint16 `json:"accompanying_guests" validate:"party_size"`
+	TimeArrived        string `json:"time_arrived,omitempty" validate:"omitempty,timestamp"`
+	GuestProfile
+}
+
+// GuestProfile holds the contact details and the needs of a guest, every member is optional
+type GuestProfile struct {
+	Email         string   `json:"email,omitempty" validate:"omitempty,email,max=254"`
+	Phone         string   `json:"phone,omitempty" validate:"omitempty,phone"`
+	Company       string   `json:"company,omitempty" validate:"max=100"`
+	Dietary       []string `json:"dietary,omitempty" validate:"dive,dietary"`
+	DietaryNotes  string   `json:"dietary_notes,omitempty" validate:"max=500"`
+	Accessibility string   `json:"accessibility,omitempty" validate:"max=500"`
+	Notes         string   `json:"notes,omitempty" validate:"max=1000"`
+}
+
+func (p GuestProfile) apply(guest *domain.Guest) {
+	guest.Email = p.Email
+	guest.Phone = p.Phone
+	guest.Company = p.Company
+	guest.Dietary = p.Dietary
+	guest.DietaryNotes = p.DietaryNotes
+	guest.Accessibility = p.Accessibility
+	guest.Notes = p.Notes
 }
 
 func createFromCreateUpdateRequest(req GuestRequest) (*domain.Guest, error) {
@@ -31,11 +78,12 @@ func createFromCreateUpdateRequest(req GuestRequest) (*domain.Guest, error) {
 		AccompanyingGuests: req.AccompanyingGuests,
 	}
 
+	req.GuestProfile.apply(&guest)
+
 	if req.TimeArrived != "" {
 		t, err := strToTimePtr(req.TimeArrived)
 		if err != nil {
-
-			return nil, fmt.Errorf("invalid time arrived input")
+			return nil, err
 		}
 
 		guest.TimeArrived = t
@@ -44,20 +92,108 @@ func createFromCreateUpdateRequest(req GuestRequest) (*domain.Guest, error) {
 	return &guest, nil
 }
 
+func createFromPatchRequest(patch map[string]json.RawMessage) (*port.GuestPatch, error) {
+	p := port.GuestPatch{}
+	req := GuestRequest{}
+	profile := GuestProfile{}
+	// the members of GuestRequest patched, whose rules they follow
+	var members []string
+
+	for field, value := range patch {
+		var err error
+
+		switch field {
+		case "name":
+			err = decodeMember(field, value, &req.Name)
+			members = append(members, "Name")
+		case "accompanying_guests":
+			err = decodeMember(field, value, &req.AccompanyingGuests)
+			members = append(members, "AccompanyingGuests")
+		case "is_arrived":
+			err = decodeMember(field, value, &p.Guest.IsArrived)
+		case "time_arrived":
+			if isNull(value) {
+				break
+			}
+
+			var timeArrived string
+			if err = decodeMember(field, value, &timeArrived); err != nil {
+				break
+			}
+
+			if p.Guest.TimeArrived, err = strToTimePtr(timeArrived); err != nil {
+				err = errors.NewValidationError(errors.FieldError{
+					Field:   field,
+					Rule:    "timestamp",
+					Message: v.TimestampMessage(field),
+				})
+			}
+		// the profile members are cleared by null
+		case "email":
+			err = decodeOptionalMember(field, value, &profile.Email)
+		case "phone":
+			err = decodeOptionalMember(field, value, &profile.Phone)
+		case "company":
+			err = decodeOptionalMember(field, value, &profile.Company)
+		case "dietary":
+			profile.Dietary = []string{}
+			err = decodeOptionalMember(field, value, &profile.Dietary)
+		case "dietary_notes":
+			err = decodeOptionalMember(field, value, &profile.DietaryNotes)
+		case "accessibility":
+			err = decodeOptionalMember(field, value, &profile.Accessibility)
+		case "notes":
+			err = decodeOptionalMember(field, value, &profile.Notes)
+		default:
+			err = fmt.Errorf("field %s cannot be patched", field)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		p.Fields = append(p.Fields, field)
+	}
+
+	if len(members) > 0 {
+		if err := v.GetValidator().StructPartial(req, members...); err != nil {
+			return nil, err
+		}
+	}
+
+	// the members left out of the patch are empty, which every rule of the profile accepts
+	if err := v.GetValidator().Struct(profile); err != nil {
+		return nil, err
+	}
+
+	p.Guest.Name = req.Name
+	p.Guest.AccompanyingGuests = req.AccompanyingGuests
+	profile.apply(&p.Guest)
+	sort.Strings(p.Fields)
+
+	return &p, nil
+}
+
 type guestController struct {
-	guestService port.GuestService
+	guestService       port.GuestService
+	guestSearchService port.GuestSearchService
 }
 
-func NewGuestController(service port.GuestService) GuestController {
+func NewGuestController(service port.GuestService, search port.GuestSearchService) GuestController {
 	return &guestController{
-		guestService: service,
+		guestService:       service,
+		guestSearchService: search,
 	}
 }
 
 func (c *guestController) Create(request *gin.Context) {
+	loc, ok := requestLocation(request)
+	if !ok {
+		return
+	}
+
 	body := GuestRequest{}
-	if err := request.ShouldBindJSON(&body); err != nil {
-		logAndAbort(request, errors.NewApiError(errors.InvalidInput, err))
+	if !bindJSON(request, &body) {
 		return
 	}
 
@@ -73,6 +209,7 @@ func (c *guestController) Create(request *gin.Context) {
 		return
 	}
 
+	renderGuests(loc, guest)
 	request.JSON(http.StatusCreated, guest)
 }
 
@@ -84,9 +221,13 @@ func (c *guestController) Update(ctx *gin.Context) {
 		return
 	}
 
+	version, ok := requireIfMatch(ctx)
+	if !ok {
+		return
+	}
+
 	body := GuestRequest{}
-	if err := ctx.ShouldBindJSON(&body); err != nil {
-		logAndAbort(ctx, errors.NewApiError(errors.InvalidInput, err))
+	if !bindJSON(ctx, &body) {
 		return
 	}
 
@@ -96,13 +237,53 @@ func (c *guestController) Update(ctx *gin.Context) {
 		return
 	}
 
+	g.Version = version
+
 	err = c.guestService.Update(ctx, int64(id), g)
+	if err != nil {
+		abortGuestWrite(ctx, err)
+		return
+	}
+
+	setETag(ctx, version+1)
+	ctx.JSON(http.StatusOK, successResponse)
+}
+
+func (c *guestController) Patch(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("guest_id"))
+
 	if err != nil {
 		logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"message": "success"})
+	version, ok := requireIfMatch(ctx)
+	if !ok {
+		return
+	}
+
+	body, err := readMergePatch(ctx)
+	if err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.InvalidInput, err))
+		return
+	}
+
+	patch, err := createFromPatchRequest(body)
+	if err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.InvalidInput, err))
+		return
+	}
+
+	patch.Guest.Version = version
+
+	err = c.guestService.Patch(ctx, int64(id), *patch)
+	if err != nil {
+		abortGuestWrite(ctx, err)
+		return
+	}
+
+	setETag(ctx, version+1)
+	ctx.JSON(http.StatusOK, successResponse)
 }
 
 func (c *guestController) Delete(ctx *gin.Context) {
@@ -113,13 +294,35 @@ func (c *guestController) Delete(ctx *gin.Context) {
 		return
 	}
 
-	err = c.guestService.Delete(ctx, int64(id))
+	version, ok := requireIfMatch(ctx)
+	if !ok {
+		return
+	}
+
+	err = c.guestService.Delete(ctx, int64(id), version)
+	if err != nil {
+		abortGuestWrite(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse)
+}
+
+func (c *guestController) Restore(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("guest_id"))
+
 	if err != nil {
 		logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"message": "success"})
+	err = c.guestService.Restore(ctx, int64(id))
+	if err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.NotFound, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse)
 }
 
 func (c *guestController) GetById(ctx *gin.Context) {
@@ -130,20 +333,67 @@ func (c *guestController) GetById(ctx *gin.Context) {
 		return
 	}
 
+	loc, ok := requestLocation(ctx)
+	if !ok {
+		return
+	}
+
 	guest, err := c.guestService.GetById(ctx, int64(id))
 	if err != nil {
 		logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
 		return
 	}
 
+	renderGuests(loc, guest)
+	setETag(ctx, guest.Version)
 	ctx.JSON(http.StatusOK, guest)
 }
 
 func (c *guestController) GetList(ctx *gin.Context) {
+	loc, ok := requestLocation(ctx)
+	if !ok {
+		return
+	}
+
 	filters := port.GetGuestFilter{}
 
 	if _, ok := ctx.GetQuery("arrived"); ok {
-		filters.IsArrived = true
+		arrived := true
+		filters.IsArrived = &arrived
+	}
+
+	if includeDeleted, ok := ctx.GetQuery("include_deleted"); ok {
+		filters.IncludeDeleted, _ = strconv.ParseBool(includeDeleted)
+	}
+
+	filters.Company = ctx.Query("company")
+
+	if status, ok := ctx.GetQuery("status"); ok {
+		if !domain.IsGuestStatus(status) {
+			logAndAbort(ctx, errors.NewApiError(errors.InvalidInput, errors.NewValidationError(errors.FieldError{
+				Field:   "status",
+				Rule:    "oneof",
+				Message: "status must be one of " + strings.Join(domain.GuestStatuses, ", "),
+			})))
+
+			return
+		}
+
+		filters.Status = status
+	}
+
+	if dietary, ok := ctx.GetQuery("dietary"); ok {
+		if !domain.Dietary(domain.DietaryRequirements).Has(dietary) {
+			logAndAbort(ctx, errors.NewApiError(errors.InvalidInput, errors.NewValidationError(errors.FieldError{
+				Field:   "dietary",
+				Rule:    "dietary",
+				Message: "dietary must be one of " + strings.Join(domain.DietaryRequirements, ", "),
+			})))
+
+			return
+		}
+
+		filters.Dietary = dietary
 	}
 
 	guests, err := c.guestService.GetList(ctx, filters)
@@ -152,5 +402,136 @@ func (c *guestController) GetList(ctx *gin.Context) {
 		return
 	}
 
+	renderGuests(loc, guests...)
 	ctx.JSON(http.StatusOK, guests)
 }
+
+// Search ranks the guests by how close their name is to ?q=, the ?limit= best ones
+func (c *guestController) Search(ctx *gin.Context) {
+	query := strings.TrimSpace(ctx.Query("q"))
+	if len([]rune(query)) < minSearchLength {
+		logAndAbort(ctx, errors.NewApiError(errors.InvalidInput, errors.NewValidationError(errors.FieldError{
+			Field:   "q",
+			Rule:    "min",
+			Message: fmt.Sprintf("q must be at least %d characters long", minSearchLength),
+		})))
+
+		return
+	}
+
+	limit := defaultSearchLimit
+	if value, ok := ctx.GetQuery("limit"); ok {
+		var err error
+
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxSearchLimit {
+			logAndAbort(ctx, errors.NewApiError(errors.InvalidInput, errors.NewValidationError(errors.FieldError{
+				Field:   "limit",
+				Rule:    "range",
+				Message: fmt.Sprintf("limit must be an integer between 1 and %d", maxSearchLimit),
+			})))
+
+			return
+		}
+	}
+
+	loc, ok := requestLocation(ctx)
+	if !ok {
+		return
+	}
+
+	matches, err := c.guestSearchService.Search(ctx, query, limit)
+	if err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
+		return
+	}
+
+	for _, match := range matches {
+		renderGuests(loc, match.Guest)
+	}
+
+	ctx.JSON(http.StatusOK, matches)
+}
+
+// RSVP records the answer of a guest to their invitation, with the number of people they expect to bring
+func (c *guestController) RSVP(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("guest_id"))
+
+	if err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
+		return
+	}
+
+	loc, ok := requestLocation(ctx)
+	if !ok {
+		return
+	}
+
+	body := &RSVPRequest{}
+	if !bindJSON(ctx, body) {
+		return
+	}
+
+	guest, err := c.guestService.RSVP(ctx, int64(id), domain.RSVP{
+		Status:    body.Status,
+		PartySize: uint16(1 + body.AccompanyingGuests),
+	})
+
+	c.renderTransition(ctx, loc, guest, err)
+}
+
+// NoShow marks a guest who was expected but did not turn up
+func (c *guestController) NoShow(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("guest_id"))
+
+	if err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
+		return
+	}
+
+	loc, ok := requestLocation(ctx)
+	if !ok {
+		return
+	}
+
+	guest, err := c.guestService.MarkNoShow(ctx, int64(id))
+
+	c.renderTransition(ctx, loc, guest, err)
+}
+
+// abortGuestWrite reports a change of a guest the state machine forbade as 409, any other failure as 500
+func abortGuestWrite(ctx *gin.Context, err error) {
+	var transition *domain.TransitionError
+	if stderrors.As(err, &transition) {
+		logAndAbort(ctx, errors.NewApiError(errors.Conflict, err))
+		return
+	}
+
+	logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
+}
+
+// renderTransition responds with the guest whose status changed, 409 when the state machine or the
+// overbooking policy forbade it, 412 when another change of the guest won the race and 404 otherwise
+func (c *guestController) renderTransition(ctx *gin.Context, loc *time.Location, guest *domain.Guest, err error) {
+	var transition *domain.TransitionError
+	var overbooked *domain.OverbookedError
+	if stderrors.As(err, &transition) || stderrors.As(err, &overbooked) {
+		logAndAbort(ctx, errors.NewApiError(errors.Conflict, err))
+		return
+	}
+
+	var conflict *errors.ConflictError
+	if stderrors.As(err, &conflict) {
+		logAndAbort(ctx, errors.NewApiError(errors.PreconditionFailed, err))
+		return
+	}
+
+	if err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.NotFound, err))
+		return
+	}
+
+	renderGuests(loc, guest)
+	setETag(ctx, guest.Version)
+	ctx.JSON(http.StatusOK, guest)
+}
@@ -1,15 +1,21 @@
 package controller
 
 import (
+	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/eazygood/getground-app/internal/api/controller/testutil"
+	"github.com/eazygood/getground-app/internal/config"
 	"github.com/eazygood/getground-app/internal/core/domain"
 	"github.com/eazygood/getground-app/internal/core/port"
+	"github.com/eazygood/getground-app/internal/errors"
+	"github.com/eazygood/getground-app/internal/validator"
 	mockPort "github.com/eazygood/getground-app/mocks/core/port"
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
@@ -20,9 +26,10 @@ import (
 type GuestControllereSuite struct {
 	suite.Suite
 	*require.Assertions
-	ctrl             *gomock.Controller
-	mockGuestService *mockPort.MockGuestService
-	guestController  GuestController
+	ctrl                   *gomock.Controller
+	mockGuestService       *mockPort.MockGuestService
+	mockGuestSearchService *mockPort.MockGuestSearchService
+	guestController        GuestController
 }
 
 func TestGuestControllereSuite(t *testing.T) {
@@ -34,7 +41,8 @@ func (g *GuestControllereSuite) SetupTest() {
 
 	g.ctrl = gomock.NewController(g.T())
 	g.mockGuestService = mockPort.NewMockGuestService(g.ctrl)
-	g.guestController = NewGuestController(g.mockGuestService)
+	g.mockGuestSearchService = mockPort.NewMockGuestSearchService(g.ctrl)
+	g.guestController = NewGuestController(g.mockGuestService, g.mockGuestSearchService)
 }
 
 func (g *GuestControllereSuite) TestCreateGuest() {
@@ -66,12 +74,40 @@ func (g *GuestControllereSuite) TestCreateGuest() {
 
 	g.EqualValues(http.StatusCreated, w.Code)
 
-	wantJson := `{"id":0,"name":"Simon","accompanying_guests":0,"time_arrived":null,"is_arrived":false}`
+	wantJson := `{"id":0,"name":"Simon","accompanying_guests":0,"time_arrived":null,"is_arrived":false,"version":0,"deleted_at":null}`
 	got, _ := io.ReadAll(res.Body)
 
 	g.Equal(wantJson, string(got))
 }
 
+func (g *GuestControllereSuite) TestCreateGuestValidationFailed() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonPost(c, GuestRequest{Name: "  "})
+
+	g.guestController.Create(c)
+
+	g.EqualValues(http.StatusUnprocessableEntity, w.Code)
+
+	wantJson := `{"code":422,"message":"validation failed","errors":[{"field":"name","rule":"notblank","message":"name must not be blank"}]}`
+	g.Equal(wantJson, w.Body.String())
+}
+
+func (g *GuestControllereSuite) TestCreateGuestWrongType() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonPost(c, map[string]interface{}{"name": "Simon", "accompanying_guests": -1})
+
+	g.guestController.Create(c)
+
+	g.EqualValues(http.StatusUnprocessableEntity, w.Code)
+
+	wantJson := `{"code":422,"message":"validation failed","errors":[{"field":"accompanying_guests","rule":"type","message":"accompanying_guests must be an integer between 0 and 65535"}]}`
+	g.Equal(wantJson, w.Body.String())
+}
+
 func (g *GuestControllereSuite) TearDownTest() {
 	g.ctrl.Finish()
 }
@@ -93,11 +129,13 @@ func (g *GuestControllereSuite) TestUpdateGuest() {
 	}
 
 	testutil.MockJsonPut(c, body, params)
+	c.Request.Header.Set("If-Match", `"2"`)
 
 	guestID := 1
 	guestServiceData := &domain.Guest{
 		Name:               "Simon",
 		AccompanyingGuests: 9999,
+		Version:            2,
 	}
 
 	g.mockGuestService.EXPECT().Update(c, int64(guestID), guestServiceData).Return(nil).Times(1)
@@ -108,6 +146,7 @@ func (g *GuestControllereSuite) TestUpdateGuest() {
 	defer res.Body.Close()
 
 	g.EqualValues(http.StatusOK, w.Code)
+	g.Equal(`"3"`, res.Header.Get("ETag"))
 
 	got, err := io.ReadAll(res.Body)
 
@@ -115,6 +154,168 @@ func (g *GuestControllereSuite) TestUpdateGuest() {
 	g.Equal(`{"message":"success"}`, string(got))
 }
 
+func (g *GuestControllereSuite) TestUpdateGuestWithoutIfMatch() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	params := []gin.Param{
+		{
+			Key:   "guest_id",
+			Value: "1",
+		},
+	}
+
+	testutil.MockJsonPut(c, GuestRequest{Name: "Simon"}, params)
+
+	g.guestController.Update(c)
+
+	g.EqualValues(http.StatusPreconditionRequired, w.Code)
+}
+
+func (g *GuestControllereSuite) TestUpdateGuestVersionConflict() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	params := []gin.Param{
+		{
+			Key:   "guest_id",
+			Value: "1",
+		},
+	}
+
+	testutil.MockJsonPut(c, GuestRequest{Name: "Simon"}, params)
+	c.Request.Header.Set("If-Match", `W/"2"`)
+
+	conflict := fmt.Errorf("update guest: %w", errors.NewConflictError("guest", 1, 2))
+	g.mockGuestService.EXPECT().Update(c, int64(1), &domain.Guest{Name: "Simon", Version: 2}).Return(conflict).Times(1)
+
+	g.guestController.Update(c)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	g.EqualValues(http.StatusPreconditionFailed, w.Code)
+
+	got, err := io.ReadAll(res.Body)
+
+	g.NoError(err)
+	g.Equal(`{"code":412,"message":"update guest: guest 1 does not match version 2"}`, string(got))
+}
+
+func (g *GuestControllereSuite) TestUpdateGuestNotFound() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	params := []gin.Param{
+		{
+			Key:   "guest_id",
+			Value: "1",
+		},
+	}
+
+	testutil.MockJsonPut(c, GuestRequest{Name: "Simon"}, params)
+	c.Request.Header.Set("If-Match", `W/"2"`)
+
+	missing := fmt.Errorf("update guest: %w by id: %v", domain.ErrNotFound, 1)
+	g.mockGuestService.EXPECT().Update(c, int64(1), &domain.Guest{Name: "Simon", Version: 2}).Return(missing).Times(1)
+
+	g.guestController.Update(c)
+
+	g.EqualValues(http.StatusNotFound, w.Code)
+}
+
+func (g *GuestControllereSuite) TestPatchGuest() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	params := []gin.Param{
+		{
+			Key:   "guest_id",
+			Value: "1",
+		},
+	}
+
+	testutil.MockJsonMergePatch(c, `{"is_arrived":false,"time_arrived":null,"accompanying_guests":0}`, params)
+	c.Request.Header.Set("If-Match", `"2"`)
+
+	patch := port.GuestPatch{
+		Guest:  domain.Guest{Version: 2},
+		Fields: []string{"accompanying_guests", "is_arrived", "time_arrived"},
+	}
+
+	g.mockGuestService.EXPECT().Patch(c, int64(1), patch).Return(nil).Times(1)
+
+	g.guestController.Patch(c)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	g.EqualValues(http.StatusOK, w.Code)
+	g.Equal(`"3"`, res.Header.Get("ETag"))
+}
+
+func (g *GuestControllereSuite) TestPatchArrivedGuestBack() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonMergePatch(c, `{"is_arrived":false}`, []gin.Param{{Key: "guest_id", Value: "1"}})
+	c.Request.Header.Set("If-Match", `"2"`)
+
+	g.mockGuestService.EXPECT().Patch(c, int64(1), port.GuestPatch{Guest: domain.Guest{Version: 2}, Fields: []string{"is_arrived"}}).
+		Return(fmt.Errorf("patch guest: %w", &domain.TransitionError{GuestID: 1, From: domain.GuestStatusArrived, To: domain.GuestStatusInvited})).Times(1)
+
+	g.guestController.Patch(c)
+
+	g.EqualValues(http.StatusConflict, w.Code)
+	g.Equal(`{"code":409,"message":"patch guest: guest 1 cannot go from arrived to invited"}`, w.Body.String())
+}
+
+func (g *GuestControllereSuite) TestPatchGuestNullName() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	params := []gin.Param{
+		{
+			Key:   "guest_id",
+			Value: "1",
+		},
+	}
+
+	testutil.MockJsonMergePatch(c, `{"name":null}`, params)
+	c.Request.Header.Set("If-Match", `"2"`)
+
+	g.guestController.Patch(c)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	g.EqualValues(http.StatusBadRequest, w.Code)
+
+	got, err := io.ReadAll(res.Body)
+
+	g.NoError(err)
+	g.Equal(`{"code":400,"message":"name cannot be null"}`, string(got))
+}
+
+func (g *GuestControllereSuite) TestPatchGuestValidationFailed() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	validator.Init(config.Venue{MaxPartySize: 4})
+	defer validator.Init(config.Venue{})
+
+	testutil.MockJsonMergePatch(c, `{"name":"  ","accompanying_guests":4}`, []gin.Param{{Key: "guest_id", Value: "1"}})
+	c.Request.Header.Set("If-Match", `"2"`)
+
+	g.guestController.Patch(c)
+
+	g.EqualValues(http.StatusUnprocessableEntity, w.Code)
+	g.Equal(`{"code":422,"message":"validation failed","errors":[`+
+		`{"field":"name","rule":"notblank","message":"name must not be blank"},`+
+		`{"field":"accompanying_guests","rule":"party_size","message":"accompanying_guests must be at most 3, a party is limited to 4 people"}]}`,
+		w.Body.String())
+}
+
 func (g *GuestControllereSuite) TestDeleteGuest() {
 	w := httptest.NewRecorder()
 	c := testutil.GetTestGinContext(w)
@@ -127,10 +328,11 @@ func (g *GuestControllereSuite) TestDeleteGuest() {
 	}
 
 	testutil.MockJsonDelete(c, params)
+	c.Request.Header.Set("If-Match", `"1"`)
 
 	guestID := 1
 
-	g.mockGuestService.EXPECT().Delete(c, int64(guestID)).Return(nil).Times(1)
+	g.mockGuestService.EXPECT().Delete(c, int64(guestID), int64(1)).Return(nil).Times(1)
 	g.guestController.Delete(c)
 
 	res := w.Result()
@@ -162,6 +364,7 @@ func (g *GuestControllereSuite) TestGetByGuest() {
 		ID:                 1,
 		Name:               "Simon",
 		AccompanyingGuests: 20,
+		Version:            5,
 	}
 
 	g.mockGuestService.EXPECT().GetById(c, int64(guestID)).Return(guestServiceData, nil).Times(1)
@@ -171,15 +374,84 @@ func (g *GuestControllereSuite) TestGetByGuest() {
 	defer res.Body.Close()
 
 	g.EqualValues(http.StatusOK, w.Code)
+	g.Equal(`"5"`, res.Header.Get("ETag"))
 
 	got, err := io.ReadAll(res.Body)
 
 	g.NoError(err)
 
-	wantJson := `{"id":1,"name":"Simon","accompanying_guests":20,"time_arrived":null,"is_arrived":false}`
+	wantJson := `{"id":1,"name":"Simon","accompanying_guests":20,"time_arrived":null,"is_arrived":false,"version":5,"deleted_at":null}`
 	g.Equal(wantJson, string(got))
 }
 
+func (g *GuestControllereSuite) TestGetByIdGuestInRequestedTimezone() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonGet(c, []gin.Param{{Key: "guest_id", Value: "1"}}, url.Values{"tz": []string{"America/New_York"}})
+
+	timeArrived := time.Date(2023, 1, 20, 18, 30, 0, 0, time.UTC)
+	guestServiceData := &domain.Guest{
+		ID:          1,
+		Name:        "Simon",
+		TimeArrived: &timeArrived,
+		IsArrived:   true,
+		Version:     2,
+	}
+
+	g.mockGuestService.EXPECT().GetById(c, int64(1)).Return(guestServiceData, nil).Times(1)
+	g.guestController.GetById(c)
+
+	g.EqualValues(http.StatusOK, w.Code)
+
+	wantJson := `{"id":1,"name":"Simon","accompanying_guests":0,"time_arrived":"2023-01-20T13:30:00-05:00","is_arrived":true,"version":2,"deleted_at":null}`
+	g.Equal(wantJson, w.Body.String())
+}
+
+func (g *GuestControllereSuite) TestGetByIdGuestUnknownTimezone() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonGet(c, []gin.Param{{Key: "guest_id", Value: "1"}}, url.Values{"tz": []string{"Mars/Olympus"}})
+
+	g.guestController.GetById(c)
+
+	g.EqualValues(http.StatusUnprocessableEntity, w.Code)
+
+	wantJson := `{"code":422,"message":"validation failed","errors":[{"field":"tz","rule":"timezone","message":"tz must be an IANA timezone such as Europe/London, got \"Mars/Olympus\""}]}`
+	g.Equal(wantJson, w.Body.String())
+}
+
+func (g *GuestControllereSuite) TestCreateGuestStoresTimeArrivedInUTC() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonPost(c, GuestRequest{Name: "Simon", TimeArrived: "2023-01-20T20:30:00+02:00"})
+
+	timeArrived := time.Date(2023, 1, 20, 18, 30, 0, 0, time.UTC)
+	guest := &domain.Guest{Name: "Simon", TimeArrived: &timeArrived}
+
+	g.mockGuestService.EXPECT().Create(c, gomock.Eq(guest)).Return(guest, nil).Times(1)
+	g.guestController.Create(c)
+
+	g.EqualValues(http.StatusCreated, w.Code)
+	g.Contains(w.Body.String(), `"time_arrived":"2023-01-20T18:30:00Z"`)
+}
+
+func (g *GuestControllereSuite) TestCreateGuestInvalidTimeArrived() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonPost(c, GuestRequest{Name: "Simon", TimeArrived: "02 Jan 2006 15:04:05 +0200"})
+
+	g.guestController.Create(c)
+
+	g.EqualValues(http.StatusUnprocessableEntity, w.Code)
+
+	wantJson := `{"code":422,"message":"validation failed","errors":[{"field":"time_arrived","rule":"timestamp","message":"time_arrived must be an RFC 3339 timestamp such as 2023-01-20T19:30:00+01:00"}]}`
+	g.Equal(wantJson, w.Body.String())
+}
+
 func (g *GuestControllereSuite) TestGetListGuest() {
 	w := httptest.NewRecorder()
 	c := testutil.GetTestGinContext(w)
@@ -220,6 +492,286 @@ func (g *GuestControllereSuite) TestGetListGuest() {
 
 	g.NoError(err)
 
-	wantJson := `[{"id":1,"name":"Simon","accompanying_guests":20,"time_arrived":null,"is_arrived":false},{"id":2,"name":"John","accompanying_guests":20,"time_arrived":null,"is_arrived":false}]`
+	wantJson := `[{"id":1,"name":"Simon","accompanying_guests":20,"time_arrived":null,"is_arrived":false,"version":0,"deleted_at":null},{"id":2,"name":"John","accompanying_guests":20,"time_arrived":null,"is_arrived":false,"version":0,"deleted_at":null}]`
 	g.Equal(wantJson, string(got))
 }
+
+func (g *GuestControllereSuite) TestRestoreGuest() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	params := []gin.Param{
+		{
+			Key:   "guest_id",
+			Value: "1",
+		},
+	}
+
+	testutil.MockJsonPost(c, nil)
+	c.Params = params
+
+	g.mockGuestService.EXPECT().Restore(c, int64(1)).Return(nil).Times(1)
+	g.guestController.Restore(c)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	g.EqualValues(http.StatusOK, w.Code)
+}
+
+func (g *GuestControllereSuite) TestGetListGuestIncludeDeleted() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonGet(c, []gin.Param{}, url.Values{"include_deleted": []string{"true"}})
+
+	filter := port.GetGuestFilter{IncludeDeleted: true}
+
+	g.mockGuestService.EXPECT().GetList(c, filter).Return([]*domain.Guest{}, nil).Times(1)
+	g.guestController.GetList(c)
+
+	g.EqualValues(http.StatusOK, w.Code)
+}
+
+func (g *GuestControllereSuite) TestSearchGuests() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonGet(c, []gin.Param{}, url.Values{"q": []string{" jon "}, "limit": []string{"5"}})
+
+	g.mockGuestSearchService.EXPECT().Search(c, "jon", 5).Return([]*domain.GuestMatch{
+		{Guest: &domain.Guest{ID: 3, Name: "Jon Snow"}, Score: 1},
+		{Guest: &domain.Guest{ID: 2, Name: "John Smith"}, Score: 0.75},
+	}, nil).Times(1)
+	g.guestController.Search(c)
+
+	g.EqualValues(http.StatusOK, w.Code)
+	g.Contains(w.Body.String(), `"name":"Jon Snow"`)
+	g.Contains(w.Body.String(), `"score":0.75}`)
+}
+
+func (g *GuestControllereSuite) TestSearchGuestsQueryTooShort() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonGet(c, []gin.Param{}, url.Values{"q": []string{"j"}})
+
+	g.guestController.Search(c)
+
+	g.EqualValues(http.StatusUnprocessableEntity, w.Code)
+	g.Equal(`{"code":422,"message":"validation failed","errors":[{"field":"q","rule":"min","message":"q must be at least 2 characters long"}]}`, w.Body.String())
+}
+
+func (g *GuestControllereSuite) TestSearchGuestsInvalidLimit() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonGet(c, []gin.Param{}, url.Values{"q": []string{"jon"}, "limit": []string{"100"}})
+
+	g.guestController.Search(c)
+
+	g.EqualValues(http.StatusUnprocessableEntity, w.Code)
+}
+
+func (g *GuestControllereSuite) TestCreateGuestWithProfile() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonPost(c, GuestRequest{Name: "Ada", GuestProfile: GuestProfile{
+		Email:        "ada@example.com",
+		Company:      "Acme",
+		Dietary:      []string{domain.DietaryVegan, domain.DietaryNutAllergy},
+		DietaryNotes: "no sesame",
+	}})
+
+	want := &domain.Guest{
+		Name:         "Ada",
+		Email:        "ada@example.com",
+		Company:      "Acme",
+		Dietary:      domain.Dietary{domain.DietaryVegan, domain.DietaryNutAllergy},
+		DietaryNotes: "no sesame",
+	}
+
+	g.mockGuestService.EXPECT().Create(c, want).Return(want, nil).Times(1)
+	g.guestController.Create(c)
+
+	g.EqualValues(http.StatusCreated, w.Code)
+	g.Contains(w.Body.String(), `"dietary":["vegan","nut_allergy"],"dietary_notes":"no sesame"`)
+}
+
+func (g *GuestControllereSuite) TestCreateGuestInvalidProfile() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonPost(c, GuestRequest{Name: "Ada", GuestProfile: GuestProfile{
+		Email:   "ada",
+		Phone:   "call me",
+		Dietary: []string{"carnivore"},
+	}})
+
+	g.guestController.Create(c)
+
+	g.EqualValues(http.StatusUnprocessableEntity, w.Code)
+
+	var got errors.ApiError
+	g.NoError(json.Unmarshal(w.Body.Bytes(), &got))
+	g.Len(got.Errors, 3)
+	g.Equal("dietary[0]", got.Errors[2].Field)
+}
+
+func (g *GuestControllereSuite) TestPatchGuestProfile() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonMergePatch(c, `{"email":"ada@example.com","dietary":["vegan"],"notes":null}`, []gin.Param{{Key: "guest_id", Value: "1"}})
+	c.Request.Header.Set("If-Match", `"2"`)
+
+	patch := port.GuestPatch{
+		Guest:  domain.Guest{Email: "ada@example.com", Dietary: domain.Dietary{domain.DietaryVegan}, Version: 2},
+		Fields: []string{"dietary", "email", "notes"},
+	}
+
+	g.mockGuestService.EXPECT().Patch(c, int64(1), patch).Return(nil).Times(1)
+
+	g.guestController.Patch(c)
+
+	g.EqualValues(http.StatusOK, w.Code)
+}
+
+func (g *GuestControllereSuite) TestPatchGuestInvalidPhone() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonMergePatch(c, `{"phone":"call me"}`, []gin.Param{{Key: "guest_id", Value: "1"}})
+	c.Request.Header.Set("If-Match", `"2"`)
+
+	g.guestController.Patch(c)
+
+	g.EqualValues(http.StatusUnprocessableEntity, w.Code)
+	g.Contains(w.Body.String(), `"field":"phone","rule":"phone"`)
+}
+
+func (g *GuestControllereSuite) TestGetListGuestByDietaryAndCompany() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonGet(c, []gin.Param{}, url.Values{"dietary": []string{"vegan"}, "company": []string{"Acme"}})
+
+	filter := port.GetGuestFilter{Dietary: domain.DietaryVegan, Company: "Acme"}
+
+	g.mockGuestService.EXPECT().GetList(c, filter).Return([]*domain.Guest{}, nil).Times(1)
+	g.guestController.GetList(c)
+
+	g.EqualValues(http.StatusOK, w.Code)
+}
+
+func (g *GuestControllereSuite) TestGetListGuestUnknownDietary() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonGet(c, []gin.Param{}, url.Values{"dietary": []string{"carnivore"}})
+
+	g.guestController.GetList(c)
+
+	g.EqualValues(http.StatusUnprocessableEntity, w.Code)
+}
+
+func (g *GuestControllereSuite) TestGetListGuestByStatus() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonGet(c, []gin.Param{}, url.Values{"status": []string{"accepted"}})
+
+	g.mockGuestService.EXPECT().GetList(c, port.GetGuestFilter{Status: domain.GuestStatusAccepted}).Return([]*domain.Guest{}, nil).Times(1)
+	g.guestController.GetList(c)
+
+	g.EqualValues(http.StatusOK, w.Code)
+}
+
+func (g *GuestControllereSuite) TestGetListGuestUnknownStatus() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonGet(c, []gin.Param{}, url.Values{"status": []string{"maybe"}})
+
+	g.guestController.GetList(c)
+
+	g.EqualValues(http.StatusUnprocessableEntity, w.Code)
+}
+
+func (g *GuestControllereSuite) TestRSVP() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonPost(c, RSVPRequest{Status: domain.GuestStatusAccepted, AccompanyingGuests: 2})
+	c.Params = gin.Params{{Key: "guest_id", Value: "1"}}
+
+	g.mockGuestService.EXPECT().RSVP(c, int64(1), domain.RSVP{Status: domain.GuestStatusAccepted, PartySize: 3}).
+		Return(&domain.Guest{ID: 1, Name: "Simon", Status: domain.GuestStatusAccepted, PartySize: 3, Version: 4}, nil).Times(1)
+
+	g.guestController.RSVP(c)
+
+	g.EqualValues(http.StatusOK, w.Code)
+	g.Equal(`"4"`, w.Header().Get("ETag"))
+	g.Equal(`{"id":1,"name":"Simon","accompanying_guests":0,"time_arrived":null,"is_arrived":false,"status":"accepted","party_size":3,"version":4,"deleted_at":null}`,
+		w.Body.String())
+}
+
+func (g *GuestControllereSuite) TestRSVPValidationFailed() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonPost(c, RSVPRequest{Status: domain.GuestStatusArrived})
+	c.Params = gin.Params{{Key: "guest_id", Value: "1"}}
+
+	g.guestController.RSVP(c)
+
+	g.EqualValues(http.StatusUnprocessableEntity, w.Code)
+	g.Contains(w.Body.String(), `"message":"status must be one of accepted, declined, tentative"`)
+}
+
+func (g *GuestControllereSuite) TestRSVPForbiddenTransition() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonPost(c, RSVPRequest{Status: domain.GuestStatusDeclined})
+	c.Params = gin.Params{{Key: "guest_id", Value: "1"}}
+
+	g.mockGuestService.EXPECT().RSVP(c, int64(1), domain.RSVP{Status: domain.GuestStatusDeclined, PartySize: 1}).
+		Return(nil, fmt.Errorf("rsvp guest: %w", &domain.TransitionError{GuestID: 1, From: domain.GuestStatusArrived, To: domain.GuestStatusDeclined})).Times(1)
+
+	g.guestController.RSVP(c)
+
+	g.EqualValues(http.StatusConflict, w.Code)
+	g.Equal(`{"code":409,"message":"rsvp guest: guest 1 cannot go from arrived to declined"}`, w.Body.String())
+}
+
+func (g *GuestControllereSuite) TestRSVPRacingAnotherChange() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonPost(c, RSVPRequest{Status: domain.GuestStatusAccepted})
+	c.Params = gin.Params{{Key: "guest_id", Value: "1"}}
+
+	g.mockGuestService.EXPECT().RSVP(c, int64(1), domain.RSVP{Status: domain.GuestStatusAccepted, PartySize: 1}).
+		Return(nil, fmt.Errorf("rsvp guest: %w", errors.NewConflictError("guest", 1, 4))).Times(1)
+
+	g.guestController.RSVP(c)
+
+	g.EqualValues(http.StatusPreconditionFailed, w.Code)
+	g.Equal(`{"code":412,"message":"rsvp guest: guest 1 does not match version 4"}`, w.Body.String())
+}
+
+func (g *GuestControllereSuite) TestNoShowOfUnknownGuest() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonPost(c, nil)
+	c.Params = gin.Params{{Key: "guest_id", Value: "9"}}
+
+	g.mockGuestService.EXPECT().MarkNoShow(c, int64(9)).Return(nil, fmt.Errorf("mark guest as no-show: record not found by id: 9")).Times(1)
+
+	g.guestController.NoShow(c)
+
+	g.EqualValues(http.StatusNotFound, w.Code)
+}
@@ -1,7 +1,7 @@
 package controller
 
 import (
-	"fmt"
+	stderrors "errors"
 	"net/http"
 
 	"github.com/eazygood/getground-app/internal/core/domain"
@@ -16,86 +16,74 @@ type GuestListController interface {
 }
 
 type GuestListRequest struct {
-	TableID            int `json:"table_id"`
-	GuestID            int `json:"guest_id"`
-	AccompanyingGuests int `json:"accompanying_guests"`
+	TableID            int `json:"table_id" validate:"min=0"`
+	GuestID            int `json:"guest_id" validate:"required,min=1"`
+	AccompanyingGuests int `json:"accompanying_guests" validate:"min=0,party_size"`
 }
 
 type guestListController struct {
-	guestService     port.GuestService
-	tableService     port.TableService
 	guestListService port.GuestListService
+	checkinService   port.CheckinService
 }
 
-func NewGuestListController(guest port.GuestService, table port.TableService, guestList port.GuestListService) GuestListController {
+func NewGuestListController(guestList port.GuestListService, checkin port.CheckinService) GuestListController {
 	return &guestListController{
-		guestService:     guest,
-		tableService:     table,
 		guestListService: guestList,
+		checkinService:   checkin,
 	}
 }
 
 func (g *guestListController) Create(ctx *gin.Context) {
 	body := &GuestListRequest{}
-	if err := ctx.ShouldBindJSON(&body); err != nil {
-		logAndAbort(ctx, errors.NewApiError(errors.InvalidInput, err))
+	if !bindJSON(ctx, body) {
 		return
 	}
 
-	filter := port.GetGuestListFilter{
-		AccompanyingGuests: uint16(body.AccompanyingGuests),
-	}
-
-	table, err := g.guestListService.FindAvailableTable(ctx, filter)
+	_, err := g.checkinService.CheckIn(ctx, int64(body.GuestID), uint16(body.AccompanyingGuests))
 
-	if err != nil {
-		logAndAbort(ctx, errors.NewApiError(errors.NotFound, err))
+	var checkedIn *domain.CheckedInError
+	if stderrors.As(err, &checkedIn) {
+		logAndAbort(ctx, errors.NewApiError(errors.InvalidInput, err))
 		return
 	}
 
-	// get guest if it is registered(invited)
-	guest, err := g.guestService.GetById(ctx, int64(body.GuestID))
-
 	if err != nil {
-		logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
+		abortWithoutTable(ctx, err)
 		return
 	}
 
-	if guest.IsArrived {
-		logAndAbort(ctx, errors.NewApiError(errors.InvalidInput, fmt.Errorf("guest already has seats")))
+	ctx.JSON(http.StatusOK, successResponse)
+}
+
+func (g *guestListController) GetList(ctx *gin.Context) {
+	loc, ok := requestLocation(ctx)
+	if !ok {
 		return
 	}
 
-	// update guest with accompanying guest
-	err = g.guestService.Update(ctx, int64(body.GuestID), &domain.Guest{
-		AccompanyingGuests: uint16(body.AccompanyingGuests),
-		IsArrived:          true,
-	})
-
+	guestList, err := g.guestListService.GetOccupiedSeats(ctx)
 	if err != nil {
 		logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
 		return
 	}
 
-	// update table with guest
-	err = g.tableService.Update(ctx, table.ID, domain.Table{
-		GuestID: &guest.ID,
-	})
+	renderTables(loc, guestList...)
+	ctx.JSON(http.StatusOK, guestList)
+}
 
-	if err != nil {
-		logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
+// abortWithoutTable responds 409 when the overbooking policy holds the seats back, 404 when none are free
+// or the guest is unknown and 500 when the check-in failed otherwise
+func abortWithoutTable(ctx *gin.Context, err error) {
+	var overbooked *domain.OverbookedError
+	if stderrors.As(err, &overbooked) {
+		logAndAbort(ctx, errors.NewApiError(errors.Conflict, err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"message": "success"})
-}
-
-func (g *guestListController) GetList(ctx *gin.Context) {
-	guestList, err := g.guestListService.GetOccupiedSeats(ctx)
-	if err != nil {
-		logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
+	if stderrors.Is(err, domain.ErrNotFound) {
+		logAndAbort(ctx, errors.NewApiError(errors.NotFound, err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, guestList)
+	logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
 }
@@ -1,7 +1,7 @@
 package controller
 
 import (
-	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -11,7 +11,6 @@ import (
 
 	"github.com/eazygood/getground-app/internal/api/controller/testutil"
 	"github.com/eazygood/getground-app/internal/core/domain"
-	"github.com/eazygood/getground-app/internal/core/port"
 	mockPort "github.com/eazygood/getground-app/mocks/core/port"
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
@@ -23,9 +22,8 @@ type GuestListControllereSuite struct {
 	suite.Suite
 	*require.Assertions
 	ctrl                 *gomock.Controller
-	mockGuestService     *mockPort.MockGuestService
-	mockTableService     *mockPort.MockTableService
 	mockGuestListService *mockPort.MockGuestListService
+	mockCheckinService   *mockPort.MockCheckinService
 	guestListController  GuestListController
 }
 
@@ -37,16 +35,31 @@ func (g *GuestListControllereSuite) SetupTest() {
 	g.Assertions = require.New(g.T())
 
 	g.ctrl = gomock.NewController(g.T())
-	g.mockGuestService = mockPort.NewMockGuestService(g.ctrl)
-	g.mockTableService = mockPort.NewMockTableService(g.ctrl)
 	g.mockGuestListService = mockPort.NewMockGuestListService(g.ctrl)
-	g.guestListController = NewGuestListController(g.mockGuestService, g.mockTableService, g.mockGuestListService)
+	g.mockCheckinService = mockPort.NewMockCheckinService(g.ctrl)
+	g.guestListController = NewGuestListController(g.mockGuestListService, g.mockCheckinService)
 }
 
 func (g *GuestListControllereSuite) TearDownTest() {
 	g.ctrl.Finish()
 }
 
+func (g *GuestListControllereSuite) TestCreateGuestListValidationFailed() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonPost(c, GuestListRequest{AccompanyingGuests: -2})
+
+	g.guestListController.Create(c)
+
+	g.EqualValues(http.StatusUnprocessableEntity, w.Code)
+
+	wantJson := `{"code":422,"message":"validation failed","errors":[` +
+		`{"field":"guest_id","rule":"required","message":"guest_id is required"},` +
+		`{"field":"accompanying_guests","rule":"min","message":"accompanying_guests must be at least 0"}]}`
+	g.Equal(wantJson, w.Body.String())
+}
+
 func (g *GuestListControllereSuite) TestCreateGuestList() {
 	w := httptest.NewRecorder()
 	c := testutil.GetTestGinContext(w)
@@ -56,28 +69,12 @@ func (g *GuestListControllereSuite) TestCreateGuestList() {
 		AccompanyingGuests: 5,
 	}
 
-	filter := port.GetGuestListFilter{
-		AccompanyingGuests: uint16(body.AccompanyingGuests),
-	}
-
 	testutil.MockJsonPost(c, body)
 
-	guest := domain.Guest{
-		ID:                 1,
-		Name:               "Simon",
-		AccompanyingGuests: 0,
-		IsArrived:          false,
-	}
-
-	availableTable := domain.Table{
-		ID:    2,
-		Seats: 10,
-	}
+	guestID := int64(1)
+	table := domain.Table{ID: 2, Seats: 10, GuestID: &guestID, Version: 4}
 
-	g.mockGuestListService.EXPECT().FindAvailableTable(c, gomock.Eq(filter)).Return(&availableTable, nil).Times(1)
-	g.mockGuestService.EXPECT().GetById(c, guest.ID).Return(&guest, nil).Times(1)
-	g.mockGuestService.EXPECT().Update(c, guest.ID, &domain.Guest{AccompanyingGuests: uint16(body.AccompanyingGuests), IsArrived: true}).Return(nil).Times(1)
-	g.mockTableService.EXPECT().Update(c, availableTable.ID, domain.Table{GuestID: &guest.ID}).Return(nil).Times(1)
+	g.mockCheckinService.EXPECT().CheckIn(c, guestID, uint16(5)).Return(&table, nil).Times(1)
 
 	g.guestListController.Create(c)
 
@@ -101,15 +98,11 @@ func (g *GuestListControllereSuite) TestCreateGuestListThrowErrorNoAvailableSeat
 		AccompanyingGuests: 1000,
 	}
 
-	filter := port.GetGuestListFilter{
-		AccompanyingGuests: uint16(body.AccompanyingGuests),
-	}
-
 	testutil.MockJsonPost(c, body)
 
-	apiError := errors.New("no available seats")
+	apiError := domain.ErrNoAvailableSeats
 
-	g.mockGuestListService.EXPECT().FindAvailableTable(c, gomock.Eq(filter)).Return(nil, apiError).Times(1)
+	g.mockCheckinService.EXPECT().CheckIn(c, int64(1), uint16(1000)).Return(nil, apiError).Times(1)
 
 	g.guestListController.Create(c)
 
@@ -124,6 +117,20 @@ func (g *GuestListControllereSuite) TestCreateGuestListThrowErrorNoAvailableSeat
 	g.Equal(wantJson, string(got))
 }
 
+func (g *GuestListControllereSuite) TestCreateGuestListFails() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonPost(c, GuestListRequest{GuestID: 1, AccompanyingGuests: 2})
+
+	g.mockCheckinService.EXPECT().CheckIn(c, int64(1), uint16(2)).Return(nil, fmt.Errorf("check in guest: connection refused")).Times(1)
+
+	g.guestListController.Create(c)
+
+	g.EqualValues(http.StatusInternalServerError, w.Code)
+	g.Equal(`{"code":500,"message":"check in guest: connection refused"}`, w.Body.String())
+}
+
 func (g *GuestListControllereSuite) TestCreateGuestListGuestAlreadHasTable() {
 	w := httptest.NewRecorder()
 	c := testutil.GetTestGinContext(w)
@@ -133,26 +140,10 @@ func (g *GuestListControllereSuite) TestCreateGuestListGuestAlreadHasTable() {
 		AccompanyingGuests: 5,
 	}
 
-	filter := port.GetGuestListFilter{
-		AccompanyingGuests: uint16(body.AccompanyingGuests),
-	}
-
 	testutil.MockJsonPost(c, body)
 
-	guest := domain.Guest{
-		ID:                 1,
-		Name:               "Simon",
-		AccompanyingGuests: 0,
-		IsArrived:          true,
-	}
-
-	availableTable := domain.Table{
-		ID:    2,
-		Seats: 10,
-	}
-
-	g.mockGuestListService.EXPECT().FindAvailableTable(c, gomock.Eq(filter)).Return(&availableTable, nil).Times(1)
-	g.mockGuestService.EXPECT().GetById(c, guest.ID).Return(&guest, nil).Times(1)
+	g.mockCheckinService.EXPECT().CheckIn(c, int64(1), uint16(5)).
+		Return(nil, fmt.Errorf("check in guest: %w", &domain.CheckedInError{GuestID: 1})).Times(1)
 
 	g.guestListController.Create(c)
 
@@ -161,7 +152,7 @@ func (g *GuestListControllereSuite) TestCreateGuestListGuestAlreadHasTable() {
 
 	g.EqualValues(http.StatusBadRequest, w.Code)
 
-	wantJson := `{"code":400,"message":"guest already has seats"}`
+	wantJson := `{"code":400,"message":"check in guest: guest 1 already has seats"}`
 	got, _ := io.ReadAll(res.Body)
 
 	g.Equal(wantJson, string(got))
@@ -210,8 +201,23 @@ func (g *GuestListControllereSuite) TestGetListGuestList() {
 
 	g.EqualValues(http.StatusOK, w.Code)
 
-	wantJson := `[{"id":1,"seats":10,"guest_id":1},{"id":2,"seats":10,"guest_id":2}]`
+	wantJson := `[{"id":1,"seats":10,"guest_id":1,"version":0,"deleted_at":null},{"id":2,"seats":10,"guest_id":2,"version":0,"deleted_at":null}]`
 	got, _ := io.ReadAll(res.Body)
 
 	g.Equal(wantJson, string(got))
 }
+
+func (g *GuestListControllereSuite) TestCreateGuestListOverbooked() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	body := GuestListRequest{GuestID: 1, AccompanyingGuests: 3}
+	testutil.MockJsonPost(c, body)
+
+	g.mockCheckinService.EXPECT().CheckIn(c, int64(1), uint16(3)).
+		Return(nil, fmt.Errorf("check in guest: %w", &domain.OverbookedError{Expected: 12, Capacity: 10})).Times(1)
+
+	g.guestListController.Create(c)
+
+	g.EqualValues(http.StatusConflict, w.Code)
+}
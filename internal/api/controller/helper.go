@@ -1,39 +1,142 @@
 package controller
 
 import (
+	"encoding/json"
+	stderrors "errors"
 	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/eazygood/getground-app/internal/core/domain"
 	"github.com/eazygood/getground-app/internal/errors"
+	"github.com/eazygood/getground-app/internal/infrastructure/log"
+	v "github.com/eazygood/getground-app/internal/validator"
+	"github.com/eazygood/getground-app/internal/venue"
 	"github.com/gin-gonic/gin"
-	logger "github.com/sirupsen/logrus"
-)
-
-var (
-	customTimeLayoutList = []string{
-		"Mon, 2 Jan 2006 15:04:05 -0700",
-		"Mon, 2 Jan 2006 15:04:05 -0700 (MST)",
-		"Mon, 2 Jan 2006 15:04:05 MST",
-		"02 Jan 2006 15:04:05 +0200",
-		"2006-01-02 00:00:00 +0000",
-		"02.01.2006 15:04:05",
-		"02/01/2006 15:04:05",
-		"02.01.2006 15:04:05",
-		"2006/01/02 15:04:05",
-	}
 )
 
 func logAndAbort(request *gin.Context, err errors.ApiError) {
-	logger.Error(err.Message)
+	log.FromContext(request).WithField("code", err.Code).Error(err.Message)
 	request.AbortWithStatusJSON(err.Code, err)
 }
 
+// bindJSON decodes the request body into obj and checks its validation rules.
+// Values of the wrong type and broken rules abort the request with 422 and the rejected fields.
+func bindJSON(request *gin.Context, obj interface{}) bool {
+	if err := request.ShouldBindJSON(obj); err != nil {
+		var typeError *json.UnmarshalTypeError
+		if stderrors.As(err, &typeError) {
+			err = errors.NewValidationError(errors.FieldError{
+				Field:   typeError.Field,
+				Rule:    "type",
+				Message: fmt.Sprintf("%s must be %s", typeError.Field, describeType(typeError.Type)),
+			})
+		}
+
+		logAndAbort(request, errors.NewApiError(errors.InvalidInput, err))
+		return false
+	}
+
+	if err := v.GetValidator().Struct(obj); err != nil {
+		logAndAbort(request, errors.NewApiError(errors.InvalidInput, err))
+		return false
+	}
+
+	return true
+}
+
+func describeType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return fmt.Sprintf("an integer between 0 and %d", uint64(1)<<t.Bits()-1)
+	case reflect.Uint, reflect.Uint64:
+		return "a non-negative integer"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "an integer"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.String:
+		return "a string"
+	default:
+		return "a " + t.Kind().String()
+	}
+}
+
+// strToTimePtr reads a timestamp sent by a client, see venue.ParseTime for the accepted layouts
 func strToTimePtr(dateValue string) (*time.Time, error) {
-	for _, layout := range customTimeLayoutList {
-		if t, err := time.Parse(layout, dateValue); err == nil {
-			return &t, nil
+	t, err := venue.ParseTime(dateValue)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// requestLocation is the timezone the client asked timestamps to be rendered in with ?tz=, the venue one by default
+func requestLocation(request *gin.Context) (*time.Location, bool) {
+	tz := request.Query("tz")
+	if tz == "" {
+		return venue.Location(), true
+	}
+
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		logAndAbort(request, errors.NewApiError(errors.InvalidInput, errors.NewValidationError(errors.FieldError{
+			Field:   "tz",
+			Rule:    "timezone",
+			Message: fmt.Sprintf("tz must be an IANA timezone such as Europe/London, got %q", tz),
+		})))
+
+		return nil, false
+	}
+
+	return loc, true
+}
+
+func renderGuests(loc *time.Location, guests ...*domain.Guest) {
+	for _, guest := range guests {
+		if guest.TimeArrived != nil {
+			t := guest.TimeArrived.In(loc)
+			guest.TimeArrived = &t
+		}
+
+		if guest.RespondedAt != nil {
+			t := guest.RespondedAt.In(loc)
+			guest.RespondedAt = &t
 		}
+
+		guest.DeletedAt.Time = guest.DeletedAt.Time.In(loc)
+	}
+}
+
+func renderTables(loc *time.Location, tables ...*domain.Table) {
+	for _, table := range tables {
+		table.DeletedAt.Time = table.DeletedAt.Time.In(loc)
+	}
+}
+
+// setETag exposes the record version so that clients can send it back with If-Match
+func setETag(request *gin.Context, version int64) {
+	request.Header("ETag", strconv.Quote(strconv.FormatInt(version, 10)))
+}
+
+// requireIfMatch reads the version the client expects to modify from the If-Match header.
+// It aborts the request and returns false when the header is missing or malformed.
+func requireIfMatch(request *gin.Context) (int64, bool) {
+	value := strings.TrimSpace(request.GetHeader("If-Match"))
+	if value == "" {
+		logAndAbort(request, errors.NewApiError(errors.PreconditionRequired, fmt.Errorf("If-Match header is required")))
+		return 0, false
+	}
+
+	value = strings.Trim(strings.TrimPrefix(value, "W/"), `"`)
+	version, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || version < 1 {
+		logAndAbort(request, errors.NewApiError(errors.InvalidInput, fmt.Errorf("invalid If-Match header")))
+		return 0, false
 	}
 
-	return nil, fmt.Errorf("unable to find proper date")
+	return version, true
 }
@@ -0,0 +1,64 @@
+package controller
+
+import (
+	stderrors "errors"
+	"net/http"
+	"strconv"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	"github.com/eazygood/getground-app/internal/errors"
+	"github.com/gin-gonic/gin"
+)
+
+type MailController interface {
+	Send(request *gin.Context)
+}
+
+type EmailRequest struct {
+	Kind string `json:"kind" validate:"required,oneof=invitation reminder table_assignment"`
+}
+
+type mailController struct {
+	guestService port.GuestService
+	mailService  port.MailService
+}
+
+func NewMailController(guest port.GuestService, mail port.MailService) MailController {
+	return &mailController{
+		guestService: guest,
+		mailService:  mail,
+	}
+}
+
+// Send emails a guest right away, whatever the reminder schedule
+func (c *mailController) Send(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("guest_id"))
+
+	if err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
+		return
+	}
+
+	body := &EmailRequest{}
+	if !bindJSON(ctx, body) {
+		return
+	}
+
+	if _, err := c.guestService.GetById(ctx, int64(id)); err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.NotFound, err))
+		return
+	}
+
+	if err := c.mailService.Send(ctx, int64(id), body.Kind); err != nil {
+		if stderrors.Is(err, domain.ErrNoEmailAddress) || stderrors.Is(err, domain.ErrNotSeated) {
+			logAndAbort(ctx, errors.NewApiError(errors.Conflict, err))
+			return
+		}
+
+		logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse)
+}
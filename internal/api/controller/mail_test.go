@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eazygood/getground-app/internal/api/controller/testutil"
+	"github.com/eazygood/getground-app/internal/core/domain"
+	mockPort "github.com/eazygood/getground-app/mocks/core/port"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	guestService := mockPort.NewMockGuestService(ctrl)
+	mailService := mockPort.NewMockMailService(ctrl)
+
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+	testutil.MockJsonPost(c, EmailRequest{Kind: domain.EmailInvitation})
+	c.Params = gin.Params{{Key: "guest_id", Value: "4"}}
+
+	guestService.EXPECT().GetById(c, int64(4)).Return(&domain.Guest{ID: 4, Name: "Ada"}, nil).Times(1)
+	mailService.EXPECT().Send(c, int64(4), domain.EmailInvitation).Return(nil).Times(1)
+
+	NewMailController(guestService, mailService).Send(c)
+
+	require.EqualValues(t, http.StatusOK, w.Code)
+}
+
+func TestSendEmailOfUnknownKind(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+	testutil.MockJsonPost(c, EmailRequest{Kind: "postcard"})
+	c.Params = gin.Params{{Key: "guest_id", Value: "4"}}
+
+	NewMailController(mockPort.NewMockGuestService(ctrl), mockPort.NewMockMailService(ctrl)).Send(c)
+
+	require.EqualValues(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestSendTableAssignmentToGuestWithoutTable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	guestService := mockPort.NewMockGuestService(ctrl)
+	mailService := mockPort.NewMockMailService(ctrl)
+
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+	testutil.MockJsonPost(c, EmailRequest{Kind: domain.EmailTableAssignment})
+	c.Params = gin.Params{{Key: "guest_id", Value: "4"}}
+
+	guestService.EXPECT().GetById(c, int64(4)).Return(&domain.Guest{ID: 4, Name: "Ada"}, nil).Times(1)
+	mailService.EXPECT().Send(c, int64(4), domain.EmailTableAssignment).
+		Return(fmt.Errorf("send table_assignment: %w", domain.ErrNotSeated)).Times(1)
+
+	NewMailController(guestService, mailService).Send(c)
+
+	require.EqualValues(t, http.StatusConflict, w.Code)
+}
+
+func TestSendEmailToUnknownGuest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	guestService := mockPort.NewMockGuestService(ctrl)
+
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+	testutil.MockJsonPost(c, EmailRequest{Kind: domain.EmailReminder})
+	c.Params = gin.Params{{Key: "guest_id", Value: "4"}}
+
+	guestService.EXPECT().GetById(c, int64(4)).Return(nil, fmt.Errorf("get guest: record not found by id: 4")).Times(1)
+
+	NewMailController(guestService, mockPort.NewMockMailService(ctrl)).Send(c)
+
+	require.EqualValues(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,477 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/errors"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3gen"
+	"gorm.io/gorm"
+)
+
+// MessageResponse is the body of the successful mutations that return no record
+type MessageResponse struct {
+	Message string `json:"message"`
+}
+
+var successResponse = MessageResponse{Message: "success"}
+
+// GuestPatchRequest documents the members of a guest merge patch, absent members are left untouched
+// and only time_arrived can be null
+type GuestPatchRequest struct {
+	Name               string  `json:"name,omitempty"`
+	AccompanyingGuests uint16  `json:"accompanying_guests,omitempty"`
+	IsArrived          bool    `json:"is_arrived,omitempty"`
+	TimeArrived        *string `json:"time_arrived,omitempty"`
+	// the members of the profile are cleared by null
+	GuestProfile
+}
+
+// TablePatchRequest documents the members of a table merge patch, a null guest_id frees the table
+type TablePatchRequest struct {
+	Seats   uint16 `json:"seats,omitempty"`
+	GuestID *int64 `json:"guest_id,omitempty"`
+}
+
+type apiOperation struct {
+	method  string
+	path    string
+	id      string
+	summary string
+	params  []*openapi3.Parameter
+	body    interface{}
+	// patch bodies are JSON merge patch documents
+	patch    bool
+	status   int
+	response interface{}
+	// contentType documents a response served as is instead of JSON, such as an image
+	contentType string
+	// etag operations return the version of the record in the ETag header
+	etag   bool
+	errors []int
+}
+
+var (
+	guestIDParam     = openapi3.NewPathParameter("guest_id").WithSchema(openapi3.NewInt64Schema())
+	tableIDParam     = openapi3.NewPathParameter("table_id").WithSchema(openapi3.NewInt64Schema())
+	webhookIDParam   = openapi3.NewPathParameter("webhook_id").WithSchema(openapi3.NewInt64Schema())
+	companionIDParam = openapi3.NewPathParameter("companion_id").WithSchema(openapi3.NewInt64Schema())
+	tzParam          = openapi3.NewQueryParameter("tz").WithSchema(openapi3.NewStringSchema()).
+				WithDescription("IANA timezone the timestamps are rendered in, the venue timezone by default")
+	ifMatch = openapi3.NewHeaderParameter("If-Match").WithRequired(true).WithSchema(openapi3.NewStringSchema()).
+		WithDescription("Version of the record last read, as returned in its ETag")
+)
+
+// apiOperations lists every route registered by the server, the OpenAPI document is built from it
+var apiOperations = []apiOperation{
+	{
+		method: http.MethodPost, path: "/guests", id: "createGuest", summary: "Register a guest",
+		params: []*openapi3.Parameter{tzParam}, body: GuestRequest{}, status: http.StatusCreated, response: domain.Guest{},
+		errors: []int{http.StatusBadRequest},
+	},
+	{
+		method: http.MethodPut, path: "/guests/{guest_id}", id: "updateGuest", summary: "Replace a guest",
+		params: []*openapi3.Parameter{guestIDParam, ifMatch}, body: GuestRequest{}, response: MessageResponse{}, etag: true,
+		errors: []int{http.StatusBadRequest, http.StatusPreconditionFailed, http.StatusPreconditionRequired},
+	},
+	{
+		method: http.MethodPatch, path: "/guests/{guest_id}", id: "patchGuest", summary: "Partially update a guest",
+		params: []*openapi3.Parameter{guestIDParam, ifMatch}, body: GuestPatchRequest{}, patch: true, response: MessageResponse{}, etag: true,
+		errors: []int{http.StatusBadRequest, http.StatusConflict, http.StatusPreconditionFailed, http.StatusPreconditionRequired},
+	},
+	{
+		method: http.MethodGet, path: "/guests/{guest_id}", id: "getGuest", summary: "Get a guest",
+		params: []*openapi3.Parameter{guestIDParam, tzParam}, response: domain.Guest{}, etag: true,
+	},
+	{
+		method: http.MethodGet, path: "/guests", id: "listGuests", summary: "List the guests",
+		params: []*openapi3.Parameter{
+			openapi3.NewQueryParameter("arrived").WithSchema(openapi3.NewBoolSchema()).
+				WithDescription("Only the arrived guests, whatever the value"),
+			openapi3.NewQueryParameter("include_deleted").WithSchema(openapi3.NewBoolSchema()).
+				WithDescription("Include the guests who left"),
+			openapi3.NewQueryParameter("dietary").WithSchema(openapi3.NewStringSchema().WithEnum(enum(domain.DietaryRequirements)...)).
+				WithDescription("Only the guests with this dietary requirement"),
+			openapi3.NewQueryParameter("company").WithSchema(openapi3.NewStringSchema()).
+				WithDescription("Only the guests of this company"),
+			openapi3.NewQueryParameter("status").WithSchema(openapi3.NewStringSchema().WithEnum(enum(domain.GuestStatuses)...)).
+				WithDescription("Only the guests in this status"),
+			tzParam,
+		},
+		response: []domain.Guest{},
+		errors:   []int{http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodGet, path: "/guests/search", id: "searchGuests", summary: "Find the guests by name, best matches first",
+		params: []*openapi3.Parameter{
+			openapi3.NewQueryParameter("q").WithRequired(true).WithSchema(openapi3.NewStringSchema().WithMinLength(minSearchLength)).
+				WithDescription("Name or part of it, case, accents and typos are tolerated"),
+			openapi3.NewQueryParameter("limit").WithSchema(openapi3.NewIntegerSchema().WithMin(1).WithMax(maxSearchLimit)).
+				WithDescription("How many matches to list, 10 by default"),
+			tzParam,
+		},
+		response: []domain.GuestMatch{},
+		errors:   []int{http.StatusUnprocessableEntity},
+	},
+	{
+		method: http.MethodDelete, path: "/guests/{guest_id}", id: "deleteGuest", summary: "A guest leaves, their table is freed",
+		params: []*openapi3.Parameter{guestIDParam, ifMatch}, response: MessageResponse{},
+		errors: []int{http.StatusBadRequest, http.StatusConflict, http.StatusPreconditionFailed, http.StatusPreconditionRequired},
+	},
+	{
+		method: http.MethodPost, path: "/guests/{guest_id}/restore", id: "restoreGuest", summary: "Bring back a guest who left",
+		params: []*openapi3.Parameter{guestIDParam}, response: MessageResponse{},
+		errors: []int{http.StatusNotFound},
+	},
+	{
+		method: http.MethodPost, path: "/guests/{guest_id}/rsvp", id: "rsvpGuest", summary: "Record the answer of a guest to their invitation",
+		params: []*openapi3.Parameter{guestIDParam, tzParam}, body: RSVPRequest{}, response: domain.Guest{}, etag: true,
+		errors: []int{http.StatusNotFound, http.StatusConflict, http.StatusPreconditionFailed},
+	},
+	{
+		method: http.MethodPost, path: "/guests/{guest_id}/no_show", id: "markGuestNoShow", summary: "Mark a guest who was expected but did not turn up",
+		params: []*openapi3.Parameter{guestIDParam, tzParam}, response: domain.Guest{}, etag: true,
+		errors: []int{http.StatusNotFound, http.StatusConflict, http.StatusPreconditionFailed},
+	},
+	{
+		method: http.MethodGet, path: "/guests/{guest_id}/ticket.png", id: "getGuestTicket", summary: "Render the ticket of a guest as a QR code",
+		params: []*openapi3.Parameter{guestIDParam}, contentType: "image/png",
+		errors: []int{http.StatusNotFound},
+	},
+	{
+		method: http.MethodPost, path: "/checkin/scan", id: "scanTicket", summary: "Check in and seat the guest of a scanned ticket",
+		body: CheckinScanRequest{}, response: CheckinResponse{},
+		errors: []int{http.StatusNotFound, http.StatusConflict},
+	},
+	{
+		method: http.MethodGet, path: "/guests/{guest_id}/companions", id: "listCompanions", summary: "List the named companions of a guest",
+		params: []*openapi3.Parameter{guestIDParam}, response: []domain.Companion{},
+		errors: []int{http.StatusNotFound},
+	},
+	{
+		method: http.MethodPost, path: "/guests/{guest_id}/companions", id: "addCompanion", summary: "Name one more person a guest brings",
+		params: []*openapi3.Parameter{guestIDParam}, body: CompanionRequest{}, status: http.StatusCreated, response: domain.Companion{},
+		errors: []int{http.StatusNotFound, http.StatusConflict},
+	},
+	{
+		method: http.MethodDelete, path: "/guests/{guest_id}/companions/{companion_id}", id: "removeCompanion", summary: "Remove a companion of a guest",
+		params: []*openapi3.Parameter{guestIDParam, companionIDParam}, response: MessageResponse{},
+		errors: []int{http.StatusNotFound},
+	},
+	{
+		method: http.MethodPost, path: "/guests/{guest_id}/emails", id: "emailGuest", summary: "Send an invitation, a reminder or the table assignment to a guest",
+		params: []*openapi3.Parameter{guestIDParam}, body: EmailRequest{}, response: MessageResponse{},
+		errors: []int{http.StatusNotFound, http.StatusConflict},
+	},
+	{
+		method: http.MethodPost, path: "/guestlist", id: "addToGuestList", summary: "Seat an invited guest at an available table",
+		body: GuestListRequest{}, response: MessageResponse{},
+		errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict},
+	},
+	{
+		method: http.MethodGet, path: "/guestlist", id: "getGuestList", summary: "List the occupied tables",
+		params: []*openapi3.Parameter{tzParam}, response: []domain.Table{},
+	},
+	{
+		method: http.MethodPost, path: "/tables/", id: "createTable", summary: "Add a table",
+		params: []*openapi3.Parameter{tzParam}, body: TableCreateRequest{}, status: http.StatusCreated, response: domain.Table{},
+		errors: []int{http.StatusBadRequest},
+	},
+	{
+		method: http.MethodPut, path: "/tables/{table_id}", id: "updateTable", summary: "Replace a table",
+		params: []*openapi3.Parameter{tableIDParam, ifMatch}, body: TableUpdateeRequest{}, response: MessageResponse{}, etag: true,
+		errors: []int{http.StatusBadRequest, http.StatusPreconditionFailed, http.StatusPreconditionRequired},
+	},
+	{
+		method: http.MethodPatch, path: "/tables/{table_id}", id: "patchTable", summary: "Partially update a table",
+		params: []*openapi3.Parameter{tableIDParam, ifMatch}, body: TablePatchRequest{}, patch: true, response: MessageResponse{}, etag: true,
+		errors: []int{http.StatusBadRequest, http.StatusPreconditionFailed, http.StatusPreconditionRequired},
+	},
+	{
+		method: http.MethodGet, path: "/tables/{table_id}", id: "getTable", summary: "Get a table",
+		params: []*openapi3.Parameter{tableIDParam, tzParam}, response: domain.Table{}, etag: true,
+	},
+	{
+		method: http.MethodGet, path: "/tables/empty_seats", id: "getEmptySeats", summary: "Count the seats of the free tables",
+		response: EmptySeatsResponse{},
+	},
+	{
+		method: http.MethodDelete, path: "/tables/{table_id}", id: "deleteTable", summary: "Remove a table",
+		params: []*openapi3.Parameter{tableIDParam, ifMatch}, response: MessageResponse{},
+		errors: []int{http.StatusBadRequest, http.StatusPreconditionFailed, http.StatusPreconditionRequired},
+	},
+	{
+		method: http.MethodPost, path: "/tables/{table_id}/restore", id: "restoreTable", summary: "Bring back a removed table",
+		params: []*openapi3.Parameter{tableIDParam}, response: MessageResponse{},
+		errors: []int{http.StatusNotFound},
+	},
+	{
+		method: http.MethodGet, path: "/reports/dietary", id: "getDietaryReport", summary: "Sum up the dietary requirements per table for the kitchen",
+		response: domain.DietaryReport{},
+	},
+	{
+		method: http.MethodGet, path: "/reports/capacity", id: "getCapacityReport", summary: "Compare the parties expected at the event with the seats of the tables",
+		response: domain.CapacityReport{},
+	},
+	{
+		method: http.MethodGet, path: "/reports/forecast", id: "getForecastReport", summary: "Forecast the attendance from the arrivals and no-shows of the accepted guests",
+		response: domain.Forecast{},
+	},
+	{
+		method: http.MethodPost, path: "/webhooks", id: "registerWebhook", summary: "Register a webhook, the response holds its signing secret",
+		params: []*openapi3.Parameter{tzParam}, body: WebhookRequest{}, status: http.StatusCreated, response: WebhookCreatedResponse{},
+		errors: []int{http.StatusBadRequest},
+	},
+	{
+		method: http.MethodGet, path: "/webhooks", id: "listWebhooks", summary: "List the webhooks",
+		params: []*openapi3.Parameter{tzParam}, response: []domain.Webhook{},
+	},
+	{
+		method: http.MethodGet, path: "/webhooks/{webhook_id}", id: "getWebhook", summary: "Get a webhook",
+		params: []*openapi3.Parameter{webhookIDParam, tzParam}, response: domain.Webhook{},
+		errors: []int{http.StatusNotFound},
+	},
+	{
+		method: http.MethodDelete, path: "/webhooks/{webhook_id}", id: "deleteWebhook", summary: "Remove a webhook, its pending deliveries are not attempted anymore",
+		params: []*openapi3.Parameter{webhookIDParam}, response: MessageResponse{},
+		errors: []int{http.StatusNotFound},
+	},
+	{
+		method: http.MethodGet, path: "/webhooks/{webhook_id}/deliveries", id: "listWebhookDeliveries", summary: "List the most recent deliveries of a webhook",
+		params: []*openapi3.Parameter{
+			webhookIDParam,
+			openapi3.NewQueryParameter("limit").WithSchema(openapi3.NewIntegerSchema().WithMin(1).WithMax(maxDeliveriesLimit)).
+				WithDescription("How many deliveries to list, 50 by default"),
+			tzParam,
+		},
+		response: []domain.WebhookDelivery{},
+		errors:   []int{http.StatusNotFound, http.StatusUnprocessableEntity},
+	},
+}
+
+var (
+	openAPIOnce sync.Once
+	openAPIDoc  *openapi3.T
+	openAPIErr  error
+)
+
+// OpenAPI returns the OpenAPI 3 document of the API, built once from the request and response types
+func OpenAPI() (*openapi3.T, error) {
+	openAPIOnce.Do(func() {
+		openAPIDoc, openAPIErr = buildOpenAPI()
+	})
+
+	return openAPIDoc, openAPIErr
+}
+
+func buildOpenAPI() (*openapi3.T, error) {
+	doc := &openapi3.T{
+		OpenAPI: "3.0.3",
+		Info: &openapi3.Info{
+			Title:   "GetGround party API",
+			Version: "1.0.0",
+		},
+		Paths: openapi3.Paths{},
+		Components: openapi3.Components{
+			Schemas: openapi3.Schemas{},
+		},
+	}
+
+	for _, operation := range apiOperations {
+		op, err := buildOperation(doc.Components.Schemas, operation)
+		if err != nil {
+			return nil, err
+		}
+
+		item, ok := doc.Paths[operation.path]
+		if !ok {
+			item = &openapi3.PathItem{}
+			doc.Paths[operation.path] = item
+		}
+
+		item.SetOperation(operation.method, op)
+	}
+
+	return doc, nil
+}
+
+func buildOperation(schemas openapi3.Schemas, operation apiOperation) (*openapi3.Operation, error) {
+	op := openapi3.NewOperation()
+	op.OperationID = operation.id
+	op.Summary = operation.summary
+	op.Tags = []string{strings.Split(strings.Trim(operation.path, "/"), "/")[0]}
+
+	for _, param := range operation.params {
+		op.AddParameter(param)
+	}
+
+	if operation.body != nil {
+		ref, err := schemaRef(schemas, operation.body)
+		if err != nil {
+			return nil, err
+		}
+
+		contentTypes := []string{"application/json"}
+		if operation.patch {
+			contentTypes = []string{mergePatchContentType, "application/json"}
+		}
+
+		op.RequestBody = &openapi3.RequestBodyRef{
+			Value: openapi3.NewRequestBody().WithRequired(true).WithSchemaRef(ref, contentTypes),
+		}
+	}
+
+	response := openapi3.NewResponse().WithDescription(http.StatusText(statusOf(operation)))
+	if operation.contentType != "" {
+		response.WithContent(openapi3.NewContentWithSchema(openapi3.NewStringSchema().WithFormat("binary"), []string{operation.contentType}))
+	} else {
+		ref, err := schemaRef(schemas, operation.response)
+		if err != nil {
+			return nil, err
+		}
+
+		response.WithJSONSchemaRef(ref)
+	}
+
+	if operation.etag {
+		response.Headers = openapi3.Headers{
+			"ETag": &openapi3.HeaderRef{Value: &openapi3.Header{Parameter: openapi3.Parameter{
+				Description: "Version of the record, to send back in If-Match",
+				Schema:      openapi3.NewStringSchema().NewRef(),
+			}}},
+		}
+	}
+
+	op.AddResponse(statusOf(operation), response)
+
+	errorRef, err := schemaRef(schemas, errors.ApiError{})
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := append([]int{}, operation.errors...)
+	if operation.body != nil && !operation.patch {
+		statuses = append(statuses, http.StatusUnprocessableEntity)
+	}
+
+	// every handler answers unexpected failures with a 500
+	for _, status := range append(statuses, http.StatusInternalServerError) {
+		op.AddResponse(status, openapi3.NewResponse().WithDescription(http.StatusText(status)).WithJSONSchemaRef(errorRef))
+	}
+
+	return op, nil
+}
+
+func statusOf(operation apiOperation) int {
+	if operation.status == 0 {
+		return http.StatusOK
+	}
+
+	return operation.status
+}
+
+// schemaRef registers the schema of the type of value in the components and returns a reference to it,
+// slices are documented as arrays of their element
+func schemaRef(schemas openapi3.Schemas, value interface{}) (*openapi3.SchemaRef, error) {
+	t := reflect.TypeOf(value)
+	if t.Kind() == reflect.Slice {
+		items, err := schemaRef(schemas, reflect.Zero(t.Elem()).Interface())
+		if err != nil {
+			return nil, err
+		}
+
+		array := openapi3.NewArraySchema()
+		array.Items = items
+
+		return openapi3.NewSchemaRef("", array), nil
+	}
+
+	name := t.Name()
+	if _, ok := schemas[name]; !ok {
+		ref, err := openapi3gen.NewSchemaRefForValue(value, schemas, openapi3gen.SchemaCustomizer(customizeSchema))
+		if err != nil {
+			return nil, err
+		}
+
+		markNullable(t, ref.Value)
+		schemas[name] = ref
+	}
+
+	return openapi3.NewSchemaRef("#/components/schemas/"+name, schemas[name].Value), nil
+}
+
+var (
+	deletedAtType  = reflect.TypeOf(gorm.DeletedAt{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+func customizeSchema(_ string, t reflect.Type, tag reflect.StructTag, schema *openapi3.Schema) error {
+	// soft delete timestamps are rendered as a nullable time
+	if t == deletedAtType {
+		*schema = *openapi3.NewDateTimeSchema().WithNullable()
+	}
+
+	// raw payloads are embedded as JSON, not as bytes
+	if t == rawMessageType {
+		*schema = *openapi3.NewObjectSchema()
+	}
+
+	// bounds of the validation rules are documented as schema bounds
+	for _, rule := range strings.Split(tag.Get("validate"), ",") {
+		name, param, _ := strings.Cut(rule, "=")
+
+		if name == "dietary" && schema.Items != nil {
+			schema.Items.Value.Enum = enum(domain.DietaryRequirements)
+		}
+
+		bound, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			continue
+		}
+
+		switch {
+		case name == "min" && schema.Type == openapi3.TypeArray:
+			schema.MinItems = uint64(bound)
+		case name == "min" && schema.Type == openapi3.TypeString:
+			schema.MinLength = uint64(bound)
+		case name == "max" && schema.Type == openapi3.TypeString:
+			schema.MaxLength = openapi3.Uint64Ptr(uint64(bound))
+		case name == "min":
+			schema.Min = openapi3.Float64Ptr(bound)
+		case name == "max":
+			schema.Max = openapi3.Float64Ptr(bound)
+		}
+	}
+
+	return nil
+}
+
+func enum(values []string) []interface{} {
+	enum := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		enum = append(enum, value)
+	}
+
+	return enum
+}
+
+// markNullable flags the properties backed by pointers, they are rendered as null when unset
+func markNullable(t reflect.Type, schema *openapi3.Schema) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Type.Kind() != reflect.Ptr {
+			continue
+		}
+
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if property, ok := schema.Properties[name]; ok && property.Value != nil {
+			property.Value.Nullable = true
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPIIsValid(t *testing.T) {
+	doc, err := OpenAPI()
+	require.NoError(t, err)
+
+	require.NoError(t, doc.Validate(context.Background()))
+}
+
+func TestOpenAPISchemas(t *testing.T) {
+	doc, err := OpenAPI()
+	require.NoError(t, err)
+
+	guest := doc.Components.Schemas["Guest"].Value
+	require.ElementsMatch(t,
+		[]string{"id", "name", "accompanying_guests", "time_arrived", "is_arrived", "version", "deleted_at",
+			"email", "phone", "company", "dietary", "dietary_notes", "accessibility", "notes", "status", "party_size", "responded_at"},
+		keys(guest.Properties))
+	require.True(t, guest.Properties["time_arrived"].Value.Nullable)
+	require.Equal(t, "date-time", guest.Properties["deleted_at"].Value.Format)
+	require.True(t, guest.Properties["deleted_at"].Value.Nullable)
+
+	table := doc.Components.Schemas["Table"].Value
+	require.NotContains(t, table.Properties, "Guest")
+	require.True(t, table.Properties["guest_id"].Value.Nullable)
+
+	patch := doc.Paths["/guests/{guest_id}"].Patch.RequestBody.Value
+	require.Contains(t, patch.Content, mergePatchContentType)
+}
+
+func TestServeOpenAPI(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	NewDocsController().OpenAPI(c)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	doc := openapi3.T{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+	require.Equal(t, "3.0.3", doc.OpenAPI)
+	require.Contains(t, doc.Paths, "/guestlist")
+}
+
+func keys(properties openapi3.Schemas) []string {
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+
+	return names
+}
@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// mergePatchContentType is the media type of JSON merge patch documents (RFC 7396)
+const mergePatchContentType = "application/merge-patch+json"
+
+// readMergePatch reads the top level members of a JSON merge patch document.
+// Members that are present but null are kept so callers can tell them apart from absent ones.
+func readMergePatch(request *gin.Context) (map[string]json.RawMessage, error) {
+	if contentType := request.ContentType(); contentType != mergePatchContentType && contentType != gin.MIMEJSON {
+		return nil, fmt.Errorf("unsupported content type %q, use %s", contentType, mergePatchContentType)
+	}
+
+	raw, err := request.GetRawData()
+	if err != nil {
+		return nil, err
+	}
+
+	patch := map[string]json.RawMessage{}
+	if err := json.Unmarshal(raw, &patch); err != nil {
+		return nil, fmt.Errorf("invalid merge patch document: %v", err)
+	}
+
+	return patch, nil
+}
+
+func isNull(value json.RawMessage) bool {
+	return bytes.Equal(bytes.TrimSpace(value), []byte("null"))
+}
+
+// decodeMember decodes a patch member that cannot be removed, so null is rejected
+func decodeMember(field string, value json.RawMessage, dst interface{}) error {
+	if isNull(value) {
+		return fmt.Errorf("%s cannot be null", field)
+	}
+
+	if err := json.Unmarshal(value, dst); err != nil {
+		return fmt.Errorf("invalid %s: %v", field, err)
+	}
+
+	return nil
+}
+
+// decodeOptionalMember decodes a patch member that is removed by null, dst is left to its zero value then
+func decodeOptionalMember(field string, value json.RawMessage, dst interface{}) error {
+	if isNull(value) {
+		return nil
+	}
+
+	return decodeMember(field, value, dst)
+}
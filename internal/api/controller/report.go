@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/eazygood/getground-app/internal/core/port"
+	"github.com/eazygood/getground-app/internal/errors"
+	"github.com/gin-gonic/gin"
+)
+
+type ReportController interface {
+	Dietary(request *gin.Context)
+	Capacity(request *gin.Context)
+	Forecast(request *gin.Context)
+}
+
+type reportController struct {
+	reportService port.ReportService
+}
+
+func NewReportController(reportService port.ReportService) ReportController {
+	return &reportController{
+		reportService: reportService,
+	}
+}
+
+// Dietary sums up the dietary requirements of the guests per table for the kitchen
+func (r *reportController) Dietary(ctx *gin.Context) {
+	report, err := r.reportService.Dietary(ctx)
+	if err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// Capacity compares the parties expected at the event with the tables of the venue
+func (r *reportController) Capacity(ctx *gin.Context) {
+	report, err := r.reportService.Capacity(ctx)
+	if err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// Forecast predicts the attendance from the guests who accepted and arrived or not so far
+func (r *reportController) Forecast(ctx *gin.Context) {
+	report, err := r.reportService.Forecast(ctx)
+	if err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/eazygood/getground-app/internal/api/controller/testutil"
+	"github.com/eazygood/getground-app/internal/core/domain"
+	mockPort "github.com/eazygood/getground-app/mocks/core/port"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDietaryReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	reportService := mockPort.NewMockReportService(ctrl)
+
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+	testutil.MockJsonGet(c, gin.Params{}, url.Values{})
+
+	tableID := int64(4)
+	reportService.EXPECT().Dietary(c).Return(&domain.DietaryReport{
+		Tables: []domain.DietaryGroup{{
+			TableID: &tableID,
+			People:  3,
+			Counts:  map[string]int{domain.DietaryVegan: 1},
+			Guests:  []domain.DietaryGuest{{GuestID: 1, Name: "Ada", Dietary: domain.Dietary{domain.DietaryVegan}}},
+		}},
+		Unseated: domain.DietaryGroup{Counts: map[string]int{}, Guests: []domain.DietaryGuest{}},
+		Totals:   map[string]int{domain.DietaryVegan: 1},
+	}, nil).Times(1)
+
+	NewReportController(reportService).Dietary(c)
+
+	require.EqualValues(t, http.StatusOK, w.Code)
+	require.Equal(t, `{"tables":[{"table_id":4,"people":3,"counts":{"vegan":1},"guests":[{"guest_id":1,"name":"Ada","dietary":["vegan"]}]}],`+
+		`"unseated":{"table_id":null,"people":0,"counts":{},"guests":[]},"totals":{"vegan":1}}`, w.Body.String())
+}
+
+func TestDietaryReportFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	reportService := mockPort.NewMockReportService(ctrl)
+
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+	testutil.MockJsonGet(c, gin.Params{}, url.Values{})
+
+	reportService.EXPECT().Dietary(c).Return(nil, fmt.Errorf("get dietary report: connection refused")).Times(1)
+
+	NewReportController(reportService).Dietary(c)
+
+	require.EqualValues(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestCapacityReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	reportService := mockPort.NewMockReportService(ctrl)
+
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+	testutil.MockJsonGet(c, gin.Params{}, url.Values{})
+
+	reportService.EXPECT().Capacity(c).Return(&domain.CapacityReport{
+		Tables:    2,
+		Seats:     6,
+		Statuses:  map[string]int{domain.GuestStatusAccepted: 1},
+		Parties:   1,
+		People:    4,
+		FreeSeats: 2,
+	}, nil).Times(1)
+
+	NewReportController(reportService).Capacity(c)
+
+	require.EqualValues(t, http.StatusOK, w.Code)
+	require.Equal(t, `{"tables":2,"seats":6,"statuses":{"accepted":1},"parties":1,"people":4,"tentative_parties":0,"tentative_people":0,`+
+		`"free_seats":2,"unseatable_parties":0}`, w.Body.String())
+}
+
+func TestForecastReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	reportService := mockPort.NewMockReportService(ctrl)
+
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+	testutil.MockJsonGet(c, gin.Params{}, url.Values{})
+
+	reportService.EXPECT().Forecast(c).Return(&domain.Forecast{
+		Samples:        4,
+		Trusted:        true,
+		Arrivals:       3,
+		NoShows:        1,
+		PlannedPeople:  8,
+		ActualPeople:   6,
+		NoShowRate:     0.25,
+		EntourageRatio: 0.75,
+		Seats:          10,
+		PresentPeople:  6,
+		PendingParties: 1,
+		PendingPeople:  4,
+		ExpectedPeople: 8.25,
+	}, nil).Times(1)
+
+	NewReportController(reportService).Forecast(c)
+
+	require.EqualValues(t, http.StatusOK, w.Code)
+	require.Equal(t, `{"samples":4,"trusted":true,"arrivals":3,"no_shows":1,"planned_people":8,"actual_people":6,`+
+		`"no_show_rate":0.25,"entourage_ratio":0.75,"seats":10,"present_people":6,"pending_parties":1,"pending_people":4,`+
+		`"expected_people":8.25}`, w.Body.String())
+}
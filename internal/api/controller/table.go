@@ -1,35 +1,81 @@
 package controller
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
 
 	"github.com/eazygood/getground-app/internal/core/domain"
 	"github.com/eazygood/getground-app/internal/core/port"
 	"github.com/eazygood/getground-app/internal/errors"
+	v "github.com/eazygood/getground-app/internal/validator"
 	"github.com/gin-gonic/gin"
 )
 
 type TableController interface {
 	Create(request *gin.Context)
+	GetById(request *gin.Context)
 	GetEmptySeats(request *gin.Context)
 	Update(request *gin.Context)
+	Patch(request *gin.Context)
 	Delete(request *gin.Context)
+	Restore(request *gin.Context)
 }
 
 type TableCreateRequest struct {
-	Seats uint16 `json:"seats"`
+	Seats uint16 `json:"seats" validate:"min=1,max=100"`
 }
 type TableUpdateeRequest struct {
-	Seats   uint16 `json:"seats"`
-	GuestID int64  `json:"guest_id"`
+	Seats   uint16 `json:"seats" validate:"min=1,max=100"`
+	GuestID int64  `json:"guest_id" validate:"min=0"`
 }
 
 type EmptySeatsResponse struct {
 	EmptySeats int64 `json:"empty_seats"`
 }
 
+func createTablePatchFromRequest(patch map[string]json.RawMessage) (*port.TablePatch, error) {
+	p := port.TablePatch{}
+
+	for field, value := range patch {
+		var err error
+
+		switch field {
+		case "seats":
+			err = decodeMember(field, value, &p.Table.Seats)
+		case "guest_id":
+			if isNull(value) {
+				break
+			}
+
+			var guestID int64
+			if err = decodeMember(field, value, &guestID); err == nil {
+				p.Table.GuestID = &guestID
+			}
+		default:
+			err = fmt.Errorf("field %s cannot be patched", field)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		p.Fields = append(p.Fields, field)
+	}
+
+	if _, ok := patch["seats"]; ok {
+		if err := v.GetValidator().StructPartial(TableUpdateeRequest{Seats: p.Table.Seats}, "Seats"); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Strings(p.Fields)
+
+	return &p, nil
+}
+
 type tableController struct {
 	tableService port.TableService
 	guestService port.GuestService
@@ -43,9 +89,13 @@ func NewTableController(tableService port.TableService, guestService port.GuestS
 }
 
 func (t *tableController) Create(ctx *gin.Context) {
+	loc, ok := requestLocation(ctx)
+	if !ok {
+		return
+	}
+
 	body := &TableCreateRequest{}
-	if err := ctx.ShouldBindJSON(body); err != nil {
-		logAndAbort(ctx, errors.NewApiError(errors.InvalidInput, err))
+	if !bindJSON(ctx, body) {
 		return
 	}
 
@@ -58,6 +108,7 @@ func (t *tableController) Create(ctx *gin.Context) {
 		return
 	}
 
+	renderTables(loc, table)
 	ctx.JSON(http.StatusCreated, table)
 }
 
@@ -69,49 +120,85 @@ func (t *tableController) Update(ctx *gin.Context) {
 		return
 	}
 
+	version, ok := requireIfMatch(ctx)
+	if !ok {
+		return
+	}
+
 	body := TableUpdateeRequest{}
-	if err := ctx.ShouldBindJSON(&body); err != nil {
-		logAndAbort(ctx, errors.NewApiError(errors.InvalidInput, err))
+	if !bindJSON(ctx, &body) {
 		return
 	}
 
 	tbl := domain.Table{}
 	tbl.Seats = body.Seats
 	tbl.GuestID = &body.GuestID
+	tbl.Version = version
 
 	if body.GuestID != 0 {
-		table, err := t.tableService.GetById(ctx, int64(id))
-
-		if err != nil {
+		if err := t.canSeatGuest(ctx, int64(id), body.GuestID, nil); err != nil {
 			logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
 			return
 		}
+	}
 
-		if table.GuestID != nil {
-			logAndAbort(ctx, errors.NewApiError(errors.Internal, fmt.Errorf("table already has guest")))
-			return
-		}
+	err = t.tableService.Update(ctx, int64(id), tbl)
+	if err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
+		return
+	}
 
-		g, err := t.guestService.GetById(ctx, body.GuestID)
+	setETag(ctx, version+1)
+	ctx.JSON(http.StatusOK, successResponse)
+}
 
-		if err != nil {
-			logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
-			return
+func (t *tableController) Patch(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("table_id"))
+
+	if err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
+		return
+	}
+
+	version, ok := requireIfMatch(ctx)
+	if !ok {
+		return
+	}
+
+	body, err := readMergePatch(ctx)
+	if err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.InvalidInput, err))
+		return
+	}
+
+	patch, err := createTablePatchFromRequest(body)
+	if err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.InvalidInput, err))
+		return
+	}
+
+	patch.Table.Version = version
+
+	if patch.Table.GuestID != nil {
+		var seats *uint16
+		if _, ok := body["seats"]; ok {
+			seats = &patch.Table.Seats
 		}
 
-		if g.AccompanyingGuests > table.Seats {
-			logAndAbort(ctx, errors.NewApiError(errors.Internal, fmt.Errorf("guest accompanying guests exceeded table available seats")))
+		if err := t.canSeatGuest(ctx, int64(id), *patch.Table.GuestID, seats); err != nil {
+			logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
 			return
 		}
 	}
 
-	err = t.tableService.Update(ctx, int64(id), tbl)
+	err = t.tableService.Patch(ctx, int64(id), *patch)
 	if err != nil {
 		logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"message": "success"})
+	setETag(ctx, version+1)
+	ctx.JSON(http.StatusOK, successResponse)
 }
 
 func (t *tableController) Delete(ctx *gin.Context) {
@@ -122,13 +209,59 @@ func (t *tableController) Delete(ctx *gin.Context) {
 		return
 	}
 
-	err = t.tableService.Delete(ctx, int64(id))
+	version, ok := requireIfMatch(ctx)
+	if !ok {
+		return
+	}
+
+	err = t.tableService.Delete(ctx, int64(id), version)
 	if err != nil {
 		logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"message": "success"})
+	ctx.JSON(http.StatusOK, successResponse)
+}
+
+func (t *tableController) Restore(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("table_id"))
+
+	if err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
+		return
+	}
+
+	err = t.tableService.Restore(ctx, int64(id))
+	if err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.NotFound, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse)
+}
+
+func (t *tableController) GetById(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("table_id"))
+
+	if err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
+		return
+	}
+
+	loc, ok := requestLocation(ctx)
+	if !ok {
+		return
+	}
+
+	table, err := t.tableService.GetById(ctx, int64(id))
+	if err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
+		return
+	}
+
+	renderTables(loc, table)
+	setETag(ctx, table.Version)
+	ctx.JSON(http.StatusOK, table)
 }
 
 func (t *tableController) GetEmptySeats(ctx *gin.Context) {
@@ -140,3 +273,45 @@ func (t *tableController) GetEmptySeats(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, EmptySeatsResponse{EmptySeats: emptySeats})
 }
+
+// canSeatGuest checks that the table is free and large enough for the guest and their entourage.
+// seats overrides the current number of seats of the table when it is being changed as well.
+// Both are read as last written, a replica lagging behind could show a taken table as free.
+func (t *tableController) canSeatGuest(ctx *gin.Context, tableID int64, guestID int64, seats *uint16) error {
+	tables, err := t.tableService.GetList(ctx, port.GetTableFilter{IDs: []int64{tableID}, Latest: true})
+
+	if err != nil {
+		return err
+	}
+
+	if len(tables) == 0 {
+		return fmt.Errorf("record not found by id: %v", tableID)
+	}
+
+	table := tables[0]
+	if table.GuestID != nil {
+		return fmt.Errorf("table already has guest")
+	}
+
+	guests, err := t.guestService.GetList(ctx, port.GetGuestFilter{IDs: []int64{guestID}, Latest: true})
+
+	if err != nil {
+		return err
+	}
+
+	if len(guests) == 0 {
+		return fmt.Errorf("record not found by id: %v", guestID)
+	}
+
+	g := guests[0]
+
+	if seats == nil {
+		seats = &table.Seats
+	}
+
+	if g.AccompanyingGuests > *seats {
+		return fmt.Errorf("guest accompanying guests exceeded table available seats")
+	}
+
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/eazygood/getground-app/internal/api/controller/testutil"
 	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
 	mockPort "github.com/eazygood/getground-app/mocks/core/port"
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
@@ -41,6 +42,20 @@ func (g *TableControllereSuite) TearDownTest() {
 	g.ctrl.Finish()
 }
 
+func (g *TableControllereSuite) TestCreateTableValidationFailed() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonPost(c, TableCreateRequest{Seats: 0})
+
+	g.tableController.Create(c)
+
+	g.EqualValues(http.StatusUnprocessableEntity, w.Code)
+
+	wantJson := `{"code":422,"message":"validation failed","errors":[{"field":"seats","rule":"min","message":"seats must be at least 1"}]}`
+	g.Equal(wantJson, w.Body.String())
+}
+
 func (g *TableControllereSuite) TestCreateTable() {
 	w := httptest.NewRecorder()
 	c := testutil.GetTestGinContext(w)
@@ -56,8 +71,9 @@ func (g *TableControllereSuite) TestCreateTable() {
 	}
 
 	want := &domain.Table{
-		ID:    1,
-		Seats: 15,
+		ID:      1,
+		Seats:   15,
+		Version: 1,
 	}
 	g.mockTableService.EXPECT().Create(c, gomock.Eq(tableData)).Return(want, nil).Times(1)
 
@@ -68,7 +84,7 @@ func (g *TableControllereSuite) TestCreateTable() {
 
 	g.EqualValues(http.StatusCreated, w.Code)
 
-	wantJson := `{"id":1,"seats":15,"guest_id":null}`
+	wantJson := `{"id":1,"seats":15,"guest_id":null,"version":1,"deleted_at":null}`
 	got, _ := io.ReadAll(res.Body)
 
 	g.Equal(wantJson, string(got))
@@ -90,12 +106,14 @@ func (g *TableControllereSuite) TestUpdateTable() {
 	}
 
 	testutil.MockJsonPut(c, body, params)
+	c.Request.Header.Set("If-Match", `"1"`)
 
 	tableId := 1
 	guest := domain.Guest{}
 	tableData := domain.Table{
 		Seats:   15,
 		GuestID: &guest.ID,
+		Version: 1,
 	}
 
 	g.mockTableService.EXPECT().Update(c, int64(tableId), gomock.Eq(tableData)).Return(nil).Times(1)
@@ -130,6 +148,7 @@ func (g *TableControllereSuite) TestUpdateTableWithGuestId() {
 	}
 
 	testutil.MockJsonPut(c, body, params)
+	c.Request.Header.Set("If-Match", `"1"`)
 
 	tableId := 1
 	guest := domain.Guest{
@@ -140,14 +159,15 @@ func (g *TableControllereSuite) TestUpdateTableWithGuestId() {
 	tableData := domain.Table{
 		Seats:   15,
 		GuestID: &guest.ID,
+		Version: 1,
 	}
 
 	returnTableData := domain.Table{
 		Seats: 15,
 	}
 
-	g.mockTableService.EXPECT().GetById(c, int64(tableId)).Return(&returnTableData, nil).Times(1)
-	g.mockGuestService.EXPECT().GetById(c, int64(tableId)).Return(&guest, nil).Times(1)
+	g.mockTableService.EXPECT().GetList(c, port.GetTableFilter{IDs: []int64{int64(tableId)}, Latest: true}).Return([]*domain.Table{&returnTableData}, nil).Times(1)
+	g.mockGuestService.EXPECT().GetList(c, port.GetGuestFilter{IDs: []int64{int64(tableId)}, Latest: true}).Return([]*domain.Guest{&guest}, nil).Times(1)
 	g.mockTableService.EXPECT().Update(c, int64(tableId), gomock.Eq(tableData)).Return(nil).Times(1)
 
 	g.tableController.Update(c)
@@ -180,6 +200,7 @@ func (g *TableControllereSuite) TestUpdateTableWithGuestIdAndAccompanyingGuestEx
 	}
 
 	testutil.MockJsonPut(c, body, params)
+	c.Request.Header.Set("If-Match", `"1"`)
 
 	tableId := 1
 	guest := domain.Guest{
@@ -192,8 +213,8 @@ func (g *TableControllereSuite) TestUpdateTableWithGuestIdAndAccompanyingGuestEx
 		Seats: 15,
 	}
 
-	g.mockTableService.EXPECT().GetById(c, int64(tableId)).Return(&returnTableData, nil).Times(1)
-	g.mockGuestService.EXPECT().GetById(c, int64(tableId)).Return(&guest, nil).Times(1)
+	g.mockTableService.EXPECT().GetList(c, port.GetTableFilter{IDs: []int64{int64(tableId)}, Latest: true}).Return([]*domain.Table{&returnTableData}, nil).Times(1)
+	g.mockGuestService.EXPECT().GetList(c, port.GetGuestFilter{IDs: []int64{int64(tableId)}, Latest: true}).Return([]*domain.Guest{&guest}, nil).Times(1)
 
 	g.tableController.Update(c)
 
@@ -220,10 +241,11 @@ func (g *TableControllereSuite) TestDeleteTable() {
 	}
 
 	testutil.MockJsonDelete(c, params)
+	c.Request.Header.Set("If-Match", `"1"`)
 
 	tableID := 1
 
-	g.mockTableService.EXPECT().Delete(c, int64(tableID)).Return(nil).Times(1)
+	g.mockTableService.EXPECT().Delete(c, int64(tableID), int64(1)).Return(nil).Times(1)
 	g.tableController.Delete(c)
 
 	res := w.Result()
@@ -237,6 +259,139 @@ func (g *TableControllereSuite) TestDeleteTable() {
 	g.Equal(`{"message":"success"}`, string(got))
 }
 
+func (g *TableControllereSuite) TestPatchTableClearGuest() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	params := []gin.Param{
+		{
+			Key:   "table_id",
+			Value: "1",
+		},
+	}
+
+	testutil.MockJsonMergePatch(c, `{"guest_id":null,"seats":4}`, params)
+	c.Request.Header.Set("If-Match", `"1"`)
+
+	patch := port.TablePatch{
+		Table:  domain.Table{Seats: 4, Version: 1},
+		Fields: []string{"guest_id", "seats"},
+	}
+
+	g.mockTableService.EXPECT().Patch(c, int64(1), patch).Return(nil).Times(1)
+
+	g.tableController.Patch(c)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	g.EqualValues(http.StatusOK, w.Code)
+	g.Equal(`"2"`, res.Header.Get("ETag"))
+}
+
+func (g *TableControllereSuite) TestPatchTableGuestExceedsPatchedSeats() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	params := []gin.Param{
+		{
+			Key:   "table_id",
+			Value: "1",
+		},
+	}
+
+	testutil.MockJsonMergePatch(c, `{"guest_id":1,"seats":2}`, params)
+	c.Request.Header.Set("If-Match", `"1"`)
+
+	guest := domain.Guest{
+		ID:                 1,
+		AccompanyingGuests: 5,
+	}
+
+	g.mockTableService.EXPECT().GetList(c, port.GetTableFilter{IDs: []int64{1}, Latest: true}).Return([]*domain.Table{{ID: 1, Seats: 10}}, nil).Times(1)
+	g.mockGuestService.EXPECT().GetList(c, port.GetGuestFilter{IDs: []int64{1}, Latest: true}).Return([]*domain.Guest{&guest}, nil).Times(1)
+
+	g.tableController.Patch(c)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	g.EqualValues(http.StatusInternalServerError, w.Code)
+
+	wantJson := `{"code":500,"message":"guest accompanying guests exceeded table available seats"}`
+	got, _ := io.ReadAll(res.Body)
+
+	g.Equal(wantJson, string(got))
+}
+
+func (g *TableControllereSuite) TestPatchTableValidationFailed() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonMergePatch(c, `{"seats":0}`, []gin.Param{{Key: "table_id", Value: "1"}})
+	c.Request.Header.Set("If-Match", `"1"`)
+
+	g.tableController.Patch(c)
+
+	g.EqualValues(http.StatusUnprocessableEntity, w.Code)
+
+	wantJson := `{"code":422,"message":"validation failed","errors":[{"field":"seats","rule":"min","message":"seats must be at least 1"}]}`
+	g.Equal(wantJson, w.Body.String())
+}
+
+func (g *TableControllereSuite) TestDeleteTableWithoutIfMatch() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	params := []gin.Param{
+		{
+			Key:   "table_id",
+			Value: "1",
+		},
+	}
+
+	testutil.MockJsonDelete(c, params)
+
+	g.tableController.Delete(c)
+
+	g.EqualValues(http.StatusPreconditionRequired, w.Code)
+}
+
+func (g *TableControllereSuite) TestGetByIdTable() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	params := []gin.Param{
+		{
+			Key:   "table_id",
+			Value: "1",
+		},
+	}
+
+	testutil.MockJsonGet(c, params, url.Values{})
+
+	table := &domain.Table{
+		ID:      1,
+		Seats:   10,
+		Version: 4,
+	}
+
+	g.mockTableService.EXPECT().GetById(c, int64(1)).Return(table, nil).Times(1)
+
+	g.tableController.GetById(c)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	g.EqualValues(http.StatusOK, w.Code)
+	g.Equal(`"4"`, res.Header.Get("ETag"))
+
+	wantJson := `{"id":1,"seats":10,"guest_id":null,"version":4,"deleted_at":null}`
+	got, _ := io.ReadAll(res.Body)
+
+	g.Equal(wantJson, string(got))
+}
+
 func (g *TableControllereSuite) TestGetEmptySeatsTable() {
 	w := httptest.NewRecorder()
 	c := testutil.GetTestGinContext(w)
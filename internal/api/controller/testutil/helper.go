@@ -39,6 +39,15 @@ func MockJsonPut(c *gin.Context, content interface{}, params gin.Params) {
 	c.Request.Body = io.NopCloser(bytes.NewBuffer(jsonbytes))
 }
 
+func MockJsonMergePatch(c *gin.Context, document string, params gin.Params) {
+	c.Request.Method = "PATCH"
+	c.Request.Header.Set("Content-Type", "application/merge-patch+json")
+	c.Params = params
+
+	// the document is passed as is, so tests can send explicit nulls
+	c.Request.Body = io.NopCloser(bytes.NewBufferString(document))
+}
+
 func MockJsonDelete(c *gin.Context, params gin.Params) {
 	c.Request.Method = "DELETE"
 	c.Request.Header.Set("Content-Type", "application/json")
@@ -0,0 +1,201 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	"github.com/eazygood/getground-app/internal/errors"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultDeliveriesLimit = 50
+	maxDeliveriesLimit     = 500
+)
+
+type WebhookController interface {
+	Create(request *gin.Context)
+	GetById(request *gin.Context)
+	GetList(request *gin.Context)
+	Delete(request *gin.Context)
+	GetDeliveries(request *gin.Context)
+}
+
+type WebhookRequest struct {
+	URL        string   `json:"url" validate:"required,http_url"`
+	EventTypes []string `json:"event_types" validate:"required,min=1,dive,event_type"`
+	// Secret signs the payloads, one is generated when it is left out
+	Secret string `json:"secret,omitempty" validate:"omitempty,min=16,max=128"`
+}
+
+// WebhookCreatedResponse is the registered webhook along with its secret, which is never shown again
+type WebhookCreatedResponse struct {
+	ID         int64     `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	Secret     string    `json:"secret"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type webhookController struct {
+	webhookService port.WebhookService
+}
+
+func NewWebhookController(webhookService port.WebhookService) WebhookController {
+	return &webhookController{
+		webhookService: webhookService,
+	}
+}
+
+func (w *webhookController) Create(ctx *gin.Context) {
+	loc, ok := requestLocation(ctx)
+	if !ok {
+		return
+	}
+
+	body := &WebhookRequest{}
+	if !bindJSON(ctx, body) {
+		return
+	}
+
+	webhook, err := w.webhookService.Register(ctx, &domain.Webhook{
+		URL:        body.URL,
+		EventTypes: body.EventTypes,
+		Secret:     body.Secret,
+	})
+
+	if err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, WebhookCreatedResponse{
+		ID:         webhook.ID,
+		URL:        webhook.URL,
+		EventTypes: webhook.EventTypes,
+		Secret:     webhook.Secret,
+		CreatedAt:  webhook.CreatedAt.In(loc),
+	})
+}
+
+func (w *webhookController) GetById(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("webhook_id"))
+
+	if err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
+		return
+	}
+
+	loc, ok := requestLocation(ctx)
+	if !ok {
+		return
+	}
+
+	webhook, err := w.webhookService.GetById(ctx, int64(id))
+	if err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.NotFound, err))
+		return
+	}
+
+	renderWebhooks(loc, webhook)
+	ctx.JSON(http.StatusOK, webhook)
+}
+
+func (w *webhookController) GetList(ctx *gin.Context) {
+	loc, ok := requestLocation(ctx)
+	if !ok {
+		return
+	}
+
+	webhooks, err := w.webhookService.GetList(ctx)
+	if err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
+		return
+	}
+
+	renderWebhooks(loc, webhooks...)
+	ctx.JSON(http.StatusOK, webhooks)
+}
+
+func (w *webhookController) Delete(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("webhook_id"))
+
+	if err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
+		return
+	}
+
+	if err := w.webhookService.Delete(ctx, int64(id)); err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.NotFound, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse)
+}
+
+// GetDeliveries lists the most recent deliveries of a webhook, ?limit= of them
+func (w *webhookController) GetDeliveries(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("webhook_id"))
+
+	if err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
+		return
+	}
+
+	loc, ok := requestLocation(ctx)
+	if !ok {
+		return
+	}
+
+	filter := port.GetDeliveriesFilter{WebhookID: int64(id), Limit: defaultDeliveriesLimit}
+
+	if limit, ok := ctx.GetQuery("limit"); ok {
+		filter.Limit, err = strconv.Atoi(limit)
+		if err != nil || filter.Limit < 1 || filter.Limit > maxDeliveriesLimit {
+			logAndAbort(ctx, errors.NewApiError(errors.InvalidInput, errors.NewValidationError(errors.FieldError{
+				Field:   "limit",
+				Rule:    "range",
+				Message: fmt.Sprintf("limit must be an integer between 1 and %d", maxDeliveriesLimit),
+			})))
+
+			return
+		}
+	}
+
+	if _, err := w.webhookService.GetById(ctx, int64(id)); err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.NotFound, err))
+		return
+	}
+
+	deliveries, err := w.webhookService.GetDeliveries(ctx, filter)
+	if err != nil {
+		logAndAbort(ctx, errors.NewApiError(errors.Internal, err))
+		return
+	}
+
+	renderDeliveries(loc, deliveries...)
+	ctx.JSON(http.StatusOK, deliveries)
+}
+
+func renderWebhooks(loc *time.Location, webhooks ...*domain.Webhook) {
+	for _, webhook := range webhooks {
+		webhook.CreatedAt = webhook.CreatedAt.In(loc)
+	}
+}
+
+func renderDeliveries(loc *time.Location, deliveries ...*domain.WebhookDelivery) {
+	for _, delivery := range deliveries {
+		delivery.CreatedAt = delivery.CreatedAt.In(loc)
+
+		for _, t := range []**time.Time{&delivery.NextAttemptAt, &delivery.DeliveredAt} {
+			if *t != nil {
+				local := (*t).In(loc)
+				*t = &local
+			}
+		}
+	}
+}
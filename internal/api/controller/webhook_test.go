@@ -0,0 +1,153 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eazygood/getground-app/internal/api/controller/testutil"
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	mockPort "github.com/eazygood/getground-app/mocks/core/port"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type WebhookControllerSuite struct {
+	suite.Suite
+	*require.Assertions
+	ctrl               *gomock.Controller
+	mockWebhookService *mockPort.MockWebhookService
+	webhookController  WebhookController
+}
+
+func TestWebhookControllerSuite(t *testing.T) {
+	suite.Run(t, new(WebhookControllerSuite))
+}
+
+func (g *WebhookControllerSuite) SetupTest() {
+	g.Assertions = require.New(g.T())
+	g.ctrl = gomock.NewController(g.T())
+	g.mockWebhookService = mockPort.NewMockWebhookService(g.ctrl)
+	g.webhookController = NewWebhookController(g.mockWebhookService)
+}
+
+func (g *WebhookControllerSuite) TearDownTest() {
+	g.ctrl.Finish()
+}
+
+func (g *WebhookControllerSuite) TestCreateWebhookValidationFailed() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonPost(c, WebhookRequest{URL: "mailto:hr@example.com", EventTypes: []string{"guest.arrived", "guest.sneezed"}})
+
+	g.webhookController.Create(c)
+
+	g.EqualValues(http.StatusUnprocessableEntity, w.Code)
+
+	wantJson := `{"code":422,"message":"validation failed","errors":[` +
+		`{"field":"url","rule":"http_url","message":"url must be an absolute http or https URL"},` +
+		`{"field":"event_types[1]","rule":"event_type","message":"event_types[1] must be one of ` + strings.Join(domain.EventTypes, ", ") + `"}]}`
+	g.Equal(wantJson, w.Body.String())
+}
+
+func (g *WebhookControllerSuite) TestCreateWebhook() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonPost(c, WebhookRequest{URL: "https://hr.example.com/hooks", EventTypes: []string{domain.EventGuestArrived}})
+
+	createdAt := time.Date(2023, 1, 20, 19, 30, 0, 0, time.UTC)
+	g.mockWebhookService.EXPECT().Register(c, gomock.Eq(&domain.Webhook{
+		URL:        "https://hr.example.com/hooks",
+		EventTypes: []string{domain.EventGuestArrived},
+	})).Return(&domain.Webhook{
+		ID:         1,
+		URL:        "https://hr.example.com/hooks",
+		EventTypes: []string{domain.EventGuestArrived},
+		Secret:     "0123456789abcdef",
+		CreatedAt:  createdAt,
+	}, nil).Times(1)
+
+	g.webhookController.Create(c)
+
+	g.EqualValues(http.StatusCreated, w.Code)
+
+	got := WebhookCreatedResponse{}
+	g.NoError(json.Unmarshal(w.Body.Bytes(), &got))
+	g.Equal("0123456789abcdef", got.Secret)
+	g.Equal(int64(1), got.ID)
+	g.True(createdAt.Equal(got.CreatedAt))
+}
+
+func (g *WebhookControllerSuite) TestGetWebhookHidesSecret() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonGet(c, gin.Params{{Key: "webhook_id", Value: "1"}}, url.Values{})
+
+	g.mockWebhookService.EXPECT().GetById(c, int64(1)).Return(&domain.Webhook{
+		ID:         1,
+		URL:        "https://hr.example.com/hooks",
+		EventTypes: []string{domain.EventGuestArrived},
+		Secret:     "0123456789abcdef",
+	}, nil).Times(1)
+
+	g.webhookController.GetById(c)
+
+	g.EqualValues(http.StatusOK, w.Code)
+	g.NotContains(w.Body.String(), "0123456789abcdef")
+}
+
+func (g *WebhookControllerSuite) TestGetDeliveries() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonGet(c, gin.Params{{Key: "webhook_id", Value: "1"}}, url.Values{"limit": []string{"2"}})
+
+	g.mockWebhookService.EXPECT().GetById(c, int64(1)).Return(&domain.Webhook{ID: 1}, nil).Times(1)
+	g.mockWebhookService.EXPECT().GetDeliveries(c, port.GetDeliveriesFilter{WebhookID: 1, Limit: 2}).
+		Return([]*domain.WebhookDelivery{{ID: 2, WebhookID: 1, Status: domain.DeliveryDelivered, Payload: []byte(`{}`)}}, nil).Times(1)
+
+	g.webhookController.GetDeliveries(c)
+
+	g.EqualValues(http.StatusOK, w.Code)
+
+	var got []domain.WebhookDelivery
+	g.NoError(json.Unmarshal(w.Body.Bytes(), &got))
+	g.Len(got, 1)
+	g.Equal(domain.DeliveryDelivered, got[0].Status)
+}
+
+func (g *WebhookControllerSuite) TestGetDeliveriesInvalidLimit() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonGet(c, gin.Params{{Key: "webhook_id", Value: "1"}}, url.Values{"limit": []string{"0"}})
+
+	g.webhookController.GetDeliveries(c)
+
+	g.EqualValues(http.StatusUnprocessableEntity, w.Code)
+	g.Contains(w.Body.String(), fmt.Sprintf("limit must be an integer between 1 and %d", maxDeliveriesLimit))
+}
+
+func (g *WebhookControllerSuite) TestGetDeliveriesUnknownWebhook() {
+	w := httptest.NewRecorder()
+	c := testutil.GetTestGinContext(w)
+
+	testutil.MockJsonGet(c, gin.Params{{Key: "webhook_id", Value: "9"}}, url.Values{})
+
+	g.mockWebhookService.EXPECT().GetById(c, int64(9)).Return(nil, fmt.Errorf("record not found by id: 9")).Times(1)
+
+	g.webhookController.GetDeliveries(c)
+
+	g.EqualValues(http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,50 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/eazygood/getground-app/internal/errors"
+	"github.com/eazygood/getground-app/internal/infrastructure/log"
+	gql "github.com/graph-gophers/graphql-go"
+)
+
+// resolverError carries the HTTP status the REST API would answer with, and the rejected fields,
+// in the extensions of the GraphQL error
+type resolverError struct {
+	err errors.ApiError
+}
+
+func (e *resolverError) Error() string {
+	return e.err.Message
+}
+
+func (e *resolverError) Extensions() map[string]interface{} {
+	extensions := map[string]interface{}{"code": e.err.Code}
+	if len(e.err.Errors) > 0 {
+		extensions["errors"] = e.err.Errors
+	}
+
+	return extensions
+}
+
+func fail(ctx context.Context, err errors.ApiError) error {
+	log.FromContext(ctx).WithField("code", err.Code).Error(err.Message)
+
+	return &resolverError{err: err}
+}
+
+func invalidField(ctx context.Context, field string, rule string, message string) error {
+	return fail(ctx, errors.NewApiError(errors.InvalidInput,
+		errors.NewValidationError(errors.FieldError{Field: field, Rule: rule, Message: message})))
+}
+
+func parseID(ctx context.Context, id gql.ID) (int64, error) {
+	value, err := strconv.ParseInt(string(id), 10, 64)
+	if err != nil || value < 1 {
+		return 0, invalidField(ctx, "id", "type", fmt.Sprintf("id must be a positive integer, got %q", id))
+	}
+
+	return value, nil
+}
@@ -0,0 +1,69 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	"github.com/graph-gophers/dataloader/v7"
+)
+
+// loaders batch the lookups made while resolving the fields of a list: the guests of N tables,
+// or the tables of N guests, are fetched with one service call instead of N.
+// They cache their results, so they are built for every request.
+type loaders struct {
+	guestByID      *dataloader.Loader[int64, *domain.Guest]
+	tableByGuestID *dataloader.Loader[int64, *domain.Table]
+}
+
+type loadersKey struct{}
+
+func newLoaders(guestService port.GuestService, tableService port.TableService) *loaders {
+	return &loaders{
+		guestByID: dataloader.NewBatchedLoader(func(ctx context.Context, ids []int64) []*dataloader.Result[*domain.Guest] {
+			guests, err := guestService.GetList(ctx, port.GetGuestFilter{IDs: ids})
+
+			byID := make(map[int64]*domain.Guest, len(guests))
+			for _, guest := range guests {
+				byID[guest.ID] = guest
+			}
+
+			return results(ids, byID, err)
+		}),
+		tableByGuestID: dataloader.NewBatchedLoader(func(ctx context.Context, guestIDs []int64) []*dataloader.Result[*domain.Table] {
+			tables, err := tableService.GetList(ctx, port.GetTableFilter{GuestIDs: guestIDs})
+
+			byGuestID := make(map[int64]*domain.Table, len(tables))
+			for _, table := range tables {
+				if table.GuestID != nil {
+					byGuestID[*table.GuestID] = table
+				}
+			}
+
+			return results(guestIDs, byGuestID, err)
+		}),
+	}
+}
+
+// results lines the batch up with its keys, keys without a record resolve to nil
+func results[V any](keys []int64, byKey map[int64]V, err error) []*dataloader.Result[V] {
+	results := make([]*dataloader.Result[V], len(keys))
+	for i, key := range keys {
+		if err != nil {
+			results[i] = &dataloader.Result[V]{Error: err}
+			continue
+		}
+
+		results[i] = &dataloader.Result[V]{Data: byKey[key]}
+	}
+
+	return results
+}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
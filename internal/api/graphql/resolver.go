@@ -0,0 +1,402 @@
+package graphql
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/eazygood/getground-app/internal/api/controller"
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	"github.com/eazygood/getground-app/internal/errors"
+	v "github.com/eazygood/getground-app/internal/validator"
+	gql "github.com/graph-gophers/graphql-go"
+)
+
+// maxPageSize caps the first argument of the list queries
+const maxPageSize = 100
+
+const (
+	guestCursor = "guest"
+	tableCursor = "table"
+)
+
+type resolver struct {
+	guestService port.GuestService
+	tableService port.TableService
+}
+
+type guestFilterInput struct {
+	Arrived        *bool
+	IncludeDeleted *bool
+}
+
+type tableFilterInput struct {
+	Occupied *bool
+	MinSeats *int32
+}
+
+type guestInput struct {
+	Name               string
+	AccompanyingGuests int32
+}
+
+type tableInput struct {
+	Seats int32
+}
+
+type guestsArgs struct {
+	Filter *guestFilterInput
+	First  int32
+	After  *string
+}
+
+type tablesArgs struct {
+	Filter *tableFilterInput
+	First  int32
+	After  *string
+}
+
+func (r *resolver) Event(ctx context.Context) (*eventResolver, error) {
+	guests, err := r.guestService.GetList(ctx, port.GetGuestFilter{})
+	if err != nil {
+		return nil, fail(ctx, errors.NewApiError(errors.Internal, err))
+	}
+
+	tables, err := r.tableService.GetList(ctx, port.GetTableFilter{})
+	if err != nil {
+		return nil, fail(ctx, errors.NewApiError(errors.Internal, err))
+	}
+
+	return newEventResolver(guests, tables), nil
+}
+
+func (r *resolver) Guest(ctx context.Context, args struct{ ID gql.ID }) (*guestResolver, error) {
+	id, err := parseID(ctx, args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	guest, err := r.guestService.GetById(ctx, id)
+	if err != nil {
+		return nil, fail(ctx, errors.NewApiError(errors.NotFound, err))
+	}
+
+	return &guestResolver{guest: guest}, nil
+}
+
+func (r *resolver) Guests(ctx context.Context, args guestsArgs) (*guestConnectionResolver, error) {
+	limit, afterID, err := page(ctx, args.First, args.After, guestCursor)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := port.GetGuestFilter{AfterID: afterID, Limit: limit + 1}
+	if args.Filter != nil {
+		filter.IsArrived = args.Filter.Arrived
+		filter.IncludeDeleted = args.Filter.IncludeDeleted != nil && *args.Filter.IncludeDeleted
+	}
+
+	guests, err := r.guestService.GetList(ctx, filter)
+	if err != nil {
+		return nil, fail(ctx, errors.NewApiError(errors.Internal, err))
+	}
+
+	connection := &guestConnectionResolver{pageInfo: &pageInfoResolver{hasNextPage: len(guests) > limit}}
+	for i, guest := range guests {
+		if i == limit {
+			break
+		}
+
+		// the guests of the page may be asked for again through their tables
+		loadersFrom(ctx).guestByID.Prime(ctx, guest.ID, guest)
+		connection.edges = append(connection.edges, &guestEdgeResolver{node: &guestResolver{guest: guest}})
+	}
+
+	if len(connection.edges) > 0 {
+		cursor := connection.edges[len(connection.edges)-1].Cursor()
+		connection.pageInfo.endCursor = &cursor
+	}
+
+	return connection, nil
+}
+
+func (r *resolver) Table(ctx context.Context, args struct{ ID gql.ID }) (*tableResolver, error) {
+	id, err := parseID(ctx, args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	table, err := r.tableService.GetById(ctx, id)
+	if err != nil {
+		return nil, fail(ctx, errors.NewApiError(errors.NotFound, err))
+	}
+
+	return &tableResolver{table: table}, nil
+}
+
+func (r *resolver) Tables(ctx context.Context, args tablesArgs) (*tableConnectionResolver, error) {
+	limit, afterID, err := page(ctx, args.First, args.After, tableCursor)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := port.GetTableFilter{AfterID: afterID, Limit: limit + 1}
+	if args.Filter != nil {
+		filter.Occupied = args.Filter.Occupied
+		if args.Filter.MinSeats != nil {
+			if filter.MinSeats, err = toUint16(ctx, "minSeats", *args.Filter.MinSeats); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	tables, err := r.tableService.GetList(ctx, filter)
+	if err != nil {
+		return nil, fail(ctx, errors.NewApiError(errors.Internal, err))
+	}
+
+	connection := &tableConnectionResolver{pageInfo: &pageInfoResolver{hasNextPage: len(tables) > limit}}
+	for i, table := range tables {
+		if i == limit {
+			break
+		}
+
+		connection.edges = append(connection.edges, &tableEdgeResolver{node: &tableResolver{table: table}})
+	}
+
+	if len(connection.edges) > 0 {
+		cursor := connection.edges[len(connection.edges)-1].Cursor()
+		connection.pageInfo.endCursor = &cursor
+	}
+
+	return connection, nil
+}
+
+func (r *resolver) CreateGuest(ctx context.Context, args struct{ Input guestInput }) (*guestResolver, error) {
+	body, err := guestRequest(ctx, args.Input)
+	if err != nil {
+		return nil, err
+	}
+
+	guest, err := r.guestService.Create(ctx, &domain.Guest{
+		Name:               body.Name,
+		AccompanyingGuests: body.AccompanyingGuests,
+	})
+	if err != nil {
+		return nil, fail(ctx, errors.NewApiError(errors.Internal, err))
+	}
+
+	return &guestResolver{guest: guest}, nil
+}
+
+func (r *resolver) UpdateGuest(ctx context.Context, args struct {
+	ID      gql.ID
+	Version int32
+	Input   guestInput
+}) (*guestResolver, error) {
+	id, err := parseID(ctx, args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := guestRequest(ctx, args.Input)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.guestService.Patch(ctx, id, port.GuestPatch{
+		Guest: domain.Guest{
+			Name:               body.Name,
+			AccompanyingGuests: body.AccompanyingGuests,
+			Version:            int64(args.Version),
+		},
+		Fields: []string{"accompanying_guests", "name"},
+	})
+	if err != nil {
+		return nil, fail(ctx, errors.NewApiError(errors.Internal, err))
+	}
+
+	return r.Guest(ctx, struct{ ID gql.ID }{ID: args.ID})
+}
+
+func (r *resolver) DeleteGuest(ctx context.Context, args struct {
+	ID      gql.ID
+	Version int32
+}) (bool, error) {
+	id, err := parseID(ctx, args.ID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.guestService.Delete(ctx, id, int64(args.Version)); err != nil {
+		return false, fail(ctx, errors.NewApiError(errors.Internal, err))
+	}
+
+	return true, nil
+}
+
+func (r *resolver) RestoreGuest(ctx context.Context, args struct{ ID gql.ID }) (*guestResolver, error) {
+	id, err := parseID(ctx, args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.guestService.Restore(ctx, id); err != nil {
+		return nil, fail(ctx, errors.NewApiError(errors.NotFound, err))
+	}
+
+	return r.Guest(ctx, struct{ ID gql.ID }{ID: args.ID})
+}
+
+func (r *resolver) CreateTable(ctx context.Context, args struct{ Input tableInput }) (*tableResolver, error) {
+	body, err := tableRequest(ctx, args.Input)
+	if err != nil {
+		return nil, err
+	}
+
+	table, err := r.tableService.Create(ctx, &domain.Table{Seats: body.Seats})
+	if err != nil {
+		return nil, fail(ctx, errors.NewApiError(errors.Internal, err))
+	}
+
+	return &tableResolver{table: table}, nil
+}
+
+func (r *resolver) UpdateTable(ctx context.Context, args struct {
+	ID      gql.ID
+	Version int32
+	Input   tableInput
+}) (*tableResolver, error) {
+	id, err := parseID(ctx, args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := tableRequest(ctx, args.Input)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.tableService.Patch(ctx, id, port.TablePatch{
+		Table:  domain.Table{Seats: body.Seats, Version: int64(args.Version)},
+		Fields: []string{"seats"},
+	})
+	if err != nil {
+		return nil, fail(ctx, errors.NewApiError(errors.Internal, err))
+	}
+
+	return r.Table(ctx, struct{ ID gql.ID }{ID: args.ID})
+}
+
+func (r *resolver) DeleteTable(ctx context.Context, args struct {
+	ID      gql.ID
+	Version int32
+}) (bool, error) {
+	id, err := parseID(ctx, args.ID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.tableService.Delete(ctx, id, int64(args.Version)); err != nil {
+		return false, fail(ctx, errors.NewApiError(errors.Internal, err))
+	}
+
+	return true, nil
+}
+
+func (r *resolver) RestoreTable(ctx context.Context, args struct{ ID gql.ID }) (*tableResolver, error) {
+	id, err := parseID(ctx, args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.tableService.Restore(ctx, id); err != nil {
+		return nil, fail(ctx, errors.NewApiError(errors.NotFound, err))
+	}
+
+	return r.Table(ctx, struct{ ID gql.ID }{ID: args.ID})
+}
+
+// guestRequest checks the input against the same rules as over HTTP
+func guestRequest(ctx context.Context, input guestInput) (*controller.GuestRequest, error) {
+	accompanyingGuests, err := toUint16(ctx, "accompanyingGuests", input.AccompanyingGuests)
+	if err != nil {
+		return nil, err
+	}
+
+	body := &controller.GuestRequest{Name: input.Name, AccompanyingGuests: accompanyingGuests}
+	if err := v.GetValidator().Struct(body); err != nil {
+		return nil, fail(ctx, errors.NewApiError(errors.InvalidInput, err))
+	}
+
+	return body, nil
+}
+
+func tableRequest(ctx context.Context, input tableInput) (*controller.TableCreateRequest, error) {
+	seats, err := toUint16(ctx, "seats", input.Seats)
+	if err != nil {
+		return nil, err
+	}
+
+	body := &controller.TableCreateRequest{Seats: seats}
+	if err := v.GetValidator().Struct(body); err != nil {
+		return nil, fail(ctx, errors.NewApiError(errors.InvalidInput, err))
+	}
+
+	return body, nil
+}
+
+func toUint16(ctx context.Context, field string, value int32) (uint16, error) {
+	if value < 0 || value > math.MaxUint16 {
+		return 0, invalidField(ctx, field, "type", fmt.Sprintf("%s must be an integer between 0 and %d", field, math.MaxUint16))
+	}
+
+	return uint16(value), nil
+}
+
+// page reads the size of the page and the id the page starts after from the list arguments
+func page(ctx context.Context, first int32, after *string, kind string) (int, int64, error) {
+	limit := int(first)
+	if limit < 1 || limit > maxPageSize {
+		return 0, 0, invalidField(ctx, "first", "max", fmt.Sprintf("first must be between 1 and %d", maxPageSize))
+	}
+
+	if after == nil {
+		return limit, 0, nil
+	}
+
+	afterID, ok := decodeCursor(kind, *after)
+	if !ok {
+		return 0, 0, invalidField(ctx, "after", "cursor", fmt.Sprintf("after is not a %s cursor", kind))
+	}
+
+	return limit, afterID, nil
+}
+
+// cursors are opaque to clients, they hold the kind and id of the last record of a page
+func encodeCursor(kind string, id int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(kind + ":" + strconv.FormatInt(id, 10)))
+}
+
+func decodeCursor(kind string, cursor string) (int64, bool) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, false
+	}
+
+	prefix := kind + ":"
+	if !strings.HasPrefix(string(raw), prefix) {
+		return 0, false
+	}
+
+	id, err := strconv.ParseInt(strings.TrimPrefix(string(raw), prefix), 10, 64)
+	if err != nil || id < 1 {
+		return 0, false
+	}
+
+	return id, true
+}
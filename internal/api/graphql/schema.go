@@ -0,0 +1,29 @@
+package graphql
+
+import (
+	_ "embed"
+	"net/http"
+
+	"github.com/eazygood/getground-app/internal/core/port"
+	gql "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+)
+
+//go:embed schema.graphql
+var schema string
+
+// maxDepth keeps a query from nesting guests and tables without end
+const maxDepth = 8
+
+// NewHandler serves GraphQL queries and mutations over POST, resolved by the same port services as the REST API
+func NewHandler(guestService port.GuestService, tableService port.TableService) http.Handler {
+	s := gql.MustParseSchema(schema, &resolver{guestService: guestService, tableService: tableService},
+		gql.MaxDepth(maxDepth))
+
+	next := &relay.Handler{Schema: s}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := withLoaders(r.Context(), newLoaders(guestService, tableService))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
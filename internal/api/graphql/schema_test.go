@@ -0,0 +1,214 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	mockPort "github.com/eazygood/getground-app/mocks/core/port"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type SchemaSuite struct {
+	suite.Suite
+	*require.Assertions
+	ctrl             *gomock.Controller
+	mockGuestService *mockPort.MockGuestService
+	mockTableService *mockPort.MockTableService
+	handler          http.Handler
+}
+
+type response struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+}
+
+func TestSchemaSuite(t *testing.T) {
+	suite.Run(t, new(SchemaSuite))
+}
+
+func (s *SchemaSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	s.ctrl = gomock.NewController(s.T())
+	s.mockGuestService = mockPort.NewMockGuestService(s.ctrl)
+	s.mockTableService = mockPort.NewMockTableService(s.ctrl)
+	s.handler = NewHandler(s.mockGuestService, s.mockTableService)
+}
+
+func (s *SchemaSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *SchemaSuite) exec(query string, variables map[string]interface{}) response {
+	body, err := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	s.NoError(err)
+
+	w := httptest.NewRecorder()
+	s.handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body)))
+	s.Equal(http.StatusOK, w.Code)
+
+	var resp response
+	s.NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+
+	return resp
+}
+
+func (s *SchemaSuite) TestGuestsWithTablesAreBatched() {
+	first, second, arrived := int64(1), int64(2), true
+	s.mockGuestService.EXPECT().GetList(gomock.Any(), port.GetGuestFilter{IsArrived: &arrived, Limit: 51}).
+		Return([]*domain.Guest{{ID: 1, Name: "Ada"}, {ID: 2, Name: "Grace"}, {ID: 3, Name: "Edsger"}}, nil)
+
+	// one lookup for the tables of all the guests of the page
+	s.mockTableService.EXPECT().GetList(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, filter port.GetTableFilter) ([]*domain.Table, error) {
+			s.ElementsMatch([]int64{1, 2, 3}, filter.GuestIDs)
+
+			return []*domain.Table{{ID: 10, Seats: 4, GuestID: &first}, {ID: 11, Seats: 2, GuestID: &second}}, nil
+		}).Times(1)
+
+	resp := s.exec(`{ guests(filter: {arrived: true}) { edges { node { name table { id seats } } } pageInfo { hasNextPage } } }`, nil)
+
+	s.Empty(resp.Errors)
+	s.JSONEq(`{"guests":{"edges":[`+
+		`{"node":{"name":"Ada","table":{"id":"10","seats":4}}},`+
+		`{"node":{"name":"Grace","table":{"id":"11","seats":2}}},`+
+		`{"node":{"name":"Edsger","table":null}}],`+
+		`"pageInfo":{"hasNextPage":false}}}`, string(resp.Data))
+}
+
+func (s *SchemaSuite) TestGuestsNotArrived() {
+	arrived := false
+	s.mockGuestService.EXPECT().GetList(gomock.Any(), port.GetGuestFilter{IsArrived: &arrived, Limit: 51}).
+		Return([]*domain.Guest{{ID: 2, Name: "Grace"}}, nil)
+
+	resp := s.exec(`{ guests(filter: {arrived: false}) { edges { node { name } } } }`, nil)
+
+	s.Empty(resp.Errors)
+	s.JSONEq(`{"guests":{"edges":[{"node":{"name":"Grace"}}]}}`, string(resp.Data))
+}
+
+func (s *SchemaSuite) TestTablesWithGuestsAreBatched() {
+	first, second := int64(1), int64(2)
+	s.mockTableService.EXPECT().GetList(gomock.Any(), port.GetTableFilter{Limit: 51}).
+		Return([]*domain.Table{{ID: 10, Seats: 4, GuestID: &first}, {ID: 11, Seats: 2, GuestID: &second}, {ID: 12, Seats: 6}}, nil)
+
+	s.mockGuestService.EXPECT().GetList(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, filter port.GetGuestFilter) ([]*domain.Guest, error) {
+			s.ElementsMatch([]int64{1, 2}, filter.IDs)
+
+			return []*domain.Guest{{ID: 1, Name: "Ada"}, {ID: 2, Name: "Grace"}}, nil
+		}).Times(1)
+
+	resp := s.exec(`{ tables { edges { node { id guest { name } } } } }`, nil)
+
+	s.Empty(resp.Errors)
+	s.JSONEq(`{"tables":{"edges":[`+
+		`{"node":{"id":"10","guest":{"name":"Ada"}}},`+
+		`{"node":{"id":"11","guest":{"name":"Grace"}}},`+
+		`{"node":{"id":"12","guest":null}}]}}`, string(resp.Data))
+}
+
+func (s *SchemaSuite) TestGuestsPagination() {
+	s.mockGuestService.EXPECT().GetList(gomock.Any(), port.GetGuestFilter{Limit: 3}).
+		Return([]*domain.Guest{{ID: 1}, {ID: 2}, {ID: 3}}, nil)
+
+	resp := s.exec(`{ guests(first: 2) { edges { node { id } } pageInfo { endCursor hasNextPage } } }`, nil)
+	s.Empty(resp.Errors)
+
+	var page struct {
+		Guests struct {
+			Edges    []struct{ Node struct{ ID string } }
+			PageInfo struct {
+				EndCursor   string
+				HasNextPage bool
+			}
+		}
+	}
+	s.NoError(json.Unmarshal(resp.Data, &page))
+	s.Len(page.Guests.Edges, 2)
+	s.True(page.Guests.PageInfo.HasNextPage)
+
+	s.mockGuestService.EXPECT().GetList(gomock.Any(), port.GetGuestFilter{AfterID: 2, Limit: 3}).
+		Return([]*domain.Guest{{ID: 3}}, nil)
+
+	resp = s.exec(`query($after: String) { guests(first: 2, after: $after) { edges { node { id } } pageInfo { hasNextPage } } }`,
+		map[string]interface{}{"after": page.Guests.PageInfo.EndCursor})
+
+	s.Empty(resp.Errors)
+	s.JSONEq(`{"guests":{"edges":[{"node":{"id":"3"}}],"pageInfo":{"hasNextPage":false}}}`, string(resp.Data))
+}
+
+func (s *SchemaSuite) TestGuestsInvalidCursor() {
+	resp := s.exec(`{ guests(after: "bm9wZQ") { edges { cursor } } }`, nil)
+
+	s.Len(resp.Errors, 1)
+	s.Equal("validation failed", resp.Errors[0].Message)
+	s.EqualValues(http.StatusUnprocessableEntity, resp.Errors[0].Extensions["code"])
+}
+
+func (s *SchemaSuite) TestEvent() {
+	guestID := int64(1)
+	s.mockGuestService.EXPECT().GetList(gomock.Any(), port.GetGuestFilter{}).
+		Return([]*domain.Guest{{ID: 1, AccompanyingGuests: 2, IsArrived: true}, {ID: 2, AccompanyingGuests: 1}}, nil)
+	s.mockTableService.EXPECT().GetList(gomock.Any(), port.GetTableFilter{}).
+		Return([]*domain.Table{{ID: 10, Seats: 4, GuestID: &guestID}, {ID: 11, Seats: 6}}, nil)
+
+	resp := s.exec(`{ event { tablesTotal tablesOccupied seatsTotal seatsEmpty guestsExpected guestsArrived } }`, nil)
+
+	s.Empty(resp.Errors)
+	s.JSONEq(`{"event":{"tablesTotal":2,"tablesOccupied":1,"seatsTotal":10,"seatsEmpty":6,"guestsExpected":5,"guestsArrived":3}}`,
+		string(resp.Data))
+}
+
+func (s *SchemaSuite) TestCreateGuest() {
+	s.mockGuestService.EXPECT().Create(gomock.Any(), &domain.Guest{Name: "Ada", AccompanyingGuests: 2}).
+		Return(&domain.Guest{ID: 7, Name: "Ada", AccompanyingGuests: 2, Version: 1}, nil)
+
+	resp := s.exec(`mutation { createGuest(input: {name: "Ada", accompanyingGuests: 2}) { id version } }`, nil)
+
+	s.Empty(resp.Errors)
+	s.JSONEq(`{"createGuest":{"id":"7","version":1}}`, string(resp.Data))
+}
+
+func (s *SchemaSuite) TestCreateGuestValidationFailed() {
+	resp := s.exec(`mutation { createGuest(input: {name: " ", accompanyingGuests: 2}) { id } }`, nil)
+
+	s.Len(resp.Errors, 1)
+	s.EqualValues(http.StatusUnprocessableEntity, resp.Errors[0].Extensions["code"])
+	s.Equal([]interface{}{map[string]interface{}{"field": "name", "rule": "notblank", "message": "name must not be blank"}},
+		resp.Errors[0].Extensions["errors"])
+}
+
+func (s *SchemaSuite) TestUpdateTable() {
+	s.mockTableService.EXPECT().Patch(gomock.Any(), int64(10), port.TablePatch{
+		Table:  domain.Table{Seats: 8, Version: 2},
+		Fields: []string{"seats"},
+	}).Return(nil)
+	s.mockTableService.EXPECT().GetById(gomock.Any(), int64(10)).Return(&domain.Table{ID: 10, Seats: 8, Version: 3}, nil)
+
+	resp := s.exec(`mutation { updateTable(id: "10", version: 2, input: {seats: 8}) { seats version } }`, nil)
+
+	s.Empty(resp.Errors)
+	s.JSONEq(`{"updateTable":{"seats":8,"version":3}}`, string(resp.Data))
+}
+
+func (s *SchemaSuite) TestDeleteGuestFailed() {
+	s.mockGuestService.EXPECT().Delete(gomock.Any(), int64(7), int64(1)).Return(errors.New("connection refused"))
+
+	resp := s.exec(`mutation { deleteGuest(id: "7", version: 1) }`, nil)
+
+	s.Len(resp.Errors, 1)
+	s.EqualValues(http.StatusInternalServerError, resp.Errors[0].Extensions["code"])
+}
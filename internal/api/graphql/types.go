@@ -0,0 +1,221 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/errors"
+	"github.com/eazygood/getground-app/internal/venue"
+	gql "github.com/graph-gophers/graphql-go"
+)
+
+type guestResolver struct {
+	guest *domain.Guest
+}
+
+func (r *guestResolver) ID() gql.ID {
+	return gql.ID(strconv.FormatInt(r.guest.ID, 10))
+}
+
+func (r *guestResolver) Name() string {
+	return r.guest.Name
+}
+
+func (r *guestResolver) AccompanyingGuests() int32 {
+	return int32(r.guest.AccompanyingGuests)
+}
+
+func (r *guestResolver) IsArrived() bool {
+	return r.guest.IsArrived
+}
+
+func (r *guestResolver) TimeArrived(ctx context.Context, args struct{ Tz *string }) (*gql.Time, error) {
+	if r.guest.TimeArrived == nil {
+		return nil, nil
+	}
+
+	loc := venue.Location()
+	if args.Tz != nil {
+		var err error
+		if loc, err = time.LoadLocation(*args.Tz); err != nil {
+			return nil, invalidField(ctx, "tz", "timezone", fmt.Sprintf("tz must be an IANA timezone, got %q", *args.Tz))
+		}
+	}
+
+	return &gql.Time{Time: r.guest.TimeArrived.In(loc)}, nil
+}
+
+func (r *guestResolver) Version() int32 {
+	return int32(r.guest.Version)
+}
+
+func (r *guestResolver) Table(ctx context.Context) (*tableResolver, error) {
+	table, err := loadersFrom(ctx).tableByGuestID.Load(ctx, r.guest.ID)()
+	if err != nil {
+		return nil, fail(ctx, errors.NewApiError(errors.Internal, err))
+	}
+
+	if table == nil {
+		return nil, nil
+	}
+
+	return &tableResolver{table: table}, nil
+}
+
+type tableResolver struct {
+	table *domain.Table
+}
+
+func (r *tableResolver) ID() gql.ID {
+	return gql.ID(strconv.FormatInt(r.table.ID, 10))
+}
+
+func (r *tableResolver) Seats() int32 {
+	return int32(r.table.Seats)
+}
+
+func (r *tableResolver) Version() int32 {
+	return int32(r.table.Version)
+}
+
+func (r *tableResolver) Guest(ctx context.Context) (*guestResolver, error) {
+	if r.table.GuestID == nil {
+		return nil, nil
+	}
+
+	guest, err := loadersFrom(ctx).guestByID.Load(ctx, *r.table.GuestID)()
+	if err != nil {
+		return nil, fail(ctx, errors.NewApiError(errors.Internal, err))
+	}
+
+	if guest == nil {
+		return nil, nil
+	}
+
+	return &guestResolver{guest: guest}, nil
+}
+
+type eventResolver struct {
+	tablesTotal    int32
+	tablesOccupied int32
+	seatsTotal     int32
+	seatsEmpty     int32
+	guestsExpected int32
+	guestsArrived  int32
+}
+
+func newEventResolver(guests []*domain.Guest, tables []*domain.Table) *eventResolver {
+	event := &eventResolver{tablesTotal: int32(len(tables))}
+	for _, table := range tables {
+		event.seatsTotal += int32(table.Seats)
+		if table.GuestID != nil {
+			event.tablesOccupied++
+		} else {
+			event.seatsEmpty += int32(table.Seats)
+		}
+	}
+
+	for _, guest := range guests {
+		party := 1 + int32(guest.AccompanyingGuests)
+		event.guestsExpected += party
+		if guest.IsArrived {
+			event.guestsArrived += party
+		}
+	}
+
+	return event
+}
+
+func (r *eventResolver) Timezone() string {
+	return venue.Location().String()
+}
+
+func (r *eventResolver) TablesTotal() int32 {
+	return r.tablesTotal
+}
+
+func (r *eventResolver) TablesOccupied() int32 {
+	return r.tablesOccupied
+}
+
+func (r *eventResolver) SeatsTotal() int32 {
+	return r.seatsTotal
+}
+
+func (r *eventResolver) SeatsEmpty() int32 {
+	return r.seatsEmpty
+}
+
+func (r *eventResolver) GuestsExpected() int32 {
+	return r.guestsExpected
+}
+
+func (r *eventResolver) GuestsArrived() int32 {
+	return r.guestsArrived
+}
+
+type pageInfoResolver struct {
+	endCursor   *string
+	hasNextPage bool
+}
+
+func (r *pageInfoResolver) EndCursor() *string {
+	return r.endCursor
+}
+
+func (r *pageInfoResolver) HasNextPage() bool {
+	return r.hasNextPage
+}
+
+type guestConnectionResolver struct {
+	edges    []*guestEdgeResolver
+	pageInfo *pageInfoResolver
+}
+
+func (r *guestConnectionResolver) Edges() []*guestEdgeResolver {
+	return r.edges
+}
+
+func (r *guestConnectionResolver) PageInfo() *pageInfoResolver {
+	return r.pageInfo
+}
+
+type guestEdgeResolver struct {
+	node *guestResolver
+}
+
+func (r *guestEdgeResolver) Cursor() string {
+	return encodeCursor(guestCursor, r.node.guest.ID)
+}
+
+func (r *guestEdgeResolver) Node() *guestResolver {
+	return r.node
+}
+
+type tableConnectionResolver struct {
+	edges    []*tableEdgeResolver
+	pageInfo *pageInfoResolver
+}
+
+func (r *tableConnectionResolver) Edges() []*tableEdgeResolver {
+	return r.edges
+}
+
+func (r *tableConnectionResolver) PageInfo() *pageInfoResolver {
+	return r.pageInfo
+}
+
+type tableEdgeResolver struct {
+	node *tableResolver
+}
+
+func (r *tableEdgeResolver) Cursor() string {
+	return encodeCursor(tableCursor, r.node.table.ID)
+}
+
+func (r *tableEdgeResolver) Node() *tableResolver {
+	return r.node
+}
@@ -0,0 +1,32 @@
+package rpc
+
+import (
+	pb "github.com/eazygood/getground-app/api/proto/getground/v1"
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func guestToProto(guest *domain.Guest) *pb.Guest {
+	message := &pb.Guest{
+		Id:                 guest.ID,
+		Name:               guest.Name,
+		AccompanyingGuests: uint32(guest.AccompanyingGuests),
+		IsArrived:          guest.IsArrived,
+		Version:            guest.Version,
+	}
+
+	if guest.TimeArrived != nil {
+		message.TimeArrived = timestamppb.New(*guest.TimeArrived)
+	}
+
+	return message
+}
+
+func tableToProto(table *domain.Table) *pb.Table {
+	return &pb.Table{
+		Id:      table.ID,
+		Seats:   uint32(table.Seats),
+		GuestId: table.GuestID,
+		Version: table.Version,
+	}
+}
@@ -0,0 +1,75 @@
+package rpc
+
+import (
+	"context"
+
+	pb "github.com/eazygood/getground-app/api/proto/getground/v1"
+	"github.com/eazygood/getground-app/internal/api/controller"
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	"github.com/eazygood/getground-app/internal/errors"
+	v "github.com/eazygood/getground-app/internal/validator"
+)
+
+type guestServer struct {
+	pb.UnimplementedGuestServiceServer
+	guestService port.GuestService
+}
+
+func NewGuestServer(guestService port.GuestService) pb.GuestServiceServer {
+	return &guestServer{guestService: guestService}
+}
+
+func (s *guestServer) CreateGuest(ctx context.Context, req *pb.CreateGuestRequest) (*pb.Guest, error) {
+	accompanyingGuests, fieldErr := toUint16("accompanying_guests", req.GetAccompanyingGuests())
+	if fieldErr != nil {
+		return nil, statusError(ctx, errors.NewApiError(errors.InvalidInput, errors.NewValidationError(*fieldErr)))
+	}
+
+	// the same rules as over HTTP
+	body := controller.GuestRequest{Name: req.GetName(), AccompanyingGuests: accompanyingGuests}
+	if err := v.GetValidator().Struct(body); err != nil {
+		return nil, statusError(ctx, errors.NewApiError(errors.InvalidInput, err))
+	}
+
+	guest, err := s.guestService.Create(ctx, &domain.Guest{
+		Name:               body.Name,
+		AccompanyingGuests: body.AccompanyingGuests,
+	})
+	if err != nil {
+		return nil, statusError(ctx, errors.NewApiError(errors.Internal, err))
+	}
+
+	return guestToProto(guest), nil
+}
+
+func (s *guestServer) GetGuest(ctx context.Context, req *pb.GetGuestRequest) (*pb.Guest, error) {
+	guest, err := s.guestService.GetById(ctx, req.GetId())
+	if err != nil {
+		return nil, statusError(ctx, errors.NewApiError(errors.NotFound, err))
+	}
+
+	return guestToProto(guest), nil
+}
+
+func (s *guestServer) ListGuests(ctx context.Context, req *pb.ListGuestsRequest) (*pb.ListGuestsResponse, error) {
+	filter := port.GetGuestFilter{IncludeDeleted: req.GetIncludeDeleted()}
+
+	// proto3 cannot tell false from unset, so arrived only ever narrows the list
+	if req.GetArrived() {
+		arrived := true
+		filter.IsArrived = &arrived
+	}
+
+	guests, err := s.guestService.GetList(ctx, filter)
+	if err != nil {
+		return nil, statusError(ctx, errors.NewApiError(errors.Internal, err))
+	}
+
+	resp := &pb.ListGuestsResponse{Guests: make([]*pb.Guest, 0, len(guests))}
+	for _, guest := range guests {
+		resp.Guests = append(resp.Guests, guestToProto(guest))
+	}
+
+	return resp, nil
+}
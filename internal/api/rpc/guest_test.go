@@ -0,0 +1,75 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pb "github.com/eazygood/getground-app/api/proto/getground/v1"
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	"github.com/golang/mock/gomock"
+	"google.golang.org/grpc/codes"
+)
+
+func (s *ServerSuite) TestCreateGuest() {
+	s.mockGuestService.EXPECT().
+		Create(gomock.Any(), &domain.Guest{Name: "Ada", AccompanyingGuests: 2}).
+		Return(&domain.Guest{ID: 7, Name: "Ada", AccompanyingGuests: 2, Version: 1}, nil)
+
+	guest, err := s.guestClient.CreateGuest(context.Background(), &pb.CreateGuestRequest{Name: "Ada", AccompanyingGuests: 2})
+	s.NoError(err)
+
+	s.Equal(int64(7), guest.GetId())
+	s.Equal("Ada", guest.GetName())
+	s.Equal(uint32(2), guest.GetAccompanyingGuests())
+	s.Equal(int64(1), guest.GetVersion())
+	s.Nil(guest.GetTimeArrived())
+}
+
+func (s *ServerSuite) TestCreateGuestValidationFailed() {
+	_, err := s.guestClient.CreateGuest(context.Background(), &pb.CreateGuestRequest{Name: "  ", AccompanyingGuests: 70000})
+
+	violations := s.requireStatus(err, codes.InvalidArgument)
+	s.Len(violations, 1)
+	s.Equal("accompanying_guests", violations[0].GetField())
+	s.Equal("accompanying_guests must be an integer between 0 and 65535", violations[0].GetDescription())
+
+	_, err = s.guestClient.CreateGuest(context.Background(), &pb.CreateGuestRequest{Name: "  "})
+
+	violations = s.requireStatus(err, codes.InvalidArgument)
+	s.Len(violations, 1)
+	s.Equal("name", violations[0].GetField())
+}
+
+func (s *ServerSuite) TestGetGuest() {
+	arrived := time.Date(2023, 1, 2, 20, 30, 0, 0, time.UTC)
+	s.mockGuestService.EXPECT().GetById(gomock.Any(), int64(7)).
+		Return(&domain.Guest{ID: 7, Name: "Ada", IsArrived: true, TimeArrived: &arrived, Version: 3}, nil)
+
+	guest, err := s.guestClient.GetGuest(context.Background(), &pb.GetGuestRequest{Id: 7})
+	s.NoError(err)
+
+	s.True(guest.GetIsArrived())
+	s.Equal(arrived, guest.GetTimeArrived().AsTime())
+}
+
+func (s *ServerSuite) TestGetGuestNotFound() {
+	s.mockGuestService.EXPECT().GetById(gomock.Any(), int64(7)).Return(nil, errors.New("record not found by id: 7"))
+
+	_, err := s.guestClient.GetGuest(context.Background(), &pb.GetGuestRequest{Id: 7})
+
+	s.requireStatus(err, codes.NotFound)
+}
+
+func (s *ServerSuite) TestListGuests() {
+	arrived := true
+	s.mockGuestService.EXPECT().GetList(gomock.Any(), port.GetGuestFilter{IsArrived: &arrived}).
+		Return([]*domain.Guest{{ID: 1, Name: "Ada"}, {ID: 2, Name: "Grace"}}, nil)
+
+	resp, err := s.guestClient.ListGuests(context.Background(), &pb.ListGuestsRequest{Arrived: true})
+	s.NoError(err)
+
+	s.Len(resp.GetGuests(), 2)
+	s.Equal("Grace", resp.GetGuests()[1].GetName())
+}
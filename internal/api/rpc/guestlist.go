@@ -0,0 +1,159 @@
+package rpc
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"time"
+
+	pb "github.com/eazygood/getground-app/api/proto/getground/v1"
+	"github.com/eazygood/getground-app/internal/api/controller"
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	"github.com/eazygood/getground-app/internal/errors"
+	v "github.com/eazygood/getground-app/internal/validator"
+	"github.com/eazygood/getground-app/internal/venue"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// defaultOccupancyInterval is used when the config leaves the interval of the occupancy stream out
+const defaultOccupancyInterval = 2 * time.Second
+
+type guestListServer struct {
+	pb.UnimplementedGuestListServiceServer
+	guestService      port.GuestService
+	tableService      port.TableService
+	guestListService  port.GuestListService
+	checkinService    port.CheckinService
+	occupancyInterval time.Duration
+}
+
+func NewGuestListServer(guest port.GuestService, table port.TableService, guestList port.GuestListService, checkin port.CheckinService, occupancyInterval time.Duration) pb.GuestListServiceServer {
+	if occupancyInterval <= 0 {
+		occupancyInterval = defaultOccupancyInterval
+	}
+
+	return &guestListServer{
+		guestService:      guest,
+		tableService:      table,
+		guestListService:  guestList,
+		checkinService:    checkin,
+		occupancyInterval: occupancyInterval,
+	}
+}
+
+func (s *guestListServer) CheckIn(ctx context.Context, req *pb.CheckInRequest) (*pb.CheckInResponse, error) {
+	accompanyingGuests, fieldErr := toUint16("accompanying_guests", req.GetAccompanyingGuests())
+	if fieldErr != nil {
+		return nil, statusError(ctx, errors.NewApiError(errors.InvalidInput, errors.NewValidationError(*fieldErr)))
+	}
+
+	body := controller.GuestListRequest{GuestID: int(req.GetGuestId()), AccompanyingGuests: int(accompanyingGuests)}
+	if err := v.GetValidator().Struct(body); err != nil {
+		return nil, statusError(ctx, errors.NewApiError(errors.InvalidInput, err))
+	}
+
+	table, err := s.checkinService.CheckIn(ctx, req.GetGuestId(), accompanyingGuests)
+
+	var checkedIn *domain.CheckedInError
+	if stderrors.As(err, &checkedIn) {
+		return nil, statusError(ctx, errors.NewApiError(errors.InvalidInput, err))
+	}
+
+	var overbooked *domain.OverbookedError
+	if stderrors.As(err, &overbooked) {
+		return nil, statusError(ctx, errors.NewApiError(errors.Conflict, err))
+	}
+
+	if stderrors.Is(err, domain.ErrNotFound) {
+		return nil, statusError(ctx, errors.NewApiError(errors.NotFound, err))
+	}
+
+	if err != nil {
+		return nil, statusError(ctx, errors.NewApiError(errors.Internal, err))
+	}
+
+	return &pb.CheckInResponse{Table: tableToProto(table)}, nil
+}
+
+func (s *guestListServer) Leave(ctx context.Context, req *pb.LeaveRequest) (*pb.LeaveResponse, error) {
+	if req.GetVersion() <= 0 {
+		return nil, statusError(ctx, errors.NewApiError(errors.PreconditionRequired,
+			fmt.Errorf("version of the guest is required")))
+	}
+
+	// answered like DELETE /guests/:guest_id, a guest the state machine keeps from leaving is a conflict
+	err := s.guestService.Delete(ctx, req.GetGuestId(), req.GetVersion())
+
+	var transition *domain.TransitionError
+	if stderrors.As(err, &transition) {
+		return nil, statusError(ctx, errors.NewApiError(errors.Conflict, err))
+	}
+
+	if err != nil {
+		return nil, statusError(ctx, errors.NewApiError(errors.Internal, err))
+	}
+
+	return &pb.LeaveResponse{}, nil
+}
+
+func (s *guestListServer) ListOccupiedTables(ctx context.Context, _ *pb.ListOccupiedTablesRequest) (*pb.ListOccupiedTablesResponse, error) {
+	tables, err := s.guestListService.GetOccupiedSeats(ctx)
+	if err != nil {
+		return nil, statusError(ctx, errors.NewApiError(errors.Internal, err))
+	}
+
+	resp := &pb.ListOccupiedTablesResponse{Tables: make([]*pb.Table, 0, len(tables))}
+	for _, table := range tables {
+		resp.Tables = append(resp.Tables, tableToProto(table))
+	}
+
+	return resp, nil
+}
+
+// WatchOccupancy sends the current occupancy right away, then checks the venue every
+// occupancy interval and sends a new snapshot whenever it changed, until the client goes away
+func (s *guestListServer) WatchOccupancy(_ *pb.WatchOccupancyRequest, stream pb.GuestListService_WatchOccupancyServer) error {
+	ctx := stream.Context()
+	ticker := time.NewTicker(s.occupancyInterval)
+	defer ticker.Stop()
+
+	var last *pb.Occupancy
+	for {
+		current, err := s.occupancy(ctx)
+		if err != nil {
+			return statusError(ctx, errors.NewApiError(errors.Internal, err))
+		}
+
+		if last == nil || current.SeatsEmpty != last.SeatsEmpty || current.TablesOccupied != last.TablesOccupied {
+			if err := stream.Send(current); err != nil {
+				return err
+			}
+			last = current
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *guestListServer) occupancy(ctx context.Context) (*pb.Occupancy, error) {
+	emptySeats, err := s.tableService.GetEmptySeats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	occupied, err := s.guestListService.GetOccupiedSeats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.Occupancy{
+		SeatsEmpty:     emptySeats,
+		TablesOccupied: int64(len(occupied)),
+		ObservedAt:     timestamppb.New(venue.Now()),
+	}, nil
+}
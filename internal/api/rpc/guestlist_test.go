@@ -0,0 +1,160 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	pb "github.com/eazygood/getground-app/api/proto/getground/v1"
+	"github.com/eazygood/getground-app/internal/core/domain"
+	apperrors "github.com/eazygood/getground-app/internal/errors"
+	"github.com/golang/mock/gomock"
+	"google.golang.org/grpc/codes"
+)
+
+func (s *ServerSuite) TestCheckIn() {
+	guestID := int64(7)
+	s.mockCheckinService.EXPECT().CheckIn(gomock.Any(), guestID, uint16(2)).
+		Return(&domain.Table{ID: 3, Seats: 4, GuestID: &guestID, Version: 2}, nil)
+
+	resp, err := s.guestListClient.CheckIn(context.Background(), &pb.CheckInRequest{GuestId: guestID, AccompanyingGuests: 2})
+	s.NoError(err)
+
+	s.Equal(int64(3), resp.GetTable().GetId())
+	s.Equal(guestID, resp.GetTable().GetGuestId())
+	s.Equal(int64(2), resp.GetTable().GetVersion())
+}
+
+func (s *ServerSuite) TestCheckInNoTable() {
+	s.mockCheckinService.EXPECT().CheckIn(gomock.Any(), int64(7), uint16(9)).
+		Return(nil, fmt.Errorf("check in guest: %w", domain.ErrNoAvailableSeats))
+
+	_, err := s.guestListClient.CheckIn(context.Background(), &pb.CheckInRequest{GuestId: 7, AccompanyingGuests: 9})
+
+	s.requireStatus(err, codes.NotFound)
+}
+
+func (s *ServerSuite) TestCheckInFails() {
+	s.mockCheckinService.EXPECT().CheckIn(gomock.Any(), int64(7), uint16(2)).
+		Return(nil, errors.New("connection refused"))
+
+	_, err := s.guestListClient.CheckIn(context.Background(), &pb.CheckInRequest{GuestId: 7, AccompanyingGuests: 2})
+
+	s.requireStatus(err, codes.Internal)
+}
+
+func (s *ServerSuite) TestCheckInAlreadyArrived() {
+	s.mockCheckinService.EXPECT().CheckIn(gomock.Any(), int64(7), uint16(0)).
+		Return(nil, fmt.Errorf("check in guest: %w", &domain.CheckedInError{GuestID: 7}))
+
+	_, err := s.guestListClient.CheckIn(context.Background(), &pb.CheckInRequest{GuestId: 7})
+
+	s.requireStatus(err, codes.InvalidArgument)
+}
+
+func (s *ServerSuite) TestCheckInOverbooked() {
+	s.mockCheckinService.EXPECT().CheckIn(gomock.Any(), int64(7), uint16(3)).
+		Return(nil, fmt.Errorf("check in guest: %w", &domain.OverbookedError{Expected: 12, Capacity: 10}))
+
+	_, err := s.guestListClient.CheckIn(context.Background(), &pb.CheckInRequest{GuestId: 7, AccompanyingGuests: 3})
+
+	s.requireStatus(err, codes.FailedPrecondition)
+}
+
+func (s *ServerSuite) TestCheckInValidationFailed() {
+	_, err := s.guestListClient.CheckIn(context.Background(), &pb.CheckInRequest{})
+
+	violations := s.requireStatus(err, codes.InvalidArgument)
+	s.Len(violations, 1)
+	s.Equal("guest_id", violations[0].GetField())
+}
+
+func (s *ServerSuite) TestLeave() {
+	s.mockGuestService.EXPECT().Delete(gomock.Any(), int64(7), int64(4)).Return(nil)
+
+	_, err := s.guestListClient.Leave(context.Background(), &pb.LeaveRequest{GuestId: 7, Version: 4})
+	s.NoError(err)
+}
+
+func (s *ServerSuite) TestLeaveVersionRequired() {
+	_, err := s.guestListClient.Leave(context.Background(), &pb.LeaveRequest{GuestId: 7})
+
+	s.requireStatus(err, codes.FailedPrecondition)
+}
+
+func (s *ServerSuite) TestLeaveConflict() {
+	s.mockGuestService.EXPECT().Delete(gomock.Any(), int64(7), int64(4)).
+		Return(apperrors.NewConflictError("guest", 7, 4))
+
+	_, err := s.guestListClient.Leave(context.Background(), &pb.LeaveRequest{GuestId: 7, Version: 4})
+
+	s.requireStatus(err, codes.Aborted)
+}
+
+func (s *ServerSuite) TestLeaveForbiddenTransition() {
+	s.mockGuestService.EXPECT().Delete(gomock.Any(), int64(7), int64(4)).
+		Return(fmt.Errorf("delete guest: %w", &domain.TransitionError{GuestID: 7, From: domain.GuestStatusDeclined, To: domain.GuestStatusLeft}))
+
+	_, err := s.guestListClient.Leave(context.Background(), &pb.LeaveRequest{GuestId: 7, Version: 4})
+
+	s.requireStatus(err, codes.FailedPrecondition)
+}
+
+func (s *ServerSuite) TestListOccupiedTables() {
+	guestID := int64(7)
+	s.mockGuestListService.EXPECT().GetOccupiedSeats(gomock.Any()).
+		Return([]*domain.Table{{ID: 3, Seats: 4, GuestID: &guestID}}, nil)
+
+	resp, err := s.guestListClient.ListOccupiedTables(context.Background(), &pb.ListOccupiedTablesRequest{})
+	s.NoError(err)
+
+	s.Len(resp.GetTables(), 1)
+	s.Equal(guestID, resp.GetTables()[0].GetGuestId())
+}
+
+func (s *ServerSuite) TestWatchOccupancy() {
+	guestID := int64(7)
+	occupied := []*domain.Table{{ID: 3, Seats: 4, GuestID: &guestID}}
+
+	// a snapshot is only sent when the occupancy changed since the previous one
+	gomock.InOrder(
+		s.mockTableService.EXPECT().GetEmptySeats(gomock.Any()).Return(int64(10), nil),
+		s.mockTableService.EXPECT().GetEmptySeats(gomock.Any()).Return(int64(10), nil),
+		s.mockTableService.EXPECT().GetEmptySeats(gomock.Any()).Return(int64(6), nil),
+		s.mockTableService.EXPECT().GetEmptySeats(gomock.Any()).Return(int64(6), nil).AnyTimes(),
+	)
+	gomock.InOrder(
+		s.mockGuestListService.EXPECT().GetOccupiedSeats(gomock.Any()).Return(nil, nil),
+		s.mockGuestListService.EXPECT().GetOccupiedSeats(gomock.Any()).Return(nil, nil),
+		s.mockGuestListService.EXPECT().GetOccupiedSeats(gomock.Any()).Return(occupied, nil).AnyTimes(),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := s.guestListClient.WatchOccupancy(ctx, &pb.WatchOccupancyRequest{})
+	s.NoError(err)
+
+	first, err := stream.Recv()
+	s.NoError(err)
+	s.Equal(int64(10), first.GetSeatsEmpty())
+	s.Equal(int64(0), first.GetTablesOccupied())
+	s.NotNil(first.GetObservedAt())
+
+	second, err := stream.Recv()
+	s.NoError(err)
+	s.Equal(int64(6), second.GetSeatsEmpty())
+	s.Equal(int64(1), second.GetTablesOccupied())
+}
+
+func (s *ServerSuite) TestWatchOccupancyFailed() {
+	s.mockTableService.EXPECT().GetEmptySeats(gomock.Any()).Return(int64(0), errors.New("connection refused"))
+
+	stream, err := s.guestListClient.WatchOccupancy(context.Background(), &pb.WatchOccupancyRequest{})
+	s.NoError(err)
+
+	_, err = stream.Recv()
+	s.NotErrorIs(err, io.EOF)
+	s.requireStatus(err, codes.Internal)
+}
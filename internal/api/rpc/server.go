@@ -0,0 +1,36 @@
+package rpc
+
+import (
+	pb "github.com/eazygood/getground-app/api/proto/getground/v1"
+	"github.com/eazygood/getground-app/internal/config"
+	"github.com/eazygood/getground-app/internal/core/port"
+	mysql "github.com/eazygood/getground-app/internal/infrastructure/db"
+	"github.com/eazygood/getground-app/internal/infrastructure/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+// NewServer registers the gRPC services of the API, they are served by the same port services as the REST API.
+// With readYourWrites the reads of a call go to the primary once that call wrote something, as over HTTP.
+func NewServer(cfg config.Grpc, readYourWrites bool, guest port.GuestService, table port.TableService, guestList port.GuestListService, checkin port.CheckinService) *grpc.Server {
+	unary := []grpc.UnaryServerInterceptor{log.UnaryServerInterceptor()}
+	stream := []grpc.StreamServerInterceptor{log.StreamServerInterceptor()}
+	if readYourWrites {
+		unary = append(unary, mysql.UnaryServerInterceptor())
+		stream = append(stream, mysql.StreamServerInterceptor())
+	}
+
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	)
+
+	pb.RegisterGuestServiceServer(server, NewGuestServer(guest))
+	pb.RegisterTableServiceServer(server, NewTableServer(table))
+	pb.RegisterGuestListServiceServer(server, NewGuestListServer(guest, table, guestList, checkin, cfg.OccupancyInterval))
+
+	// lets tools such as grpcurl discover the services without the proto files
+	reflection.Register(server)
+
+	return server
+}
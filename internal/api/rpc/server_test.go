@@ -0,0 +1,92 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	pb "github.com/eazygood/getground-app/api/proto/getground/v1"
+	"github.com/eazygood/getground-app/internal/config"
+	mockPort "github.com/eazygood/getground-app/mocks/core/port"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// ServerSuite serves the gRPC API over an in-memory listener, backed by mocked port services
+type ServerSuite struct {
+	suite.Suite
+	*require.Assertions
+	ctrl                 *gomock.Controller
+	mockGuestService     *mockPort.MockGuestService
+	mockTableService     *mockPort.MockTableService
+	mockGuestListService *mockPort.MockGuestListService
+	mockCheckinService   *mockPort.MockCheckinService
+	server               *grpc.Server
+	conn                 *grpc.ClientConn
+	guestClient          pb.GuestServiceClient
+	tableClient          pb.TableServiceClient
+	guestListClient      pb.GuestListServiceClient
+}
+
+func TestServerSuite(t *testing.T) {
+	suite.Run(t, new(ServerSuite))
+}
+
+func (s *ServerSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	s.ctrl = gomock.NewController(s.T())
+	s.mockGuestService = mockPort.NewMockGuestService(s.ctrl)
+	s.mockTableService = mockPort.NewMockTableService(s.ctrl)
+	s.mockGuestListService = mockPort.NewMockGuestListService(s.ctrl)
+	s.mockCheckinService = mockPort.NewMockCheckinService(s.ctrl)
+
+	listener := bufconn.Listen(1024 * 1024)
+	s.server = NewServer(config.Grpc{OccupancyInterval: 10 * time.Millisecond}, true,
+		s.mockGuestService, s.mockTableService, s.mockGuestListService, s.mockCheckinService)
+	go func() {
+		_ = s.server.Serve(listener)
+	}()
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	s.NoError(err)
+
+	s.conn = conn
+	s.guestClient = pb.NewGuestServiceClient(conn)
+	s.tableClient = pb.NewTableServiceClient(conn)
+	s.guestListClient = pb.NewGuestListServiceClient(conn)
+}
+
+func (s *ServerSuite) TearDownTest() {
+	s.conn.Close()
+	s.server.Stop()
+	s.ctrl.Finish()
+}
+
+// requireStatus checks the code of a failed call and returns the rejected fields it carries, if any
+func (s *ServerSuite) requireStatus(err error, code codes.Code) []*errdetails.BadRequest_FieldViolation {
+	st, ok := status.FromError(err)
+	s.True(ok, "not a grpc status: %v", err)
+	s.Equal(code, st.Code(), st.Message())
+
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			return badRequest.FieldViolations
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+
+	"github.com/eazygood/getground-app/internal/errors"
+	"github.com/eazygood/getground-app/internal/infrastructure/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statusError logs err and converts it to the gRPC status matching the HTTP status the REST API
+// would answer with, rejected fields are attached as BadRequest details
+func statusError(ctx context.Context, err errors.ApiError) error {
+	log.FromContext(ctx).WithField("code", err.Code).Error(err.Message)
+
+	st := status.New(grpcCode(err.Code), err.Message)
+	if len(err.Errors) == 0 {
+		return st.Err()
+	}
+
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(err.Errors))
+	for _, field := range err.Errors {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       field.Field,
+			Description: field.Message,
+		})
+	}
+
+	detailed, detailsErr := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if detailsErr != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
+
+func grpcCode(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return codes.InvalidArgument
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusPreconditionFailed:
+		return codes.Aborted
+	case http.StatusConflict, http.StatusPreconditionRequired:
+		return codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	default:
+		return codes.Internal
+	}
+}
+
+// toUint16 narrows the counters of the messages, which protobuf only has as 32 bits integers
+func toUint16(field string, value uint32) (uint16, *errors.FieldError) {
+	if value > math.MaxUint16 {
+		return 0, &errors.FieldError{
+			Field:   field,
+			Rule:    "type",
+			Message: fmt.Sprintf("%s must be an integer between 0 and %d", field, math.MaxUint16),
+		}
+	}
+
+	return uint16(value), nil
+}
@@ -0,0 +1,58 @@
+package rpc
+
+import (
+	"context"
+
+	pb "github.com/eazygood/getground-app/api/proto/getground/v1"
+	"github.com/eazygood/getground-app/internal/api/controller"
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	"github.com/eazygood/getground-app/internal/errors"
+	v "github.com/eazygood/getground-app/internal/validator"
+)
+
+type tableServer struct {
+	pb.UnimplementedTableServiceServer
+	tableService port.TableService
+}
+
+func NewTableServer(tableService port.TableService) pb.TableServiceServer {
+	return &tableServer{tableService: tableService}
+}
+
+func (s *tableServer) CreateTable(ctx context.Context, req *pb.CreateTableRequest) (*pb.Table, error) {
+	seats, fieldErr := toUint16("seats", req.GetSeats())
+	if fieldErr != nil {
+		return nil, statusError(ctx, errors.NewApiError(errors.InvalidInput, errors.NewValidationError(*fieldErr)))
+	}
+
+	body := controller.TableCreateRequest{Seats: seats}
+	if err := v.GetValidator().Struct(body); err != nil {
+		return nil, statusError(ctx, errors.NewApiError(errors.InvalidInput, err))
+	}
+
+	table, err := s.tableService.Create(ctx, &domain.Table{Seats: body.Seats})
+	if err != nil {
+		return nil, statusError(ctx, errors.NewApiError(errors.Internal, err))
+	}
+
+	return tableToProto(table), nil
+}
+
+func (s *tableServer) GetTable(ctx context.Context, req *pb.GetTableRequest) (*pb.Table, error) {
+	table, err := s.tableService.GetById(ctx, req.GetId())
+	if err != nil {
+		return nil, statusError(ctx, errors.NewApiError(errors.NotFound, err))
+	}
+
+	return tableToProto(table), nil
+}
+
+func (s *tableServer) GetEmptySeats(ctx context.Context, _ *pb.GetEmptySeatsRequest) (*pb.GetEmptySeatsResponse, error) {
+	seats, err := s.tableService.GetEmptySeats(ctx)
+	if err != nil {
+		return nil, statusError(ctx, errors.NewApiError(errors.Internal, err))
+	}
+
+	return &pb.GetEmptySeatsResponse{SeatsEmpty: seats}, nil
+}
@@ -0,0 +1,50 @@
+package rpc
+
+import (
+	"context"
+
+	pb "github.com/eazygood/getground-app/api/proto/getground/v1"
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/golang/mock/gomock"
+	"google.golang.org/grpc/codes"
+)
+
+func (s *ServerSuite) TestCreateTable() {
+	s.mockTableService.EXPECT().Create(gomock.Any(), &domain.Table{Seats: 4}).
+		Return(&domain.Table{ID: 3, Seats: 4, Version: 1}, nil)
+
+	table, err := s.tableClient.CreateTable(context.Background(), &pb.CreateTableRequest{Seats: 4})
+	s.NoError(err)
+
+	s.Equal(int64(3), table.GetId())
+	s.Equal(uint32(4), table.GetSeats())
+	s.Nil(table.GuestId)
+}
+
+func (s *ServerSuite) TestCreateTableValidationFailed() {
+	_, err := s.tableClient.CreateTable(context.Background(), &pb.CreateTableRequest{Seats: 0})
+
+	violations := s.requireStatus(err, codes.InvalidArgument)
+	s.Len(violations, 1)
+	s.Equal("seats", violations[0].GetField())
+}
+
+func (s *ServerSuite) TestGetTable() {
+	guestID := int64(7)
+	s.mockTableService.EXPECT().GetById(gomock.Any(), int64(3)).
+		Return(&domain.Table{ID: 3, Seats: 4, GuestID: &guestID, Version: 2}, nil)
+
+	table, err := s.tableClient.GetTable(context.Background(), &pb.GetTableRequest{Id: 3})
+	s.NoError(err)
+
+	s.Equal(guestID, table.GetGuestId())
+}
+
+func (s *ServerSuite) TestGetEmptySeats() {
+	s.mockTableService.EXPECT().GetEmptySeats(gomock.Any()).Return(int64(12), nil)
+
+	resp, err := s.tableClient.GetEmptySeats(context.Background(), &pb.GetEmptySeatsRequest{})
+	s.NoError(err)
+
+	s.Equal(int64(12), resp.GetSeatsEmpty())
+}
@@ -1,27 +1,170 @@
 package config
 
 import (
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
 )
 
 type App struct {
-	Env         string   `mapstructure:"ENV_ID"`
-	Server      Server   `mapstructure:"Server"`
-	Database    Database `mapstructure:"DATABASE"`
-	Environment string   `mapstructure:"ENVIRONMENT"`
-	Log         Log      `mapstructure:"LOG"`
+	Env         string      `mapstructure:"ENV_ID"`
+	Server      Server      `mapstructure:"Server"`
+	Database    Database    `mapstructure:"DATABASE"`
+	Environment string      `mapstructure:"ENVIRONMENT"`
+	Log         Log         `mapstructure:"LOG"`
+	Tracing     Tracing     `mapstructure:"TRACING"`
+	Cache       Cache       `mapstructure:"CACHE"`
+	Venue       Venue       `mapstructure:"VENUE"`
+	Webhooks    Webhooks    `mapstructure:"WEBHOOKS"`
+	Outbox      Outbox      `mapstructure:"OUTBOX"`
+	Tickets     Tickets     `mapstructure:"TICKETS"`
+	Search      Search      `mapstructure:"SEARCH"`
+	Mail        Mail        `mapstructure:"MAIL"`
+	Overbooking Overbooking `mapstructure:"OVERBOOKING"`
+}
+
+type Overbooking struct {
+	// Ratio caps the forecast attendance to a share of the seats, e.g. 1.1 books a tenth more people, 0 disables the policy
+	Ratio float64 `mapstructure:"RATIO"`
+	// MinSamples is how many accepted guests must have arrived or not shown up before the forecast goes by them
+	MinSamples int `mapstructure:"MIN_SAMPLES"`
+}
+
+type Mail struct {
+	// Adapter is smtp to send the emails, or file to write them to Dir as .eml files for development
+	Adapter string `mapstructure:"ADAPTER"`
+	// From is the sender of the emails, e.g. "The Party <party@example.com>"
+	From string   `mapstructure:"FROM"`
+	Dir  string   `mapstructure:"DIR"`
+	SMTP MailSMTP `mapstructure:"SMTP"`
+	// Templates is a directory replacing the built-in templates, with a <kind>.txt and a <kind>.html for every kind of email
+	Templates string `mapstructure:"TEMPLATES"`
+	// Event names the event in the emails, EventDate is when it starts, empty disables the reminders
+	Event     string `mapstructure:"EVENT"`
+	EventDate string `mapstructure:"EVENT_DATE"`
+	// Reminders lists how long before the event the guests who did not answer for sure are reminded of it
+	Reminders []time.Duration `mapstructure:"REMINDERS"`
+	// PollInterval is how often the due reminders are looked up, BatchSize how many are sent at a time
+	PollInterval time.Duration `mapstructure:"POLL_INTERVAL"`
+	BatchSize    int           `mapstructure:"BATCH_SIZE"`
+	// a reminder that could not be sent is retried after InitialBackoff, doubled on every attempt up to MaxBackoff,
+	// until MaxAttempts were made
+	MaxAttempts    int           `mapstructure:"MAX_ATTEMPTS"`
+	InitialBackoff time.Duration `mapstructure:"INITIAL_BACKOFF"`
+	MaxBackoff     time.Duration `mapstructure:"MAX_BACKOFF"`
+}
+
+type MailSMTP struct {
+	Host     string `mapstructure:"HOST"`
+	Port     string `mapstructure:"PORT"`
+	Username string `mapstructure:"USERNAME"`
+	Password string `mapstructure:"PASSWORD" json:"-"`
+	// StartTLS upgrades the connection before authenticating, the server must offer it
+	StartTLS bool `mapstructure:"STARTTLS"`
+	// Timeout bounds the whole conversation with the server
+	Timeout time.Duration `mapstructure:"TIMEOUT"`
+}
+
+type Search struct {
+	// Adapter is fulltext to look the guests up in the FULLTEXT index of MySQL, or memory to score them all in process
+	Adapter string `mapstructure:"ADAPTER"`
+}
+
+type Tickets struct {
+	// Secret signs the ticket tokens, changing it invalidates every ticket issued so far. It is read from
+	// TICKETS_SECRET and must be at least 32 bytes long
+	Secret string `mapstructure:"SECRET" json:"-"`
+	// Event is signed along with the guest, so a ticket of another party is not valid at this one
+	Event string `mapstructure:"EVENT"`
+	// QRSize is the width and height of the QR code images, in pixels
+	QRSize int `mapstructure:"QR_SIZE"`
+}
+
+type Outbox struct {
+	// Sinks the events are published to, webhooks and log
+	Sinks []string `mapstructure:"SINKS"`
+	// PollInterval is how often the pending messages are looked up, BatchSize how many are published at a time
+	PollInterval time.Duration `mapstructure:"POLL_INTERVAL"`
+	BatchSize    int           `mapstructure:"BATCH_SIZE"`
+	// a message a sink failed to take is retried after InitialBackoff, doubled on every attempt up to MaxBackoff
+	InitialBackoff time.Duration `mapstructure:"INITIAL_BACKOFF"`
+	MaxBackoff     time.Duration `mapstructure:"MAX_BACKOFF"`
+}
+
+type Webhooks struct {
+	// OccupancyThreshold is the share of the seats taken, between 0 and 1, above which
+	// occupancy.threshold_crossed is published, 0 disables the event
+	OccupancyThreshold float64 `mapstructure:"OCCUPANCY_THRESHOLD"`
+	// PollInterval is how often the pending deliveries are looked up, BatchSize how many are attempted at a time
+	PollInterval time.Duration `mapstructure:"POLL_INTERVAL"`
+	BatchSize    int           `mapstructure:"BATCH_SIZE"`
+	// Timeout bounds every delivery request
+	Timeout time.Duration `mapstructure:"TIMEOUT"`
+	// a failed delivery is retried after InitialBackoff, doubled on every attempt up to MaxBackoff,
+	// until MaxAttempts were made
+	MaxAttempts    int           `mapstructure:"MAX_ATTEMPTS"`
+	InitialBackoff time.Duration `mapstructure:"INITIAL_BACKOFF"`
+	MaxBackoff     time.Duration `mapstructure:"MAX_BACKOFF"`
+	// AllowPrivateNetworks lets the webhooks reach loopback, link-local and private addresses, for local development only
+	AllowPrivateNetworks bool `mapstructure:"ALLOW_PRIVATE_NETWORKS"`
+}
+
+type Venue struct {
+	// Timezone is the IANA timezone of the venue, timestamps are rendered in it by default
+	Timezone string `mapstructure:"TIMEZONE"`
+	// MaxPartySize caps a guest and their accompanying guests, 0 means no limit
+	MaxPartySize int `mapstructure:"MAX_PARTY_SIZE"`
 }
 
 type Server struct {
-	Http Http `mapstructure:"Http"`
+	Http      Http      `mapstructure:"Http"`
+	Health    Health    `mapstructure:"HEALTH"`
+	RateLimit RateLimit `mapstructure:"RATE_LIMIT"`
+	Grpc      Grpc      `mapstructure:"GRPC"`
+}
+
+type Grpc struct {
+	Enabled bool   `mapstructure:"ENABLED"`
+	Host    string `mapstructure:"HOST"`
+	Port    string `mapstructure:"PORT"`
+	// OccupancyInterval is how often the occupancy stream checks the venue for changes
+	OccupancyInterval time.Duration `mapstructure:"OCCUPANCY_INTERVAL"`
 }
 
 type Http struct {
 	Host            string        `mapstructure:"HOST"`
 	Port            string        `mapstructure:"PORT"`
 	ShutdownTimeout time.Duration `mapstructure:"SHUTDOWN_TIMEOUT"`
+	// DrainDelay is how long the server keeps serving with a failing readiness probe before shutting down
+	DrainDelay time.Duration `mapstructure:"DRAIN_DELAY"`
+	// TrustedProxies are the proxies whose X-Forwarded-For is believed, none by default
+	TrustedProxies []string `mapstructure:"TRUSTED_PROXIES"`
+}
+
+type Health struct {
+	// Timeout bounds every dependency probe of the readiness endpoint
+	Timeout time.Duration `mapstructure:"TIMEOUT"`
+}
+
+type RateLimit struct {
+	Enabled bool `mapstructure:"ENABLED"`
+	// APIKeyHeader identifies the client when it carries one of APIKeys, other requests are limited by client IP
+	APIKeyHeader string          `mapstructure:"API_KEY_HEADER"`
+	APIKeys      []string        `mapstructure:"API_KEYS" json:"-"`
+	Read         RateLimitBudget `mapstructure:"READ"`
+	Write        RateLimitBudget `mapstructure:"WRITE"`
+	// Routes overrides the read or write budget of a route, keyed by method and route, e.g. "GET /guests"
+	Routes map[string]RateLimitBudget `mapstructure:"ROUTES"`
+	// MaxBuckets bounds the buckets kept in memory, the ones closest to full are forgotten first
+	MaxBuckets int `mapstructure:"MAX_BUCKETS"`
+}
+
+// RateLimitBudget refills Requests tokens every Period, up to Burst tokens
+type RateLimitBudget struct {
+	Requests int           `mapstructure:"REQUESTS"`
+	Period   time.Duration `mapstructure:"PERIOD"`
+	Burst    int           `mapstructure:"BURST"`
 }
 
 type Database struct {
@@ -30,6 +173,42 @@ type Database struct {
 	Password string `mapstructure:"PASSWORD" json:"-"`
 	Host     string `mapstructure:"HOST"`
 	Port     string `mapstructure:"PORT"`
+
+	MaxOpenConns    int           `mapstructure:"MAX_OPEN_CONNS"`
+	MaxIdleConns    int           `mapstructure:"MAX_IDLE_CONNS"`
+	ConnMaxLifetime time.Duration `mapstructure:"CONN_MAX_LIFETIME"`
+	ConnMaxIdleTime time.Duration `mapstructure:"CONN_MAX_IDLE_TIME"`
+	// QueryTimeout bounds every query, on top of the deadline of the request context if any
+	QueryTimeout time.Duration `mapstructure:"QUERY_TIMEOUT"`
+
+	TLS     DatabaseTLS     `mapstructure:"TLS"`
+	Connect DatabaseConnect `mapstructure:"CONNECT"`
+
+	// Replicas serve the heavy read endpoints, they share the name and credentials of the primary
+	Replicas []DatabaseReplica `mapstructure:"REPLICAS"`
+	// ReadYourWrites sends the reads of a request to the primary once that request wrote something
+	ReadYourWrites bool `mapstructure:"READ_YOUR_WRITES"`
+}
+
+type DatabaseReplica struct {
+	Host string `mapstructure:"HOST"`
+	Port string `mapstructure:"PORT"`
+}
+
+type DatabaseTLS struct {
+	Enabled            bool   `mapstructure:"ENABLED"`
+	CAFile             string `mapstructure:"CA_FILE"`
+	CertFile           string `mapstructure:"CERT_FILE"`
+	KeyFile            string `mapstructure:"KEY_FILE"`
+	ServerName         string `mapstructure:"SERVER_NAME"`
+	InsecureSkipVerify bool   `mapstructure:"INSECURE_SKIP_VERIFY"`
+}
+
+// DatabaseConnect configures how long the service waits for the database on startup
+type DatabaseConnect struct {
+	MaxAttempts    int           `mapstructure:"MAX_ATTEMPTS"`
+	InitialBackoff time.Duration `mapstructure:"INITIAL_BACKOFF"`
+	MaxBackoff     time.Duration `mapstructure:"MAX_BACKOFF"`
 }
 
 type Log struct {
@@ -37,10 +216,36 @@ type Log struct {
 	Formatter string `mapstructure:"FORMATTER"`
 }
 
+type Tracing struct {
+	// Exporter is one of "none", "stdout" or "otlp"
+	Exporter    string  `mapstructure:"EXPORTER"`
+	Endpoint    string  `mapstructure:"ENDPOINT"`
+	Insecure    bool    `mapstructure:"INSECURE"`
+	ServiceName string  `mapstructure:"SERVICE_NAME"`
+	SampleRatio float64 `mapstructure:"SAMPLE_RATIO"`
+}
+
+type Cache struct {
+	// Store is none, memory for an in-process LRU, or redis for any Redis-compatible server
+	Store string        `mapstructure:"STORE"`
+	Size  int           `mapstructure:"SIZE"`
+	TTL   time.Duration `mapstructure:"TTL"`
+	Redis CacheRedis    `mapstructure:"REDIS"`
+}
+
+type CacheRedis struct {
+	Addr     string `mapstructure:"ADDR"`
+	Password string `mapstructure:"PASSWORD"`
+	DB       int    `mapstructure:"DB"`
+	Prefix   string `mapstructure:"PREFIX"`
+}
+
 func Load(path string) (*App, error) {
 	viper.AddConfigPath(path)
 	viper.SetConfigName("config")
 	viper.SetConfigType("yaml")
+	// nested keys are read from the environment too, e.g. TICKETS_SECRET for tickets.secret
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	viper.AutomaticEnv()
 
 	err := viper.ReadInConfig()
@@ -0,0 +1,17 @@
+package domain
+
+// CapacityReport compares the parties expected at the event with the tables of the venue. The accepted
+// guests are expected with the party size of their RSVP and the arrived ones with their accompanying
+// guests. Every party needs a table of its own: Unseatable counts the expected parties left without a
+// table big enough once as many as possible are seated, FreeSeats is negative when people outnumber seats.
+type CapacityReport struct {
+	Tables           int            `json:"tables"`
+	Seats            int            `json:"seats"`
+	Statuses         map[string]int `json:"statuses"`
+	Parties          int            `json:"parties"`
+	People           int            `json:"people"`
+	TentativeParties int            `json:"tentative_parties"`
+	TentativePeople  int            `json:"tentative_people"`
+	FreeSeats        int            `json:"free_seats"`
+	Unseatable       int            `json:"unseatable_parties"`
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Companion is a named member of the entourage of a guest, the accompanying guests of the guest are
+// counted from their companions
+type Companion struct {
+	ID           int64      `json:"id" db:"id"`
+	GuestID      int64      `json:"guest_id" db:"guest_id"`
+	Name         string     `json:"name" db:"name"`
+	Dietary      Dietary    `json:"dietary,omitempty" db:"dietary"`
+	DietaryNotes string     `json:"dietary_notes,omitempty" db:"dietary_notes"`
+	IsArrived    bool       `json:"is_arrived" db:"is_arrived"`
+	LeftAt       *time.Time `json:"left_at" db:"left_at"`
+}
+
+// PartyTooLargeError is returned when naming one more companion would outgrow the table the guest is seated at
+type PartyTooLargeError struct {
+	Party   int
+	Seats   uint16
+	TableID int64
+}
+
+func (e *PartyTooLargeError) Error() string {
+	return fmt.Sprintf("guest party of %d exceeds the %d seats of table %d", e.Party, e.Seats, e.TableID)
+}
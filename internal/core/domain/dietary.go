@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// Dietary requirements the kitchen caters for, anything else goes in the dietary notes of the guest
+const (
+	DietaryVegetarian  = "vegetarian"
+	DietaryVegan       = "vegan"
+	DietaryPescatarian = "pescatarian"
+	DietaryGlutenFree  = "gluten_free"
+	DietaryDairyFree   = "dairy_free"
+	DietaryNutAllergy  = "nut_allergy"
+	DietaryHalal       = "halal"
+	DietaryKosher      = "kosher"
+)
+
+// DietaryRequirements lists every requirement a guest can have
+var DietaryRequirements = []string{
+	DietaryVegetarian,
+	DietaryVegan,
+	DietaryPescatarian,
+	DietaryGlutenFree,
+	DietaryDairyFree,
+	DietaryNutAllergy,
+	DietaryHalal,
+	DietaryKosher,
+}
+
+// Dietary is the list of the requirements of a guest, stored in a MySQL SET column
+type Dietary []string
+
+// Has tells whether requirement is part of the list
+func (d Dietary) Has(requirement string) bool {
+	for _, r := range d {
+		if r == requirement {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (d Dietary) Value() (driver.Value, error) {
+	return strings.Join(d, ","), nil
+}
+
+func (d *Dietary) Scan(value interface{}) error {
+	var set string
+
+	switch v := value.(type) {
+	case nil:
+	case []byte:
+		set = string(v)
+	case string:
+		set = v
+	default:
+		return fmt.Errorf("cannot scan %T into dietary requirements", value)
+	}
+
+	*d = Dietary{}
+	if set != "" {
+		*d = strings.Split(set, ",")
+	}
+
+	return nil
+}
+
+// DietaryGuest is a guest with dietary requirements or notes, as listed for the kitchen. CompanionID is set
+// when the requirements are the ones of a companion of the guest, Name is then the name of the companion.
+type DietaryGuest struct {
+	GuestID      int64   `json:"guest_id"`
+	CompanionID  *int64  `json:"companion_id,omitempty"`
+	Name         string  `json:"name"`
+	Dietary      Dietary `json:"dietary"`
+	DietaryNotes string  `json:"dietary_notes,omitempty"`
+}
+
+// DietaryGroup counts the requirements of the people of a table, their accompanying guests included,
+// Guests lists the ones who have requirements or notes
+type DietaryGroup struct {
+	// TableID is null for the guests who are not seated yet
+	TableID *int64         `json:"table_id"`
+	People  int            `json:"people"`
+	Counts  map[string]int `json:"counts"`
+	Guests  []DietaryGuest `json:"guests"`
+}
+
+// DietaryReport sums up the dietary requirements per table, the guests who are not seated yet are
+// counted apart so the kitchen can plan for them
+type DietaryReport struct {
+	Tables   []DietaryGroup `json:"tables"`
+	Unseated DietaryGroup   `json:"unseated"`
+	Totals   map[string]int `json:"totals"`
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Kinds of the emails sent to the guests, each one is rendered from the templates of the same name
+const (
+	EmailInvitation      = "invitation"
+	EmailReminder        = "reminder"
+	EmailTableAssignment = "table_assignment"
+)
+
+// EmailKinds lists every email that can be sent to a guest
+var EmailKinds = []string{
+	EmailInvitation,
+	EmailReminder,
+	EmailTableAssignment,
+}
+
+var (
+	// ErrNoEmailAddress is returned when an email is sent to a guest who did not leave their address
+	ErrNoEmailAddress = errors.New("guest has no email address")
+	// ErrNotSeated is returned when the table assignment is sent to a guest who has no table
+	ErrNotSeated = errors.New("guest is not seated at a table")
+)
+
+// Email is a rendered message to a single recipient, Text and HTML are the two alternatives of its body
+type Email struct {
+	To      string
+	ToName  string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// EmailData is what the templates are rendered with, Table is set for the table assignment only
+type EmailData struct {
+	Guest     *Guest
+	Event     string
+	EventDate time.Time
+	Table     *Table
+}
+
+// GuestReminder records a reminder sent to a guest, DueAt tells the reminders of the schedule apart.
+// SentAt is set when the reminder is claimed and cleared when it could not be sent, it is then sent
+// again at NextAttemptAt
+type GuestReminder struct {
+	GuestID       int64      `db:"guest_id" gorm:"primaryKey;autoIncrement:false"`
+	DueAt         time.Time  `db:"due_at" gorm:"primaryKey"`
+	Attempts      int        `db:"attempts"`
+	SentAt        *time.Time `db:"sent_at"`
+	NextAttemptAt *time.Time `db:"next_attempt_at"`
+	LastError     string     `db:"last_error"`
+}
+
+func (GuestReminder) TableName() string {
+	return "guest_reminders"
+}
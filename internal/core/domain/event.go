@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Types of the events published through the outbox, webhooks subscribe to them
+const (
+	EventGuestCreated      = "guest.created"
+	EventGuestUpdated      = "guest.updated"
+	EventGuestArrived      = "guest.arrived"
+	EventGuestLeft         = "guest.left"
+	EventGuestRestored     = "guest.restored"
+	EventTableCreated      = "table.created"
+	EventTableUpdated      = "table.updated"
+	EventTableDeleted      = "table.deleted"
+	EventTableRestored     = "table.restored"
+	EventOccupancyExceeded = "occupancy.threshold_crossed"
+)
+
+// EventTypes lists every event type, in the order they are documented
+var EventTypes = []string{
+	EventGuestCreated, EventGuestUpdated, EventGuestArrived, EventGuestLeft, EventGuestRestored,
+	EventTableCreated, EventTableUpdated, EventTableDeleted, EventTableRestored,
+	EventOccupancyExceeded,
+}
+
+// Event is something that happened at the party, ID identifies it across deliveries so that
+// receivers can drop the ones they already handled
+type Event struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+func NewEvent(eventType string, occurredAt time.Time, data interface{}) (Event, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return Event{}, fmt.Errorf("encode %s event: %w", eventType, err)
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return Event{}, fmt.Errorf("generate event id: %w", err)
+	}
+
+	return Event{ID: hex.EncodeToString(id), Type: eventType, OccurredAt: occurredAt, Data: raw}, nil
+}
+
+// GuestEvent is the data of the other guest events: who the guest is and how they are seated. Their contact
+// details, dietary requirements, accessibility needs and notes stay out of the events, which reach the webhooks
+// and the logs
+type GuestEvent struct {
+	ID                 int64          `json:"id"`
+	Name               string         `json:"name"`
+	AccompanyingGuests uint16         `json:"accompanying_guests"`
+	TimeArrived        *time.Time     `json:"time_arrived"`
+	IsArrived          bool           `json:"is_arrived"`
+	Status             string         `json:"status,omitempty"`
+	PartySize          uint16         `json:"party_size,omitempty"`
+	Version            int64          `json:"version"`
+	DeletedAt          gorm.DeletedAt `json:"deleted_at"`
+}
+
+func NewGuestEvent(guest *Guest) GuestEvent {
+	return GuestEvent{
+		ID:                 guest.ID,
+		Name:               guest.Name,
+		AccompanyingGuests: guest.AccompanyingGuests,
+		TimeArrived:        guest.TimeArrived,
+		IsArrived:          guest.IsArrived,
+		Status:             guest.Status,
+		PartySize:          guest.PartySize,
+		Version:            guest.Version,
+		DeletedAt:          guest.DeletedAt,
+	}
+}
+
+// GuestLeft is the data of guest.left
+type GuestLeft struct {
+	GuestID int64 `json:"guest_id"`
+}
+
+// TableDeleted is the data of table.deleted
+type TableDeleted struct {
+	TableID int64 `json:"table_id"`
+}
+
+// OutboxMessage is an event waiting to be published, it is stored in the transaction of the
+// mutation it comes from so that it is published if and only if the mutation is committed
+type OutboxMessage struct {
+	ID int64 `json:"id" db:"id"`
+	// EventID deduplicates the message, sinks may see it more than once
+	EventID   string          `json:"event_id" db:"event_id"`
+	EventType string          `json:"event_type" db:"event_type"`
+	Payload   json.RawMessage `json:"payload" db:"payload"`
+	Attempts  int             `json:"attempts" db:"attempts"`
+	// NextAttemptAt delays the message after a sink failed to publish it
+	NextAttemptAt time.Time  `json:"next_attempt_at" db:"next_attempt_at"`
+	LastError     string     `json:"last_error,omitempty" db:"last_error"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	PublishedAt   *time.Time `json:"published_at" db:"published_at"`
+}
+
+func (OutboxMessage) TableName() string {
+	return "outbox"
+}
+
+func NewOutboxMessage(event Event) (*OutboxMessage, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("encode event %v: %w", event.ID, err)
+	}
+
+	return &OutboxMessage{
+		EventID:       event.ID,
+		EventType:     event.Type,
+		Payload:       payload,
+		NextAttemptAt: event.OccurredAt,
+		CreatedAt:     event.OccurredAt,
+	}, nil
+}
+
+// Event decodes the event the message carries
+func (m *OutboxMessage) Event() (Event, error) {
+	event := Event{}
+	if err := json.Unmarshal(m.Payload, &event); err != nil {
+		return Event{}, fmt.Errorf("decode event %v: %w", m.EventID, err)
+	}
+
+	return event, nil
+}
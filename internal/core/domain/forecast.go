@@ -0,0 +1,104 @@
+package domain
+
+import "fmt"
+
+// OverbookingPolicy lets the guests accept while the forecast attendance stays within Ratio times the seats,
+// e.g. 1.1 books up to a tenth more people than seats on top of the expected no-shows. A zero Ratio disables
+// the policy. The forecast trusts the history of the event once MinSamples outcomes are known.
+type OverbookingPolicy struct {
+	Ratio      float64
+	MinSamples int
+}
+
+func (p OverbookingPolicy) Enabled() bool {
+	return p.Ratio > 0
+}
+
+// Forecast predicts the attendance from the guests who accepted so far and whose outcome is known: those who
+// arrived tell how their actual entourage compares to the party size they planned, those marked as no-show
+// how many parties do not turn up. Until Samples reaches the MinSamples of the policy every accepted party is
+// expected in full. ExpectedPeople adds the people expected from the accepted parties to the ones present.
+type Forecast struct {
+	Samples        int     `json:"samples"`
+	Trusted        bool    `json:"trusted"`
+	Arrivals       int     `json:"arrivals"`
+	NoShows        int     `json:"no_shows"`
+	PlannedPeople  int     `json:"planned_people"`
+	ActualPeople   int     `json:"actual_people"`
+	NoShowRate     float64 `json:"no_show_rate"`
+	EntourageRatio float64 `json:"entourage_ratio"`
+	Seats          int     `json:"seats"`
+	PresentPeople  int     `json:"present_people"`
+	PendingParties int     `json:"pending_parties"`
+	PendingPeople  int     `json:"pending_people"`
+	ExpectedPeople float64 `json:"expected_people"`
+	// Ratio and Capacity, the people the overbooking policy books, are left out when it is disabled
+	Ratio    float64 `json:"overbooking_ratio,omitempty"`
+	Capacity float64 `json:"capacity,omitempty"`
+}
+
+// NewForecast forecasts the attendance of guests, the ones who left included, at seats
+func NewForecast(guests []*Guest, seats int, policy OverbookingPolicy) *Forecast {
+	forecast := &Forecast{Seats: seats, EntourageRatio: 1}
+
+	for _, guest := range guests {
+		// the party size is set by the acceptance only, declining forgets it
+		accepted := guest.PartySize > 0
+
+		switch {
+		case guest.Status == GuestStatusAccepted:
+			forecast.PendingParties++
+			forecast.PendingPeople += int(guest.PartySize)
+		case guest.Status == GuestStatusNoShow && accepted:
+			forecast.NoShows++
+		case guest.TimeArrived != nil && accepted:
+			forecast.Arrivals++
+			forecast.PlannedPeople += int(guest.PartySize)
+			forecast.ActualPeople += 1 + int(guest.AccompanyingGuests)
+		}
+
+		if guest.Status == GuestStatusArrived {
+			forecast.PresentPeople += 1 + int(guest.AccompanyingGuests)
+		}
+	}
+
+	forecast.Samples = forecast.Arrivals + forecast.NoShows
+	forecast.Trusted = forecast.Samples > 0 && forecast.Samples >= policy.MinSamples
+
+	if forecast.Trusted {
+		forecast.NoShowRate = float64(forecast.NoShows) / float64(forecast.Samples)
+
+		if forecast.PlannedPeople > 0 {
+			forecast.EntourageRatio = float64(forecast.ActualPeople) / float64(forecast.PlannedPeople)
+		}
+	}
+
+	forecast.ExpectedPeople = float64(forecast.PresentPeople) + forecast.Expect(forecast.PendingPeople)
+
+	if policy.Enabled() {
+		forecast.Ratio = policy.Ratio
+		forecast.Capacity = policy.Ratio * float64(seats)
+	}
+
+	return forecast
+}
+
+// Expect is how many people are expected from parties of partySize people who accepted
+func (f *Forecast) Expect(partySize int) float64 {
+	return float64(partySize) * (1 - f.NoShowRate) * f.EntourageRatio
+}
+
+// Admits tells whether the policy books people on top of the ones expected, always when it is disabled
+func (f *Forecast) Admits(people float64) bool {
+	return f.Ratio <= 0 || f.ExpectedPeople+people <= f.Capacity
+}
+
+// OverbookedError is returned when a party would bring the forecast attendance over the capacity
+type OverbookedError struct {
+	Expected float64
+	Capacity float64
+}
+
+func (e *OverbookedError) Error() string {
+	return fmt.Sprintf("forecast attendance of %.1f people would exceed the capacity of %.1f", e.Expected, e.Capacity)
+}
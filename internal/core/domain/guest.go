@@ -1,11 +1,68 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"time"
 
+	"gorm.io/gorm"
+)
+
+// Guest is an invited person, the members of their profile from Email to Notes are optional and
+// left out of the responses when empty, Notes are written by the staff for the staff.
+// Status follows the state machine of the guests, PartySize and RespondedAt are set by their RSVP.
+// AccompanyingGuests counts the named companions of the guest and the UnnamedGuests they bring on top.
 type Guest struct {
-	ID                 int64      `json:"id" db:"id"`
-	Name               string     `json:"name" db:"name"`
-	AccompanyingGuests uint16     `json:"accompanying_guests" db:"accompanying_guests"`
-	TimeArrived        *time.Time `json:"time_arrived" db:"time_arrived"`
-	IsArrived          bool       `json:"is_arrived" db:"is_arrived"`
+	ID                 int64          `json:"id" db:"id"`
+	Name               string         `json:"name" db:"name"`
+	AccompanyingGuests uint16         `json:"accompanying_guests" db:"accompanying_guests"`
+	UnnamedGuests      uint16         `json:"-" db:"unnamed_guests"`
+	TimeArrived        *time.Time     `json:"time_arrived" db:"time_arrived"`
+	IsArrived          bool           `json:"is_arrived" db:"is_arrived"`
+	Email              string         `json:"email,omitempty" db:"email"`
+	Phone              string         `json:"phone,omitempty" db:"phone"`
+	Company            string         `json:"company,omitempty" db:"company"`
+	Dietary            Dietary        `json:"dietary,omitempty" db:"dietary"`
+	DietaryNotes       string         `json:"dietary_notes,omitempty" db:"dietary_notes"`
+	Accessibility      string         `json:"accessibility,omitempty" db:"accessibility"`
+	Notes              string         `json:"notes,omitempty" db:"notes"`
+	Status             string         `json:"status,omitempty" db:"status"`
+	PartySize          uint16         `json:"party_size,omitempty" db:"party_size"`
+	RespondedAt        *time.Time     `json:"responded_at,omitempty" db:"responded_at"`
+	Version            int64          `json:"version" db:"version"`
+	DeletedAt          gorm.DeletedAt `json:"deleted_at" db:"deleted_at"`
+}
+
+// GuestMatch is a guest found by a search, Score tells how close their name is to the query, from 0 to 1
+type GuestMatch struct {
+	Guest *Guest  `json:"guest"`
+	Score float64 `json:"score"`
+}
+
+// ErrNotFound is wrapped by the errors of the lookups that found nothing, such as an unknown guest
+var ErrNotFound = errors.New("record not found")
+
+// notFoundError is an error of its own that still tells it found nothing
+type notFoundError string
+
+func (e notFoundError) Error() string {
+	return string(e)
+}
+
+func (e notFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// CheckedInError is returned when a guest checks in again, while they are seated or after they left
+type CheckedInError struct {
+	GuestID int64
+	Left    bool
+}
+
+func (e *CheckedInError) Error() string {
+	if e.Left {
+		return fmt.Sprintf("guest %d already left", e.GuestID)
+	}
+
+	return fmt.Sprintf("guest %d already has seats", e.GuestID)
 }
@@ -0,0 +1,85 @@
+package domain
+
+import "fmt"
+
+// Statuses of a guest, from their invitation to the end of the event
+const (
+	GuestStatusInvited   = "invited"
+	GuestStatusAccepted  = "accepted"
+	GuestStatusDeclined  = "declined"
+	GuestStatusTentative = "tentative"
+	GuestStatusArrived   = "arrived"
+	GuestStatusLeft      = "left"
+	GuestStatusNoShow    = "no_show"
+)
+
+// GuestStatuses lists every status a guest can be in
+var GuestStatuses = []string{
+	GuestStatusInvited,
+	GuestStatusAccepted,
+	GuestStatusDeclined,
+	GuestStatusTentative,
+	GuestStatusArrived,
+	GuestStatusLeft,
+	GuestStatusNoShow,
+}
+
+// RSVPStatuses are the answers a guest can give to their invitation
+var RSVPStatuses = []string{
+	GuestStatusAccepted,
+	GuestStatusDeclined,
+	GuestStatusTentative,
+}
+
+// guestTransitions is the state machine of the guests. A guest answers as often as they like until the
+// event, whoever turns up arrives whatever they answered, and only the guests who were expected can be
+// no-shows. A guest who left comes back arrived or invited when they are restored.
+var guestTransitions = map[string][]string{
+	GuestStatusInvited:   {GuestStatusAccepted, GuestStatusDeclined, GuestStatusTentative, GuestStatusArrived, GuestStatusNoShow, GuestStatusLeft},
+	GuestStatusTentative: {GuestStatusAccepted, GuestStatusDeclined, GuestStatusTentative, GuestStatusArrived, GuestStatusNoShow, GuestStatusLeft},
+	GuestStatusAccepted:  {GuestStatusAccepted, GuestStatusDeclined, GuestStatusTentative, GuestStatusArrived, GuestStatusNoShow, GuestStatusLeft},
+	GuestStatusDeclined:  {GuestStatusAccepted, GuestStatusDeclined, GuestStatusTentative, GuestStatusArrived, GuestStatusLeft},
+	GuestStatusNoShow:    {GuestStatusArrived, GuestStatusLeft},
+	GuestStatusArrived:   {GuestStatusLeft},
+	GuestStatusLeft:      {GuestStatusArrived, GuestStatusInvited},
+}
+
+// IsGuestStatus tells whether status is one of GuestStatuses
+func IsGuestStatus(status string) bool {
+	for _, s := range GuestStatuses {
+		if s == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CanTransition tells whether a guest in status from can move to status to
+func CanTransition(from, to string) bool {
+	for _, status := range guestTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TransitionError is returned when a change of status of a guest breaks the state machine
+type TransitionError struct {
+	GuestID int64
+	From    string
+	To      string
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("guest %d cannot go from %s to %s", e.GuestID, e.From, e.To)
+}
+
+// RSVP is the answer of a guest to their invitation, PartySize counts the people expected with the
+// guest included and is zero when they declined
+type RSVP struct {
+	Status    string
+	PartySize uint16
+}
@@ -1,8 +1,15 @@
 package domain
 
+import "gorm.io/gorm"
+
+// ErrNoAvailableSeats is returned when no free table fits a party, it is an ErrNotFound
+var ErrNoAvailableSeats error = notFoundError("no available seats")
+
 type Table struct {
-	ID      int64  `json:"id" db:"id"`
-	Seats   uint16 `json:"seats" db:"seats"`
-	GuestID *int64 `json:"guest_id" db:"guest_id"`
-	Guest   Guest  `json:"-" gorm:"foreignKey:ID;references:GuestID"`
+	ID        int64          `json:"id" db:"id"`
+	Seats     uint16         `json:"seats" db:"seats"`
+	GuestID   *int64         `json:"guest_id" db:"guest_id"`
+	Version   int64          `json:"version" db:"version"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at" db:"deleted_at"`
+	Guest     Guest          `json:"-" gorm:"foreignKey:ID;references:GuestID"`
 }
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Webhook struct {
+	ID  int64  `json:"id" db:"id"`
+	URL string `json:"url" db:"url"`
+	// Secret signs the payloads, it is only handed out when the webhook is registered
+	Secret     string         `json:"-" db:"secret"`
+	EventTypes []string       `json:"event_types" db:"event_types" gorm:"serializer:json"`
+	CreatedAt  time.Time      `json:"created_at" db:"created_at"`
+	DeletedAt  gorm.DeletedAt `json:"-" db:"deleted_at"`
+}
+
+// Subscribes tells whether the webhook is notified of events of eventType
+func (w *Webhook) Subscribes(eventType string) bool {
+	for _, t := range w.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	DeliveryFailed    DeliveryStatus = "failed"
+)
+
+// WebhookDelivery is an event to deliver to a webhook, pending deliveries are retried until
+// they succeed or run out of attempts, and are kept afterwards as the delivery history
+type WebhookDelivery struct {
+	ID             int64           `json:"id" db:"id"`
+	WebhookID      int64           `json:"webhook_id" db:"webhook_id"`
+	EventID        string          `json:"event_id" db:"event_id"`
+	EventType      string          `json:"event_type" db:"event_type"`
+	Payload        json.RawMessage `json:"payload" db:"payload"`
+	Status         DeliveryStatus  `json:"status" db:"status"`
+	Attempts       int             `json:"attempts" db:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at" db:"next_attempt_at"`
+	LastError      string          `json:"last_error,omitempty" db:"last_error"`
+	ResponseStatus int             `json:"response_status,omitempty" db:"response_status"`
+	CreatedAt      time.Time       `json:"created_at" db:"created_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at" db:"delivered_at"`
+}
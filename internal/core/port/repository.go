@@ -2,36 +2,158 @@ package port
 
 import (
 	"context"
+	"time"
 
 	"github.com/eazygood/getground-app/internal/core/domain"
 )
 
 type GetGuestFilter struct {
-	IsArrived bool `json:"is_arrived"`
+	// IsArrived lists only the guests who arrived (true) or did not (false)
+	IsArrived      *bool `json:"is_arrived"`
+	IncludeDeleted bool  `json:"include_deleted"`
+	// IDs restricts the list to the given guests
+	IDs []int64 `json:"ids"`
+	// Dietary restricts the list to the guests with this requirement, Company to the guests of this company
+	Dietary string `json:"dietary"`
+	Company string `json:"company"`
+	// Status restricts the list to the guests in this status
+	Status string `json:"status"`
+	// AfterID and Limit page through the guests in id order, a zero Limit lists them all
+	AfterID int64 `json:"after_id"`
+	Limit   int   `json:"limit"`
+	// Latest reads the guests as last written rather than from a replica, for a read feeding a versioned write
+	Latest bool `json:"-"`
+}
+
+type GetTableFilter struct {
+	// Occupied lists only the tables with (true) or without (false) a guest
+	Occupied *bool `json:"occupied"`
+	// MinSeats lists only the tables with at least that many seats
+	MinSeats uint16 `json:"min_seats"`
+	// IDs and GuestIDs restrict the list to the given tables and to the tables of the given guests
+	IDs      []int64 `json:"ids"`
+	GuestIDs []int64 `json:"guest_ids"`
+	// AfterID and Limit page through the tables in id order, a zero Limit lists them all
+	AfterID int64 `json:"after_id"`
+	Limit   int   `json:"limit"`
+	// Latest reads the tables as last written rather than from a replica, for a read feeding a versioned write
+	Latest bool `json:"-"`
+}
+
+// GuestPatch is a partial update of a guest, only the columns listed in Fields are written,
+// zero values included
+type GuestPatch struct {
+	Guest  domain.Guest
+	Fields []string
+}
+
+// Has tells whether field is one of the columns the patch writes
+func (p GuestPatch) Has(field string) bool {
+	for _, f := range p.Fields {
+		if f == field {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TablePatch is a partial update of a table, only the columns listed in Fields are written,
+// zero values included
+type TablePatch struct {
+	Table  domain.Table
+	Fields []string
 }
 
 //go:generate mockgen -source repository.go -destination=../../../mocks/core/port/repository_mock.go -package ports
 type GuestRepository interface {
 	GetById(ctx context.Context, id int64) (*domain.Guest, error)
+	// GetLatestById reads the guest as last written, GetById may read it from a replica lagging behind
+	GetLatestById(ctx context.Context, id int64) (*domain.Guest, error)
 	GetAll(ctx context.Context, filter GetGuestFilter) ([]*domain.Guest, error)
 	Create(ctx context.Context, guest *domain.Guest) (*domain.Guest, error)
 	Update(ctx context.Context, id int64, guest *domain.Guest) error
-	Delete(ctx context.Context, id int64) error
+	Patch(ctx context.Context, id int64, patch GuestPatch) error
+	Delete(ctx context.Context, id int64, version int64) error
+	Restore(ctx context.Context, id int64) error
 }
 
 type TableRepository interface {
 	GetById(ctx context.Context, id int64) (*domain.Table, error)
+	GetAll(ctx context.Context, filter GetTableFilter) ([]*domain.Table, error)
 	GetEmptySeats(ctx context.Context) (int64, error)
 	Create(ctx context.Context, table *domain.Table) (*domain.Table, error)
 	Update(ctx context.Context, id int64, table domain.Table) error
-	Delete(ctx context.Context, id int64) error
+	Patch(ctx context.Context, id int64, patch TablePatch) error
+	Delete(ctx context.Context, id int64, version int64) error
+	Restore(ctx context.Context, id int64) error
 }
 
 type GetGuestListFilter struct {
 	AccompanyingGuests uint16 `json:"accompanying_guests"`
+	// GuestID is the guest to be seated, the overbooking policy holds the seats of the accepted parties back
+	// from the guests who did not accept
+	GuestID int64 `json:"guest_id"`
 }
 
 type GuesListRepository interface {
 	FindAvailableTable(ctx context.Context, filter GetGuestListFilter) (*domain.Table, error)
 	GetOccupiedSeats(ctx context.Context) ([]*domain.Table, error)
 }
+
+type GetDeliveriesFilter struct {
+	WebhookID int64 `json:"webhook_id"`
+	// Limit keeps the most recent deliveries, a zero Limit lists them all
+	Limit int `json:"limit"`
+}
+
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error)
+	GetById(ctx context.Context, id int64) (*domain.Webhook, error)
+	GetAll(ctx context.Context) ([]*domain.Webhook, error)
+	Delete(ctx context.Context, id int64) error
+	// Enqueue stores a pending delivery of event for every webhook subscribed to it and returns
+	// how many were stored, an event already enqueued for a webhook is not enqueued twice
+	Enqueue(ctx context.Context, event domain.Event) (int, error)
+	// GetDueDeliveries returns the oldest pending deliveries whose next attempt is due at now
+	GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*domain.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+	GetDeliveries(ctx context.Context, filter GetDeliveriesFilter) ([]*domain.WebhookDelivery, error)
+}
+
+type OutboxRepository interface {
+	// Add stores events that do not come from a guest or table mutation, the repositories of those
+	// store their events in the transaction of the mutation
+	Add(ctx context.Context, events ...domain.Event) error
+	// GetPending returns the oldest unpublished messages whose next attempt is due at now
+	GetPending(ctx context.Context, now time.Time, limit int) ([]*domain.OutboxMessage, error)
+	Update(ctx context.Context, message *domain.OutboxMessage) error
+}
+
+type CompanionRepository interface {
+	// GetAll lists the companions of the given guests
+	GetAll(ctx context.Context, guestIDs ...int64) ([]*domain.Companion, error)
+	// Create adds a companion to their guest and counts the accompanying guests of the guest again, it fails
+	// with a domain.PartyTooLargeError when the party of the guest would outgrow their table
+	Create(ctx context.Context, companion *domain.Companion) (*domain.Companion, error)
+	// Delete removes a companion of guestID and counts the accompanying guests of the guest again
+	Delete(ctx context.Context, guestID int64, id int64) error
+}
+
+// GuestSearcher finds the guests whose name is close to query, whatever the case, accents and typos,
+// and returns the limit best matches, best first
+type GuestSearcher interface {
+	Search(ctx context.Context, query string, limit int) ([]*domain.GuestMatch, error)
+}
+
+// ReminderRepository keeps track of the reminders sent to the guests, a reminder is told apart by when it was due
+type ReminderRepository interface {
+	// GetPending returns the guests in one of statuses with an email address who were not reminded at dueAt yet,
+	// leaving out the ones whose failed reminder is not due again at now or was attempted maxAttempts times
+	GetPending(ctx context.Context, dueAt time.Time, now time.Time, statuses []string, maxAttempts int, limit int) ([]*domain.Guest, error)
+	// Claim records the attempt to send the reminder of the guest before it is sent and returns how many attempts
+	// were made with it, none when the reminder was already claimed or is not due again
+	Claim(ctx context.Context, guestID int64, dueAt time.Time, now time.Time, maxAttempts int) (int, error)
+	// Fail records that a claimed reminder could not be sent, it is sent again from nextAttemptAt
+	Fail(ctx context.Context, guestID int64, dueAt time.Time, nextAttemptAt time.Time, lastError string) error
+}
@@ -10,9 +10,25 @@ import (
 type GuestService interface {
 	Create(ctx context.Context, g *domain.Guest) (*domain.Guest, error)
 	Update(ctx context.Context, id int64, u *domain.Guest) error
-	Delete(ctx context.Context, id int64) error
+	Patch(ctx context.Context, id int64, patch GuestPatch) error
+	Delete(ctx context.Context, id int64, version int64) error
+	Restore(ctx context.Context, id int64) error
 	GetById(ctx context.Context, id int64) (*domain.Guest, error)
 	GetList(ctx context.Context, filter GetGuestFilter) ([]*domain.Guest, error)
+	// RSVP records the answer of a guest to their invitation and MarkNoShow a guest who was expected
+	// but did not turn up, both return a *domain.TransitionError when the status of the guest forbids it
+	RSVP(ctx context.Context, id int64, rsvp domain.RSVP) (*domain.Guest, error)
+	MarkNoShow(ctx context.Context, id int64) (*domain.Guest, error)
+}
+
+type CompanionService interface {
+	GetList(ctx context.Context, guestID int64) ([]*domain.Companion, error)
+	Add(ctx context.Context, companion *domain.Companion) (*domain.Companion, error)
+	Remove(ctx context.Context, guestID int64, id int64) error
+}
+
+type GuestSearchService interface {
+	Search(ctx context.Context, query string, limit int) ([]*domain.GuestMatch, error)
 }
 
 type GuestListService interface {
@@ -20,10 +36,86 @@ type GuestListService interface {
 	GetOccupiedSeats(ctx context.Context) ([]*domain.Table, error)
 }
 
+// CheckinService seats the guests as they turn up, through the guest list, a ticket or gRPC alike
+type CheckinService interface {
+	// CheckIn marks the guest as arrived with their accompanying guests and seats them at an available table,
+	// it returns a *domain.CheckedInError when the guest is seated already or left
+	CheckIn(ctx context.Context, guestID int64, accompanyingGuests uint16) (*domain.Table, error)
+}
+
 type TableService interface {
 	GetById(ctx context.Context, id int64) (*domain.Table, error)
+	GetList(ctx context.Context, filter GetTableFilter) ([]*domain.Table, error)
 	GetEmptySeats(ctx context.Context) (int64, error)
 	Create(ctx context.Context, table *domain.Table) (*domain.Table, error)
 	Update(ctx context.Context, id int64, table domain.Table) error
+	Patch(ctx context.Context, id int64, patch TablePatch) error
+	Delete(ctx context.Context, id int64, version int64) error
+	Restore(ctx context.Context, id int64) error
+}
+
+type WebhookService interface {
+	Register(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error)
+	GetById(ctx context.Context, id int64) (*domain.Webhook, error)
+	GetList(ctx context.Context) ([]*domain.Webhook, error)
 	Delete(ctx context.Context, id int64) error
+	GetDeliveries(ctx context.Context, filter GetDeliveriesFilter) ([]*domain.WebhookDelivery, error)
+	// Publish queues event for delivery to the webhooks subscribed to it
+	Publish(ctx context.Context, event domain.Event) error
+	// DeliverDue attempts the deliveries that are due and returns how many were attempted
+	DeliverDue(ctx context.Context) (int, error)
+}
+
+// WebhookSender posts a delivery to its webhook and returns the HTTP status of the response,
+// Check tells why a webhook url cannot be delivered to
+type WebhookSender interface {
+	Send(ctx context.Context, webhook *domain.Webhook, delivery *domain.WebhookDelivery) (int, error)
+	Check(ctx context.Context, url string) error
+}
+
+type OutboxService interface {
+	// Publish stores event in the outbox, it is handed to the sinks by Dispatch
+	Publish(ctx context.Context, event domain.Event) error
+	// Dispatch publishes the pending messages to every sink and returns how many were attempted
+	Dispatch(ctx context.Context) (int, error)
+}
+
+// EventSink receives the events of the outbox, an event is published at least once so sinks
+// deduplicate them by id
+type EventSink interface {
+	Name() string
+	Publish(ctx context.Context, event domain.Event) error
+}
+
+// TicketService issues the signed tokens encoded in the tickets of the guests and checks the scanned ones
+type TicketService interface {
+	Issue(guestID int64) string
+	// Verify returns the guest the token was issued to, it fails for tokens the service did not issue
+	Verify(token string) (int64, error)
+}
+
+type ReportService interface {
+	Dietary(ctx context.Context) (*domain.DietaryReport, error)
+	Capacity(ctx context.Context) (*domain.CapacityReport, error)
+	Forecast(ctx context.Context) (*domain.Forecast, error)
+	// LatestForecast is the forecast of the guests and tables as last written, for the checks admitting a guest
+	LatestForecast(ctx context.Context) (*domain.Forecast, error)
+}
+
+type MailService interface {
+	// Send renders the email of kind for the guest and sends it right away
+	Send(ctx context.Context, guestID int64, kind string) error
+	// SendDueReminders reminds the guests who did not answer for sure of the event once the latest reminder
+	// before it is due, and returns how many reminders were attempted
+	SendDueReminders(ctx context.Context) (int, error)
+}
+
+// Mailer delivers rendered emails, over SMTP or to files for development
+type Mailer interface {
+	Send(ctx context.Context, email domain.Email) error
+}
+
+// MailTemplates renders the subject and the bodies of an email of kind, the recipient is left to the caller
+type MailTemplates interface {
+	Render(kind string, data domain.EmailData) (*domain.Email, error)
 }
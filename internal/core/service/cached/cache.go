@@ -0,0 +1,73 @@
+package cached
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+
+	"github.com/eazygood/getground-app/internal/infrastructure/cache"
+	infra "github.com/eazygood/getground-app/internal/infrastructure/db"
+	"github.com/eazygood/getground-app/internal/infrastructure/log"
+	"github.com/eazygood/getground-app/internal/infrastructure/metrics"
+)
+
+// cache keys of the aggregate reads, mutations invalidate the keys they can change
+const (
+	emptySeatsKey    = "empty_seats"
+	occupiedSeatsKey = "occupied_seats"
+)
+
+// load answers from the store when it holds key, otherwise it calls fetch and stores its result.
+// Values are gob encoded: unlike JSON it keeps the guests preloaded on tables, and every hit hands
+// out a fresh copy the caller is free to modify.
+// A failing store is logged and bypassed, the cache never fails a read. The value is fetched from the
+// primary: a replica lagging behind the mutation that just invalidated key would store the stale value
+// again, until the next mutation or the TTL.
+func load[T any](ctx context.Context, store cache.Store, key string, fetch func(context.Context) (T, error)) (T, error) {
+	value, ok, err := store.Get(ctx, key)
+	if err != nil {
+		log.FromContext(ctx).WithField("key", key).Warnf("failed to read cache: %v", err)
+	}
+
+	if ok {
+		var cachedValue T
+		if err := gob.NewDecoder(bytes.NewReader(value)).Decode(&cachedValue); err == nil {
+			metrics.ObserveCache(key, true)
+			return cachedValue, nil
+		}
+
+		log.FromContext(ctx).WithField("key", key).Warnf("failed to decode cached value: %v", err)
+	}
+
+	metrics.ObserveCache(key, false)
+
+	result, err := fetch(infra.WithPrimaryReads(ctx))
+	if err != nil {
+		return result, err
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(result); err != nil {
+		log.FromContext(ctx).WithField("key", key).Warnf("failed to encode value to cache: %v", err)
+		return result, nil
+	}
+
+	if err := store.Set(ctx, key, buf.Bytes()); err != nil {
+		log.FromContext(ctx).WithField("key", key).Warnf("failed to write cache: %v", err)
+	}
+
+	return result, nil
+}
+
+// invalidate drops keys once a mutation succeeded, a failed mutation changed nothing
+func invalidate(ctx context.Context, store cache.Store, err error, keys ...string) error {
+	if err != nil {
+		return err
+	}
+
+	if err := store.Delete(ctx, keys...); err != nil {
+		log.FromContext(ctx).WithField("keys", keys).Errorf("failed to invalidate cache: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package cached
+
+import (
+	"context"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	"github.com/eazygood/getground-app/internal/infrastructure/cache"
+)
+
+type CompanionService struct {
+	next  port.CompanionService
+	store cache.Store
+}
+
+// NewCompanionService invalidates the occupied seats, they embed the seated guests whose accompanying
+// guests are counted from their companions
+func NewCompanionService(next port.CompanionService, store cache.Store) port.CompanionService {
+	return &CompanionService{next: next, store: store}
+}
+
+func (s *CompanionService) GetList(ctx context.Context, guestID int64) ([]*domain.Companion, error) {
+	return s.next.GetList(ctx, guestID)
+}
+
+func (s *CompanionService) Add(ctx context.Context, c *domain.Companion) (*domain.Companion, error) {
+	companion, err := s.next.Add(ctx, c)
+
+	return companion, invalidate(ctx, s.store, err, occupiedSeatsKey)
+}
+
+func (s *CompanionService) Remove(ctx context.Context, guestID int64, id int64) error {
+	return invalidate(ctx, s.store, s.next.Remove(ctx, guestID, id), occupiedSeatsKey)
+}
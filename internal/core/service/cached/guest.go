@@ -0,0 +1,57 @@
+package cached
+
+import (
+	"context"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	"github.com/eazygood/getground-app/internal/infrastructure/cache"
+)
+
+type GuestService struct {
+	next  port.GuestService
+	store cache.Store
+}
+
+// NewGuestService caches nothing itself, it invalidates the seat aggregates the guest mutations change:
+// the occupied seats embed the seated guests, and deleting a guest frees their table
+func NewGuestService(next port.GuestService, store cache.Store) port.GuestService {
+	return &GuestService{next: next, store: store}
+}
+
+func (s *GuestService) Create(ctx context.Context, g *domain.Guest) (*domain.Guest, error) {
+	return s.next.Create(ctx, g)
+}
+
+func (s *GuestService) Update(ctx context.Context, id int64, u *domain.Guest) error {
+	return invalidate(ctx, s.store, s.next.Update(ctx, id, u), occupiedSeatsKey)
+}
+
+func (s *GuestService) Patch(ctx context.Context, id int64, patch port.GuestPatch) error {
+	return invalidate(ctx, s.store, s.next.Patch(ctx, id, patch), occupiedSeatsKey)
+}
+
+func (s *GuestService) Delete(ctx context.Context, id int64, version int64) error {
+	return invalidate(ctx, s.store, s.next.Delete(ctx, id, version), emptySeatsKey, occupiedSeatsKey)
+}
+
+func (s *GuestService) Restore(ctx context.Context, id int64) error {
+	return s.next.Restore(ctx, id)
+}
+
+func (s *GuestService) GetById(ctx context.Context, id int64) (*domain.Guest, error) {
+	return s.next.GetById(ctx, id)
+}
+
+func (s *GuestService) GetList(ctx context.Context, filter port.GetGuestFilter) ([]*domain.Guest, error) {
+	return s.next.GetList(ctx, filter)
+}
+
+// RSVP and MarkNoShow leave the seats alone, the guests who are seated cannot answer anymore
+func (s *GuestService) RSVP(ctx context.Context, id int64, rsvp domain.RSVP) (*domain.Guest, error) {
+	return s.next.RSVP(ctx, id, rsvp)
+}
+
+func (s *GuestService) MarkNoShow(ctx context.Context, id int64) (*domain.Guest, error) {
+	return s.next.MarkNoShow(ctx, id)
+}
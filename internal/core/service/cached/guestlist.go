@@ -0,0 +1,35 @@
+package cached
+
+import (
+	"context"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	"github.com/eazygood/getground-app/internal/infrastructure/cache"
+)
+
+type GuestListService struct {
+	next  port.GuestListService
+	store cache.Store
+}
+
+// NewGuestListService caches the occupied seats. The available table is never cached,
+// it must be free at the time the guest is seated.
+func NewGuestListService(next port.GuestListService, store cache.Store) port.GuestListService {
+	return &GuestListService{next: next, store: store}
+}
+
+func (s *GuestListService) FindAvailableTable(ctx context.Context, filter port.GetGuestListFilter) (*domain.Table, error) {
+	return s.next.FindAvailableTable(ctx, filter)
+}
+
+func (s *GuestListService) GetOccupiedSeats(ctx context.Context) ([]*domain.Table, error) {
+	tables, err := load(ctx, s.store, occupiedSeatsKey, s.next.GetOccupiedSeats)
+
+	// gob decodes an empty list as nil, keep rendering it as [] rather than null
+	if err == nil && tables == nil {
+		tables = []*domain.Table{}
+	}
+
+	return tables, err
+}
@@ -0,0 +1,94 @@
+package cached
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	"github.com/eazygood/getground-app/internal/infrastructure/cache"
+	ports "github.com/eazygood/getground-app/mocks/core/port"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type GuestListServiceSuite struct {
+	suite.Suite
+	*require.Assertions
+	ctrl                 *gomock.Controller
+	mockGuestListService *ports.MockGuestListService
+	mockGuestService     *ports.MockGuestService
+	guestListService     port.GuestListService
+	guestService         port.GuestService
+}
+
+func TestGuestListServiceSuite(t *testing.T) {
+	suite.Run(t, new(GuestListServiceSuite))
+}
+
+func (s *GuestListServiceSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.ctrl = gomock.NewController(s.T())
+	s.mockGuestListService = ports.NewMockGuestListService(s.ctrl)
+	s.mockGuestService = ports.NewMockGuestService(s.ctrl)
+
+	store := cache.NewLRU(10, 0)
+	s.guestListService = NewGuestListService(s.mockGuestListService, store)
+	s.guestService = NewGuestService(s.mockGuestService, store)
+}
+
+func (s *GuestListServiceSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *GuestListServiceSuite) TestGetOccupiedSeatsKeepsGuests() {
+	ctx := context.Background()
+	guestID := int64(3)
+	tables := []*domain.Table{{ID: 1, Seats: 4, GuestID: &guestID, Version: 2, Guest: domain.Guest{ID: 3, Name: "John", AccompanyingGuests: 2}}}
+
+	s.mockGuestListService.EXPECT().GetOccupiedSeats(gomock.Any()).Return(tables, nil).Times(1)
+
+	_, err := s.guestListService.GetOccupiedSeats(ctx)
+	s.NoError(err)
+
+	cachedTables, err := s.guestListService.GetOccupiedSeats(ctx)
+	s.NoError(err)
+	s.Equal(tables, cachedTables)
+}
+
+func (s *GuestListServiceSuite) TestGuestUpdateInvalidatesOccupiedSeats() {
+	ctx := context.Background()
+	guest := &domain.Guest{Name: "John", Version: 1}
+
+	gomock.InOrder(
+		s.mockGuestListService.EXPECT().GetOccupiedSeats(gomock.Any()).Return([]*domain.Table{}, nil),
+		s.mockGuestService.EXPECT().Update(ctx, int64(3), guest).Return(nil),
+		s.mockGuestListService.EXPECT().GetOccupiedSeats(gomock.Any()).Return([]*domain.Table{}, nil),
+	)
+
+	_, err := s.guestListService.GetOccupiedSeats(ctx)
+	s.NoError(err)
+
+	s.NoError(s.guestService.Update(ctx, 3, guest))
+
+	tables, err := s.guestListService.GetOccupiedSeats(ctx)
+	s.NoError(err)
+	s.NotNil(tables)
+
+	tables, err = s.guestListService.GetOccupiedSeats(ctx)
+	s.NoError(err)
+	s.NotNil(tables)
+}
+
+func (s *GuestListServiceSuite) TestFindAvailableTableIsNotCached() {
+	ctx := context.Background()
+	filter := port.GetGuestListFilter{AccompanyingGuests: 2}
+
+	s.mockGuestListService.EXPECT().FindAvailableTable(ctx, filter).Return(&domain.Table{ID: 1}, nil).Times(2)
+
+	for i := 0; i < 2; i++ {
+		_, err := s.guestListService.FindAvailableTable(ctx, filter)
+		s.NoError(err)
+	}
+}
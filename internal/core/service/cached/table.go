@@ -0,0 +1,53 @@
+package cached
+
+import (
+	"context"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	"github.com/eazygood/getground-app/internal/infrastructure/cache"
+)
+
+type TableService struct {
+	next  port.TableService
+	store cache.Store
+}
+
+// NewTableService caches the empty seat count, every table mutation invalidates the seat aggregates
+func NewTableService(next port.TableService, store cache.Store) port.TableService {
+	return &TableService{next: next, store: store}
+}
+
+func (s *TableService) GetById(ctx context.Context, id int64) (*domain.Table, error) {
+	return s.next.GetById(ctx, id)
+}
+
+func (s *TableService) GetList(ctx context.Context, filter port.GetTableFilter) ([]*domain.Table, error) {
+	return s.next.GetList(ctx, filter)
+}
+
+func (s *TableService) GetEmptySeats(ctx context.Context) (int64, error) {
+	return load(ctx, s.store, emptySeatsKey, s.next.GetEmptySeats)
+}
+
+func (s *TableService) Create(ctx context.Context, t *domain.Table) (*domain.Table, error) {
+	table, err := s.next.Create(ctx, t)
+
+	return table, invalidate(ctx, s.store, err, emptySeatsKey, occupiedSeatsKey)
+}
+
+func (s *TableService) Update(ctx context.Context, id int64, table domain.Table) error {
+	return invalidate(ctx, s.store, s.next.Update(ctx, id, table), emptySeatsKey, occupiedSeatsKey)
+}
+
+func (s *TableService) Patch(ctx context.Context, id int64, patch port.TablePatch) error {
+	return invalidate(ctx, s.store, s.next.Patch(ctx, id, patch), emptySeatsKey, occupiedSeatsKey)
+}
+
+func (s *TableService) Delete(ctx context.Context, id int64, version int64) error {
+	return invalidate(ctx, s.store, s.next.Delete(ctx, id, version), emptySeatsKey, occupiedSeatsKey)
+}
+
+func (s *TableService) Restore(ctx context.Context, id int64) error {
+	return invalidate(ctx, s.store, s.next.Restore(ctx, id), emptySeatsKey, occupiedSeatsKey)
+}
@@ -0,0 +1,102 @@
+package cached
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	"github.com/eazygood/getground-app/internal/infrastructure/cache"
+	ports "github.com/eazygood/getground-app/mocks/core/port"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type TableServiceSuite struct {
+	suite.Suite
+	*require.Assertions
+	ctrl             *gomock.Controller
+	mockTableService *ports.MockTableService
+	store            cache.Store
+	tableService     port.TableService
+}
+
+func TestTableServiceSuite(t *testing.T) {
+	suite.Run(t, new(TableServiceSuite))
+}
+
+func (s *TableServiceSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.ctrl = gomock.NewController(s.T())
+	s.mockTableService = ports.NewMockTableService(s.ctrl)
+	s.store = cache.NewLRU(10, 0)
+	s.tableService = NewTableService(s.mockTableService, s.store)
+}
+
+func (s *TableServiceSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *TableServiceSuite) TestGetEmptySeatsIsCached() {
+	ctx := context.Background()
+	s.mockTableService.EXPECT().GetEmptySeats(gomock.Any()).Return(int64(12), nil).Times(1)
+
+	for i := 0; i < 3; i++ {
+		count, err := s.tableService.GetEmptySeats(ctx)
+		s.NoError(err)
+		s.Equal(int64(12), count)
+	}
+}
+
+func (s *TableServiceSuite) TestGetEmptySeatsErrorIsNotCached() {
+	ctx := context.Background()
+	gomock.InOrder(
+		s.mockTableService.EXPECT().GetEmptySeats(gomock.Any()).Return(int64(0), errors.New("db error")),
+		s.mockTableService.EXPECT().GetEmptySeats(gomock.Any()).Return(int64(12), nil),
+	)
+
+	_, err := s.tableService.GetEmptySeats(ctx)
+	s.Error(err)
+
+	count, err := s.tableService.GetEmptySeats(ctx)
+	s.NoError(err)
+	s.Equal(int64(12), count)
+}
+
+func (s *TableServiceSuite) TestUpdateInvalidatesSeats() {
+	ctx := context.Background()
+	table := domain.Table{Seats: 4, Version: 1}
+
+	gomock.InOrder(
+		s.mockTableService.EXPECT().GetEmptySeats(gomock.Any()).Return(int64(12), nil),
+		s.mockTableService.EXPECT().Update(ctx, int64(1), table).Return(nil),
+		s.mockTableService.EXPECT().GetEmptySeats(gomock.Any()).Return(int64(8), nil),
+	)
+
+	_, err := s.tableService.GetEmptySeats(ctx)
+	s.NoError(err)
+
+	s.NoError(s.tableService.Update(ctx, 1, table))
+
+	count, err := s.tableService.GetEmptySeats(ctx)
+	s.NoError(err)
+	s.Equal(int64(8), count)
+}
+
+func (s *TableServiceSuite) TestFailedMutationKeepsCache() {
+	ctx := context.Background()
+
+	s.mockTableService.EXPECT().GetEmptySeats(gomock.Any()).Return(int64(12), nil).Times(1)
+	s.mockTableService.EXPECT().Delete(ctx, int64(1), int64(2)).Return(errors.New("conflict"))
+
+	_, err := s.tableService.GetEmptySeats(ctx)
+	s.NoError(err)
+
+	s.Error(s.tableService.Delete(ctx, 1, 2))
+
+	count, err := s.tableService.GetEmptySeats(ctx)
+	s.NoError(err)
+	s.Equal(int64(12), count)
+}
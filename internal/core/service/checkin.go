@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	"github.com/eazygood/getground-app/internal/infrastructure/log"
+)
+
+type CheckinService struct {
+	guests    port.GuestService
+	tables    port.TableService
+	guestList port.GuestListService
+}
+
+// NewCheckinService seats the guests through the port services, so that every layer wrapping them, such
+// as the overbooking policy or the occupancy events, sees a check-in the way it sees any other change
+func NewCheckinService(guests port.GuestService, tables port.TableService, guestList port.GuestListService) port.CheckinService {
+	return &CheckinService{
+		guests:    guests,
+		tables:    tables,
+		guestList: guestList,
+	}
+}
+
+func (s *CheckinService) CheckIn(ctx context.Context, guestID int64, accompanyingGuests uint16) (*domain.Table, error) {
+	// the guests who left are looked up as well, to tell a replayed check-in from an unknown guest,
+	// and as last written since their version is the one the check-in writes with
+	guests, err := s.guests.GetList(ctx, port.GetGuestFilter{IDs: []int64{guestID}, IncludeDeleted: true, Latest: true})
+	if err != nil {
+		return nil, fmt.Errorf("check in guest: %w", err)
+	}
+
+	if len(guests) == 0 {
+		return nil, fmt.Errorf("check in guest: %w by id: %v", domain.ErrNotFound, guestID)
+	}
+
+	guest := guests[0]
+
+	if guest.DeletedAt.Valid || guest.IsArrived {
+		return nil, fmt.Errorf("check in guest: %w", &domain.CheckedInError{GuestID: guest.ID, Left: guest.DeletedAt.Valid})
+	}
+
+	table, err := s.guestList.FindAvailableTable(ctx, port.GetGuestListFilter{
+		AccompanyingGuests: accompanyingGuests,
+		GuestID:            guest.ID,
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("check in guest: %w", err)
+	}
+
+	// the table is taken first: a guest racing another one for it is turned down before they arrive,
+	// and can check in again with another table
+	err = s.tables.Update(ctx, table.ID, domain.Table{
+		GuestID: &guest.ID,
+		Version: table.Version,
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("check in guest: %w", err)
+	}
+
+	err = s.guests.Update(ctx, guest.ID, &domain.Guest{
+		AccompanyingGuests: accompanyingGuests,
+		IsArrived:          true,
+		Version:            guest.Version,
+	})
+
+	if err != nil {
+		s.free(ctx, table.ID, table.Version+1)
+		return nil, fmt.Errorf("check in guest: %w", err)
+	}
+
+	guest.AccompanyingGuests = accompanyingGuests
+	guest.IsArrived = true
+
+	table.GuestID = &guest.ID
+	table.Guest = *guest
+	table.Version++
+
+	return table, nil
+}
+
+// free gives back the table of a guest who could not arrive, a table that cannot be freed is left to the staff
+func (s *CheckinService) free(ctx context.Context, tableID int64, version int64) {
+	err := s.tables.Patch(ctx, tableID, port.TablePatch{Table: domain.Table{Version: version}, Fields: []string{"guest_id"}})
+	if err != nil {
+		log.FromContext(ctx).WithError(err).WithField("table_id", tableID).Error("failed to free table of a guest who did not check in")
+	}
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	apperrors "github.com/eazygood/getground-app/internal/errors"
+	ports "github.com/eazygood/getground-app/mocks/core/port"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestCheckIn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	guests := ports.NewMockGuestService(ctrl)
+	tables := ports.NewMockTableService(ctrl)
+	guestList := ports.NewMockGuestListService(ctrl)
+	ctx := context.Background()
+
+	guests.EXPECT().GetList(ctx, port.GetGuestFilter{IDs: []int64{1}, IncludeDeleted: true, Latest: true}).
+		Return([]*domain.Guest{{ID: 1, Name: "Simon", Version: 4}}, nil).Times(1)
+	guestList.EXPECT().FindAvailableTable(ctx, port.GetGuestListFilter{AccompanyingGuests: 2, GuestID: 1}).
+		Return(&domain.Table{ID: 3, Seats: 4, Version: 1}, nil).Times(1)
+	gomock.InOrder(
+		tables.EXPECT().Update(ctx, int64(3), domain.Table{GuestID: &[]int64{1}[0], Version: 1}).Return(nil).Times(1),
+		guests.EXPECT().Update(ctx, int64(1), &domain.Guest{AccompanyingGuests: 2, IsArrived: true, Version: 4}).Return(nil).Times(1),
+	)
+
+	table, err := NewCheckinService(guests, tables, guestList).CheckIn(ctx, 1, 2)
+
+	require.NoError(t, err)
+	require.EqualValues(t, 3, table.ID)
+	require.EqualValues(t, 1, *table.GuestID)
+	require.EqualValues(t, 2, table.Version)
+	require.Equal(t, "Simon", table.Guest.Name)
+	require.EqualValues(t, 2, table.Guest.AccompanyingGuests)
+}
+
+func TestCheckInRacingForTheTable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	guests := ports.NewMockGuestService(ctrl)
+	tables := ports.NewMockTableService(ctrl)
+	guestList := ports.NewMockGuestListService(ctrl)
+	ctx := context.Background()
+
+	guests.EXPECT().GetList(ctx, gomock.Any()).Return([]*domain.Guest{{ID: 1, Version: 4}}, nil).Times(1)
+	guestList.EXPECT().FindAvailableTable(ctx, gomock.Any()).Return(&domain.Table{ID: 3, Seats: 4, Version: 1}, nil).Times(1)
+	// taken by another guest meanwhile, the guest does not arrive
+	tables.EXPECT().Update(ctx, int64(3), gomock.Any()).Return(apperrors.NewConflictError("table", 3, 1)).Times(1)
+
+	_, err := NewCheckinService(guests, tables, guestList).CheckIn(ctx, 1, 2)
+
+	var conflict *apperrors.ConflictError
+	require.ErrorAs(t, err, &conflict)
+}
+
+func TestCheckInFreesTheTableWhenTheGuestCannotArrive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	guests := ports.NewMockGuestService(ctrl)
+	tables := ports.NewMockTableService(ctrl)
+	guestList := ports.NewMockGuestListService(ctrl)
+	ctx := context.Background()
+
+	guests.EXPECT().GetList(ctx, gomock.Any()).Return([]*domain.Guest{{ID: 1, Version: 4}}, nil).Times(1)
+	guestList.EXPECT().FindAvailableTable(ctx, gomock.Any()).Return(&domain.Table{ID: 3, Seats: 4, Version: 1}, nil).Times(1)
+	tables.EXPECT().Update(ctx, int64(3), gomock.Any()).Return(nil).Times(1)
+	// checked in through another table meanwhile
+	guests.EXPECT().Update(ctx, int64(1), gomock.Any()).Return(apperrors.NewConflictError("guest", 1, 4)).Times(1)
+	tables.EXPECT().Patch(ctx, int64(3), port.TablePatch{Table: domain.Table{Version: 2}, Fields: []string{"guest_id"}}).Return(nil).Times(1)
+
+	_, err := NewCheckinService(guests, tables, guestList).CheckIn(ctx, 1, 2)
+
+	var conflict *apperrors.ConflictError
+	require.ErrorAs(t, err, &conflict)
+}
+
+func TestCheckInAgain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	guests := ports.NewMockGuestService(ctrl)
+	service := NewCheckinService(guests, ports.NewMockTableService(ctrl), ports.NewMockGuestListService(ctrl))
+	ctx := context.Background()
+
+	guests.EXPECT().GetList(ctx, port.GetGuestFilter{IDs: []int64{1}, IncludeDeleted: true, Latest: true}).
+		Return([]*domain.Guest{{ID: 1, IsArrived: true}}, nil).Times(1)
+
+	_, err := service.CheckIn(ctx, 1, 0)
+
+	var checkedIn *domain.CheckedInError
+	require.ErrorAs(t, err, &checkedIn)
+	require.EqualError(t, err, "check in guest: guest 1 already has seats")
+
+	guests.EXPECT().GetList(ctx, port.GetGuestFilter{IDs: []int64{2}, IncludeDeleted: true, Latest: true}).
+		Return([]*domain.Guest{{ID: 2, IsArrived: true, DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true}}}, nil).Times(1)
+
+	_, err = service.CheckIn(ctx, 2, 0)
+
+	require.ErrorAs(t, err, &checkedIn)
+	require.True(t, checkedIn.Left)
+	require.EqualError(t, err, "check in guest: guest 2 already left")
+}
+
+func TestCheckInUnknownGuest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	guests := ports.NewMockGuestService(ctrl)
+	ctx := context.Background()
+
+	guests.EXPECT().GetList(ctx, port.GetGuestFilter{IDs: []int64{9}, IncludeDeleted: true, Latest: true}).Return(nil, nil).Times(1)
+
+	_, err := NewCheckinService(guests, ports.NewMockTableService(ctrl), ports.NewMockGuestListService(ctrl)).CheckIn(ctx, 9, 0)
+
+	require.EqualError(t, err, "check in guest: record not found by id: 9")
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+)
+
+type CompanionService struct {
+	repository port.CompanionRepository
+}
+
+func NewCompanionService(repository port.CompanionRepository) port.CompanionService {
+	return &CompanionService{
+		repository: repository,
+	}
+}
+
+func (c *CompanionService) GetList(ctx context.Context, guestID int64) ([]*domain.Companion, error) {
+	companions, err := c.repository.GetAll(ctx, guestID)
+	if err != nil {
+		return nil, fmt.Errorf("get companions: %w", err)
+	}
+
+	return companions, nil
+}
+
+func (c *CompanionService) Add(ctx context.Context, companion *domain.Companion) (*domain.Companion, error) {
+	created, err := c.repository.Create(ctx, companion)
+	if err != nil {
+		return nil, fmt.Errorf("add companion: %w", err)
+	}
+
+	return created, nil
+}
+
+func (c *CompanionService) Remove(ctx context.Context, guestID int64, id int64) error {
+	if err := c.repository.Delete(ctx, guestID, id); err != nil {
+		return fmt.Errorf("remove companion: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	ports "github.com/eazygood/getground-app/mocks/core/port"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompanionService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repository := ports.NewMockCompanionRepository(ctrl)
+	service := NewCompanionService(repository)
+	ctx := context.Background()
+
+	carl := &domain.Companion{GuestID: 4, Name: "Carl"}
+	repository.EXPECT().Create(ctx, carl).Return(&domain.Companion{ID: 7, GuestID: 4, Name: "Carl"}, nil).Times(1)
+
+	created, err := service.Add(ctx, carl)
+	require.NoError(t, err)
+	require.EqualValues(t, 7, created.ID)
+
+	repository.EXPECT().GetAll(ctx, int64(4)).Return([]*domain.Companion{created}, nil).Times(1)
+
+	companions, err := service.GetList(ctx, 4)
+	require.NoError(t, err)
+	require.Equal(t, []*domain.Companion{created}, companions)
+
+	repository.EXPECT().Delete(ctx, int64(4), int64(7)).Return(errors.New("record not found by id: 7")).Times(1)
+
+	require.EqualError(t, service.Remove(ctx, 4, 7), "remove companion: record not found by id: 7")
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/eazygood/getground-app/internal/core/domain"
 	"github.com/eazygood/getground-app/internal/core/port"
+	apperrors "github.com/eazygood/getground-app/internal/errors"
+	"github.com/eazygood/getground-app/internal/venue"
 )
 
 type GuestService struct {
@@ -25,11 +27,29 @@ func (srv *GuestService) Create(ctx context.Context, guest *domain.Guest) (*doma
 	return guest, nil
 }
 
-func (srv *GuestService) Delete(ctx context.Context, id int64) error {
-	if err := srv.repository.Delete(ctx, id); err != nil {
+// Delete removes the guest, who leaves the event
+func (srv *GuestService) Delete(ctx context.Context, id int64, version int64) error {
+	guest, err := srv.current(ctx, id, version)
+	if err != nil {
 		return fmt.Errorf("delete guest: %w", err)
 	}
 
+	if !domain.CanTransition(guest.Status, domain.GuestStatusLeft) {
+		return fmt.Errorf("delete guest: %w", &domain.TransitionError{GuestID: id, From: guest.Status, To: domain.GuestStatusLeft})
+	}
+
+	if err := srv.repository.Delete(ctx, id, version); err != nil {
+		return fmt.Errorf("delete guest: %w", err)
+	}
+
+	return nil
+}
+
+func (srv *GuestService) Restore(ctx context.Context, id int64) error {
+	if err := srv.repository.Restore(ctx, id); err != nil {
+		return fmt.Errorf("restore guest: %w", err)
+	}
+
 	return nil
 }
 
@@ -51,10 +71,145 @@ func (srv *GuestService) GetList(ctx context.Context, filter port.GetGuestFilter
 	return guests, nil
 }
 
+// Update replaces the guest, a guest who is not arrived is left as they are since the update
+// writes the non-zero members only
 func (srv *GuestService) Update(ctx context.Context, id int64, guest *domain.Guest) error {
+	current, err := srv.current(ctx, id, guest.Version)
+	if err != nil {
+		return fmt.Errorf("update guest: %w", err)
+	}
+
+	if guest.IsArrived {
+		if _, err := arrive(current, guest); err != nil {
+			return fmt.Errorf("update guest: %w", err)
+		}
+	}
+
 	if err := srv.repository.Update(ctx, id, guest); err != nil {
 		return fmt.Errorf("update guest: %w", err)
 	}
 
 	return nil
 }
+
+// Patch writes the fields of the patch, arriving moves the guest to arrived while an arrived guest
+// cannot be taken back as they can only leave
+func (srv *GuestService) Patch(ctx context.Context, id int64, patch port.GuestPatch) error {
+	if patch.Has("is_arrived") {
+		current, err := srv.current(ctx, id, patch.Guest.Version)
+		if err != nil {
+			return fmt.Errorf("patch guest: %w", err)
+		}
+
+		if !patch.Guest.IsArrived && current.Status == domain.GuestStatusArrived {
+			return fmt.Errorf("patch guest: %w", &domain.TransitionError{GuestID: id, From: current.Status, To: domain.GuestStatusInvited})
+		}
+
+		if patch.Guest.IsArrived {
+			arrived, err := arrive(current, &patch.Guest)
+			if err != nil {
+				return fmt.Errorf("patch guest: %w", err)
+			}
+
+			if arrived && !patch.Has("time_arrived") {
+				patch.Fields = append(patch.Fields, "time_arrived")
+			}
+
+			if arrived {
+				patch.Fields = append(patch.Fields, "status")
+			}
+		}
+	}
+
+	if err := srv.repository.Patch(ctx, id, patch); err != nil {
+		return fmt.Errorf("patch guest: %w", err)
+	}
+
+	return nil
+}
+
+// RSVP moves the guest to the status of their answer, the change goes through the versioned patch of the
+// guest so an answer racing another change of the guest fails with a conflict instead of overwriting it
+func (srv *GuestService) RSVP(ctx context.Context, id int64, rsvp domain.RSVP) (*domain.Guest, error) {
+	if rsvp.Status == domain.GuestStatusDeclined {
+		rsvp.PartySize = 0
+	}
+
+	now := venue.Now()
+
+	guest, err := srv.transition(ctx, id, domain.Guest{Status: rsvp.Status, PartySize: rsvp.PartySize, RespondedAt: &now},
+		"status", "party_size", "responded_at")
+
+	if err != nil {
+		return nil, fmt.Errorf("rsvp guest: %w", err)
+	}
+
+	return guest, nil
+}
+
+func (srv *GuestService) MarkNoShow(ctx context.Context, id int64) (*domain.Guest, error) {
+	guest, err := srv.transition(ctx, id, domain.Guest{Status: domain.GuestStatusNoShow}, "status")
+	if err != nil {
+		return nil, fmt.Errorf("mark guest as no-show: %w", err)
+	}
+
+	return guest, nil
+}
+
+// current reads the guest a change holding version is made to, the status the change is checked against
+// is then the one the versioned write finds
+func (srv *GuestService) current(ctx context.Context, id int64, version int64) (*domain.Guest, error) {
+	guest, err := srv.repository.GetLatestById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if guest.Version != version {
+		return nil, apperrors.NewConflictError("guest", id, version)
+	}
+
+	return guest, nil
+}
+
+// arrive moves next to arrived when current is not there yet and stamps their arrival unless it is given,
+// it tells whether the status changes
+func arrive(current *domain.Guest, next *domain.Guest) (bool, error) {
+	if current.Status == domain.GuestStatusArrived {
+		if next.TimeArrived == nil {
+			next.TimeArrived = current.TimeArrived
+		}
+
+		return false, nil
+	}
+
+	if !domain.CanTransition(current.Status, domain.GuestStatusArrived) {
+		return false, &domain.TransitionError{GuestID: current.ID, From: current.Status, To: domain.GuestStatusArrived}
+	}
+
+	next.Status = domain.GuestStatusArrived
+	if next.TimeArrived == nil {
+		t := venue.Now()
+		next.TimeArrived = &t
+	}
+
+	return true, nil
+}
+
+// transition writes fields of next to the guest once the state machine allows their move to next.Status
+func (srv *GuestService) transition(ctx context.Context, id int64, next domain.Guest, fields ...string) (*domain.Guest, error) {
+	guest, err := srv.repository.GetLatestById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !domain.CanTransition(guest.Status, next.Status) {
+		return nil, &domain.TransitionError{GuestID: id, From: guest.Status, To: next.Status}
+	}
+
+	next.Version = guest.Version
+	if err := srv.repository.Patch(ctx, id, port.GuestPatch{Guest: next, Fields: fields}); err != nil {
+		return nil, err
+	}
+
+	return srv.repository.GetLatestById(ctx, id)
+}
@@ -1,12 +1,15 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/eazygood/getground-app/internal/core/domain"
 	"github.com/eazygood/getground-app/internal/core/port"
+	apperrors "github.com/eazygood/getground-app/internal/errors"
 	mockPort "github.com/eazygood/getground-app/mocks/core/port"
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
@@ -86,9 +89,10 @@ func (g *GuestServiceSuite) TestGuestDelete() {
 	gin.SetMode(gin.TestMode)
 	c, _ := gin.CreateTestContext(httptest.NewRecorder())
 
-	g.mockGuestRepository.EXPECT().Delete(c, int64(1)).Return(nil).Times(1)
+	g.mockGuestRepository.EXPECT().GetLatestById(c, int64(1)).Return(&domain.Guest{ID: 1, Status: domain.GuestStatusArrived, Version: 1}, nil).Times(1)
+	g.mockGuestRepository.EXPECT().Delete(c, int64(1), int64(1)).Return(nil).Times(1)
 
-	err := g.guestService.Delete(c, int64(1))
+	err := g.guestService.Delete(c, int64(1), int64(1))
 
 	g.NoError(err)
 }
@@ -179,9 +183,162 @@ func (g *GuestServiceSuite) TestGuestUpdate() {
 		TimeArrived:        nil,
 	}
 
+	g.mockGuestRepository.EXPECT().GetLatestById(c, int64(1)).Return(&domain.Guest{ID: 1, Status: domain.GuestStatusAccepted}, nil).Times(1)
 	g.mockGuestRepository.EXPECT().Update(c, int64(1), guest).Return(nil).Times(1)
 
 	err := g.guestService.Update(c, int64(1), guest)
 
 	g.NoError(err)
+	g.Empty(guest.Status)
+}
+
+func (g *GuestServiceSuite) TestUpdateArrivalStampsUTC() {
+	c := context.Background()
+
+	guest := &domain.Guest{Name: "Tere", IsArrived: true, Version: 3}
+
+	g.mockGuestRepository.EXPECT().GetLatestById(c, int64(1)).Return(&domain.Guest{ID: 1, Status: domain.GuestStatusNoShow, Version: 3}, nil).Times(1)
+	g.mockGuestRepository.EXPECT().Update(c, int64(1), guest).Return(nil).Times(1)
+
+	g.NoError(g.guestService.Update(c, 1, guest))
+	g.Equal(domain.GuestStatusArrived, guest.Status)
+	g.NotNil(guest.TimeArrived)
+	g.Equal(time.UTC, guest.TimeArrived.Location())
+}
+
+func (g *GuestServiceSuite) TestUpdateOfArrivedGuestKeepsTimeArrived() {
+	c := context.Background()
+
+	timeArrived := time.Date(2023, 1, 20, 18, 30, 0, 0, time.UTC)
+	guest := &domain.Guest{Name: "Tere", IsArrived: true, Version: 3}
+
+	g.mockGuestRepository.EXPECT().GetLatestById(c, int64(1)).
+		Return(&domain.Guest{ID: 1, Status: domain.GuestStatusArrived, IsArrived: true, TimeArrived: &timeArrived, Version: 3}, nil).Times(1)
+	g.mockGuestRepository.EXPECT().Update(c, int64(1), guest).Return(nil).Times(1)
+
+	g.NoError(g.guestService.Update(c, 1, guest))
+	g.Empty(guest.Status)
+	g.Equal(timeArrived, *guest.TimeArrived)
+}
+
+func (g *GuestServiceSuite) TestUpdateOfStaleVersionConflicts() {
+	c := context.Background()
+
+	g.mockGuestRepository.EXPECT().GetLatestById(c, int64(1)).Return(&domain.Guest{ID: 1, Status: domain.GuestStatusInvited, Version: 4}, nil).Times(1)
+
+	err := g.guestService.Update(c, 1, &domain.Guest{Name: "Tere", IsArrived: true, Version: 3})
+
+	var conflict *apperrors.ConflictError
+	g.ErrorAs(err, &conflict)
+}
+
+func (g *GuestServiceSuite) TestPatchArrivalMovesGuestToArrived() {
+	c := context.Background()
+
+	g.mockGuestRepository.EXPECT().GetLatestById(c, int64(1)).Return(&domain.Guest{ID: 1, Status: domain.GuestStatusTentative, Version: 2}, nil).Times(1)
+	g.mockGuestRepository.EXPECT().Patch(c, int64(1), gomock.Any()).DoAndReturn(func(_ context.Context, _ int64, patch port.GuestPatch) error {
+		g.Equal([]string{"is_arrived", "time_arrived", "status"}, patch.Fields)
+		g.Equal(domain.GuestStatusArrived, patch.Guest.Status)
+		g.NotNil(patch.Guest.TimeArrived)
+
+		return nil
+	}).Times(1)
+
+	g.NoError(g.guestService.Patch(c, 1, port.GuestPatch{Guest: domain.Guest{IsArrived: true, Version: 2}, Fields: []string{"is_arrived"}}))
+}
+
+func (g *GuestServiceSuite) TestPatchArrivedGuestBackIsRejected() {
+	c := context.Background()
+
+	g.mockGuestRepository.EXPECT().GetLatestById(c, int64(1)).Return(&domain.Guest{ID: 1, Status: domain.GuestStatusArrived, IsArrived: true, Version: 2}, nil).Times(1)
+
+	err := g.guestService.Patch(c, 1, port.GuestPatch{Guest: domain.Guest{Version: 2}, Fields: []string{"is_arrived"}})
+
+	var transition *domain.TransitionError
+	g.ErrorAs(err, &transition)
+	g.EqualError(err, "patch guest: guest 1 cannot go from arrived to invited")
+}
+
+func (g *GuestServiceSuite) TestPatchWithoutArrivalSkipsTheStateMachine() {
+	c := context.Background()
+
+	patch := port.GuestPatch{Guest: domain.Guest{Name: "Tere", Version: 2}, Fields: []string{"name"}}
+	g.mockGuestRepository.EXPECT().Patch(c, int64(1), patch).Return(nil).Times(1)
+
+	g.NoError(g.guestService.Patch(c, 1, patch))
+}
+
+func (g *GuestServiceSuite) TestRSVPAccepted() {
+	c := context.Background()
+
+	g.mockGuestRepository.EXPECT().GetLatestById(c, int64(1)).
+		Return(&domain.Guest{ID: 1, Status: domain.GuestStatusInvited, Version: 2}, nil).Times(1)
+	g.mockGuestRepository.EXPECT().Patch(c, int64(1), gomock.Any()).DoAndReturn(func(_ context.Context, _ int64, patch port.GuestPatch) error {
+		g.Equal([]string{"status", "party_size", "responded_at"}, patch.Fields)
+		g.Equal(domain.GuestStatusAccepted, patch.Guest.Status)
+		g.EqualValues(3, patch.Guest.PartySize)
+		g.NotNil(patch.Guest.RespondedAt)
+		g.EqualValues(2, patch.Guest.Version)
+
+		return nil
+	}).Times(1)
+	g.mockGuestRepository.EXPECT().GetLatestById(c, int64(1)).
+		Return(&domain.Guest{ID: 1, Status: domain.GuestStatusAccepted, PartySize: 3, Version: 3}, nil).Times(1)
+
+	guest, err := g.guestService.RSVP(c, 1, domain.RSVP{Status: domain.GuestStatusAccepted, PartySize: 3})
+
+	g.NoError(err)
+	g.Equal(domain.GuestStatusAccepted, guest.Status)
+}
+
+func (g *GuestServiceSuite) TestRSVPDeclinedForgetsThePartySize() {
+	c := context.Background()
+
+	g.mockGuestRepository.EXPECT().GetLatestById(c, int64(1)).
+		Return(&domain.Guest{ID: 1, Status: domain.GuestStatusAccepted, PartySize: 3, Version: 2}, nil).Times(1)
+	g.mockGuestRepository.EXPECT().Patch(c, int64(1), gomock.Any()).DoAndReturn(func(_ context.Context, _ int64, patch port.GuestPatch) error {
+		g.Equal(domain.GuestStatusDeclined, patch.Guest.Status)
+		g.Zero(patch.Guest.PartySize)
+
+		return nil
+	}).Times(1)
+	g.mockGuestRepository.EXPECT().GetLatestById(c, int64(1)).Return(&domain.Guest{ID: 1, Status: domain.GuestStatusDeclined}, nil).Times(1)
+
+	_, err := g.guestService.RSVP(c, 1, domain.RSVP{Status: domain.GuestStatusDeclined, PartySize: 3})
+
+	g.NoError(err)
+}
+
+func (g *GuestServiceSuite) TestRSVPOfArrivedGuestIsRejected() {
+	c := context.Background()
+
+	g.mockGuestRepository.EXPECT().GetLatestById(c, int64(1)).Return(&domain.Guest{ID: 1, Status: domain.GuestStatusArrived}, nil).Times(1)
+
+	_, err := g.guestService.RSVP(c, 1, domain.RSVP{Status: domain.GuestStatusDeclined})
+
+	var transition *domain.TransitionError
+	g.ErrorAs(err, &transition)
+	g.EqualError(err, "rsvp guest: guest 1 cannot go from arrived to declined")
+}
+
+func (g *GuestServiceSuite) TestMarkNoShow() {
+	c := context.Background()
+
+	g.mockGuestRepository.EXPECT().GetLatestById(c, int64(1)).Return(&domain.Guest{ID: 1, Status: domain.GuestStatusAccepted, Version: 4}, nil).Times(1)
+	g.mockGuestRepository.EXPECT().Patch(c, int64(1), port.GuestPatch{
+		Guest:  domain.Guest{Status: domain.GuestStatusNoShow, Version: 4},
+		Fields: []string{"status"},
+	}).Return(nil).Times(1)
+	g.mockGuestRepository.EXPECT().GetLatestById(c, int64(1)).Return(&domain.Guest{ID: 1, Status: domain.GuestStatusNoShow, Version: 5}, nil).Times(1)
+
+	guest, err := g.guestService.MarkNoShow(c, 1)
+
+	g.NoError(err)
+	g.Equal(domain.GuestStatusNoShow, guest.Status)
+
+	g.mockGuestRepository.EXPECT().GetLatestById(c, int64(2)).Return(&domain.Guest{ID: 2, Status: domain.GuestStatusDeclined}, nil).Times(1)
+
+	_, err = g.guestService.MarkNoShow(c, 2)
+
+	g.EqualError(err, "mark guest as no-show: guest 2 cannot go from declined to no_show")
 }
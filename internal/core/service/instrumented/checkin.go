@@ -0,0 +1,26 @@
+package instrumented
+
+import (
+	"context"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+)
+
+const checkinService = "checkin"
+
+type CheckinService struct {
+	next port.CheckinService
+}
+
+// NewCheckinService traces every call made to the wrapped service
+func NewCheckinService(next port.CheckinService) port.CheckinService {
+	return &CheckinService{next: next}
+}
+
+func (s *CheckinService) CheckIn(ctx context.Context, guestID int64, accompanyingGuests uint16) (table *domain.Table, err error) {
+	ctx, done := observe(ctx, checkinService, "CheckIn")
+	defer func() { done(err) }()
+
+	return s.next.CheckIn(ctx, guestID, accompanyingGuests)
+}
@@ -0,0 +1,40 @@
+package instrumented
+
+import (
+	"context"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+)
+
+const companionService = "companion"
+
+type CompanionService struct {
+	next port.CompanionService
+}
+
+// NewCompanionService traces every call made to the wrapped service
+func NewCompanionService(next port.CompanionService) port.CompanionService {
+	return &CompanionService{next: next}
+}
+
+func (s *CompanionService) GetList(ctx context.Context, guestID int64) (companions []*domain.Companion, err error) {
+	ctx, done := observe(ctx, companionService, "GetList")
+	defer func() { done(err) }()
+
+	return s.next.GetList(ctx, guestID)
+}
+
+func (s *CompanionService) Add(ctx context.Context, c *domain.Companion) (companion *domain.Companion, err error) {
+	ctx, done := observe(ctx, companionService, "Add")
+	defer func() { done(err) }()
+
+	return s.next.Add(ctx, c)
+}
+
+func (s *CompanionService) Remove(ctx context.Context, guestID int64, id int64) (err error) {
+	ctx, done := observe(ctx, companionService, "Remove")
+	defer func() { done(err) }()
+
+	return s.next.Remove(ctx, guestID, id)
+}
@@ -0,0 +1,82 @@
+package instrumented
+
+import (
+	"context"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+)
+
+const guestService = "guest"
+
+type GuestService struct {
+	next port.GuestService
+}
+
+// NewGuestService traces every call made to the wrapped service
+func NewGuestService(next port.GuestService) port.GuestService {
+	return &GuestService{next: next}
+}
+
+func (s *GuestService) Create(ctx context.Context, g *domain.Guest) (guest *domain.Guest, err error) {
+	ctx, done := observe(ctx, guestService, "Create")
+	defer func() { done(err) }()
+
+	return s.next.Create(ctx, g)
+}
+
+func (s *GuestService) Update(ctx context.Context, id int64, u *domain.Guest) (err error) {
+	ctx, done := observe(ctx, guestService, "Update")
+	defer func() { done(err) }()
+
+	return s.next.Update(ctx, id, u)
+}
+
+func (s *GuestService) Patch(ctx context.Context, id int64, patch port.GuestPatch) (err error) {
+	ctx, done := observe(ctx, guestService, "Patch")
+	defer func() { done(err) }()
+
+	return s.next.Patch(ctx, id, patch)
+}
+
+func (s *GuestService) Delete(ctx context.Context, id int64, version int64) (err error) {
+	ctx, done := observe(ctx, guestService, "Delete")
+	defer func() { done(err) }()
+
+	return s.next.Delete(ctx, id, version)
+}
+
+func (s *GuestService) Restore(ctx context.Context, id int64) (err error) {
+	ctx, done := observe(ctx, guestService, "Restore")
+	defer func() { done(err) }()
+
+	return s.next.Restore(ctx, id)
+}
+
+func (s *GuestService) GetById(ctx context.Context, id int64) (guest *domain.Guest, err error) {
+	ctx, done := observe(ctx, guestService, "GetById")
+	defer func() { done(err) }()
+
+	return s.next.GetById(ctx, id)
+}
+
+func (s *GuestService) GetList(ctx context.Context, filter port.GetGuestFilter) (guests []*domain.Guest, err error) {
+	ctx, done := observe(ctx, guestService, "GetList")
+	defer func() { done(err) }()
+
+	return s.next.GetList(ctx, filter)
+}
+
+func (s *GuestService) RSVP(ctx context.Context, id int64, rsvp domain.RSVP) (guest *domain.Guest, err error) {
+	ctx, done := observe(ctx, guestService, "RSVP")
+	defer func() { done(err) }()
+
+	return s.next.RSVP(ctx, id, rsvp)
+}
+
+func (s *GuestService) MarkNoShow(ctx context.Context, id int64) (guest *domain.Guest, err error) {
+	ctx, done := observe(ctx, guestService, "MarkNoShow")
+	defer func() { done(err) }()
+
+	return s.next.MarkNoShow(ctx, id)
+}
@@ -0,0 +1,33 @@
+package instrumented
+
+import (
+	"context"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+)
+
+const guestListService = "guestlist"
+
+type GuestListService struct {
+	next port.GuestListService
+}
+
+// NewGuestListService traces every call made to the wrapped service
+func NewGuestListService(next port.GuestListService) port.GuestListService {
+	return &GuestListService{next: next}
+}
+
+func (s *GuestListService) FindAvailableTable(ctx context.Context, filter port.GetGuestListFilter) (table *domain.Table, err error) {
+	ctx, done := observe(ctx, guestListService, "FindAvailableTable")
+	defer func() { done(err) }()
+
+	return s.next.FindAvailableTable(ctx, filter)
+}
+
+func (s *GuestListService) GetOccupiedSeats(ctx context.Context) (tables []*domain.Table, err error) {
+	ctx, done := observe(ctx, guestListService, "GetOccupiedSeats")
+	defer func() { done(err) }()
+
+	return s.next.GetOccupiedSeats(ctx)
+}
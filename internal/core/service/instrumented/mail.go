@@ -0,0 +1,32 @@
+package instrumented
+
+import (
+	"context"
+
+	"github.com/eazygood/getground-app/internal/core/port"
+)
+
+const mailService = "mail"
+
+type MailService struct {
+	next port.MailService
+}
+
+// NewMailService traces every call made to the wrapped service
+func NewMailService(next port.MailService) port.MailService {
+	return &MailService{next: next}
+}
+
+func (s *MailService) Send(ctx context.Context, guestID int64, kind string) (err error) {
+	ctx, done := observe(ctx, mailService, "Send")
+	defer func() { done(err) }()
+
+	return s.next.Send(ctx, guestID, kind)
+}
+
+func (s *MailService) SendDueReminders(ctx context.Context) (sent int, err error) {
+	ctx, done := observe(ctx, mailService, "SendDueReminders")
+	defer func() { done(err) }()
+
+	return s.next.SendDueReminders(ctx)
+}
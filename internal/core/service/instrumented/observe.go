@@ -0,0 +1,16 @@
+package instrumented
+
+import (
+	"context"
+
+	"github.com/eazygood/getground-app/internal/infrastructure/tracing"
+)
+
+// observe starts a span for a service call, the returned func ends it with the outcome of the call
+func observe(ctx context.Context, service string, method string) (context.Context, func(error)) {
+	ctx, span := tracing.Start(ctx, "service."+service+"."+method)
+
+	return ctx, func(err error) {
+		tracing.End(span, err)
+	}
+}
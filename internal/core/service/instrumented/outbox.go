@@ -0,0 +1,33 @@
+package instrumented
+
+import (
+	"context"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+)
+
+const outboxService = "outbox"
+
+type OutboxService struct {
+	next port.OutboxService
+}
+
+// NewOutboxService traces every call made to the wrapped service
+func NewOutboxService(next port.OutboxService) port.OutboxService {
+	return &OutboxService{next: next}
+}
+
+func (s *OutboxService) Publish(ctx context.Context, event domain.Event) (err error) {
+	ctx, done := observe(ctx, outboxService, "Publish")
+	defer func() { done(err) }()
+
+	return s.next.Publish(ctx, event)
+}
+
+func (s *OutboxService) Dispatch(ctx context.Context) (count int, err error) {
+	ctx, done := observe(ctx, outboxService, "Dispatch")
+	defer func() { done(err) }()
+
+	return s.next.Dispatch(ctx)
+}
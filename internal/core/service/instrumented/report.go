@@ -0,0 +1,47 @@
+package instrumented
+
+import (
+	"context"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+)
+
+const reportService = "report"
+
+type ReportService struct {
+	next port.ReportService
+}
+
+// NewReportService traces every call made to the wrapped service
+func NewReportService(next port.ReportService) port.ReportService {
+	return &ReportService{next: next}
+}
+
+func (s *ReportService) Dietary(ctx context.Context) (report *domain.DietaryReport, err error) {
+	ctx, done := observe(ctx, reportService, "Dietary")
+	defer func() { done(err) }()
+
+	return s.next.Dietary(ctx)
+}
+
+func (s *ReportService) Capacity(ctx context.Context) (report *domain.CapacityReport, err error) {
+	ctx, done := observe(ctx, reportService, "Capacity")
+	defer func() { done(err) }()
+
+	return s.next.Capacity(ctx)
+}
+
+func (s *ReportService) Forecast(ctx context.Context) (report *domain.Forecast, err error) {
+	ctx, done := observe(ctx, reportService, "Forecast")
+	defer func() { done(err) }()
+
+	return s.next.Forecast(ctx)
+}
+
+func (s *ReportService) LatestForecast(ctx context.Context) (report *domain.Forecast, err error) {
+	ctx, done := observe(ctx, reportService, "LatestForecast")
+	defer func() { done(err) }()
+
+	return s.next.LatestForecast(ctx)
+}
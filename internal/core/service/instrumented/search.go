@@ -0,0 +1,26 @@
+package instrumented
+
+import (
+	"context"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+)
+
+const guestSearchService = "guest_search"
+
+type GuestSearchService struct {
+	next port.GuestSearchService
+}
+
+// NewGuestSearchService traces every call made to the wrapped service
+func NewGuestSearchService(next port.GuestSearchService) port.GuestSearchService {
+	return &GuestSearchService{next: next}
+}
+
+func (s *GuestSearchService) Search(ctx context.Context, query string, limit int) (matches []*domain.GuestMatch, err error) {
+	ctx, done := observe(ctx, guestSearchService, "Search")
+	defer func() { done(err) }()
+
+	return s.next.Search(ctx, query, limit)
+}
@@ -0,0 +1,75 @@
+package instrumented
+
+import (
+	"context"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+)
+
+const tableService = "table"
+
+type TableService struct {
+	next port.TableService
+}
+
+// NewTableService traces every call made to the wrapped service
+func NewTableService(next port.TableService) port.TableService {
+	return &TableService{next: next}
+}
+
+func (s *TableService) GetById(ctx context.Context, id int64) (table *domain.Table, err error) {
+	ctx, done := observe(ctx, tableService, "GetById")
+	defer func() { done(err) }()
+
+	return s.next.GetById(ctx, id)
+}
+
+func (s *TableService) GetList(ctx context.Context, filter port.GetTableFilter) (tables []*domain.Table, err error) {
+	ctx, done := observe(ctx, tableService, "GetList")
+	defer func() { done(err) }()
+
+	return s.next.GetList(ctx, filter)
+}
+
+func (s *TableService) GetEmptySeats(ctx context.Context) (count int64, err error) {
+	ctx, done := observe(ctx, tableService, "GetEmptySeats")
+	defer func() { done(err) }()
+
+	return s.next.GetEmptySeats(ctx)
+}
+
+func (s *TableService) Create(ctx context.Context, t *domain.Table) (table *domain.Table, err error) {
+	ctx, done := observe(ctx, tableService, "Create")
+	defer func() { done(err) }()
+
+	return s.next.Create(ctx, t)
+}
+
+func (s *TableService) Update(ctx context.Context, id int64, table domain.Table) (err error) {
+	ctx, done := observe(ctx, tableService, "Update")
+	defer func() { done(err) }()
+
+	return s.next.Update(ctx, id, table)
+}
+
+func (s *TableService) Patch(ctx context.Context, id int64, patch port.TablePatch) (err error) {
+	ctx, done := observe(ctx, tableService, "Patch")
+	defer func() { done(err) }()
+
+	return s.next.Patch(ctx, id, patch)
+}
+
+func (s *TableService) Delete(ctx context.Context, id int64, version int64) (err error) {
+	ctx, done := observe(ctx, tableService, "Delete")
+	defer func() { done(err) }()
+
+	return s.next.Delete(ctx, id, version)
+}
+
+func (s *TableService) Restore(ctx context.Context, id int64) (err error) {
+	ctx, done := observe(ctx, tableService, "Restore")
+	defer func() { done(err) }()
+
+	return s.next.Restore(ctx, id)
+}
@@ -0,0 +1,68 @@
+package instrumented
+
+import (
+	"context"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+)
+
+const webhookService = "webhook"
+
+type WebhookService struct {
+	next port.WebhookService
+}
+
+// NewWebhookService traces every call made to the wrapped service
+func NewWebhookService(next port.WebhookService) port.WebhookService {
+	return &WebhookService{next: next}
+}
+
+func (s *WebhookService) Register(ctx context.Context, w *domain.Webhook) (webhook *domain.Webhook, err error) {
+	ctx, done := observe(ctx, webhookService, "Register")
+	defer func() { done(err) }()
+
+	return s.next.Register(ctx, w)
+}
+
+func (s *WebhookService) GetById(ctx context.Context, id int64) (webhook *domain.Webhook, err error) {
+	ctx, done := observe(ctx, webhookService, "GetById")
+	defer func() { done(err) }()
+
+	return s.next.GetById(ctx, id)
+}
+
+func (s *WebhookService) GetList(ctx context.Context) (webhooks []*domain.Webhook, err error) {
+	ctx, done := observe(ctx, webhookService, "GetList")
+	defer func() { done(err) }()
+
+	return s.next.GetList(ctx)
+}
+
+func (s *WebhookService) Delete(ctx context.Context, id int64) (err error) {
+	ctx, done := observe(ctx, webhookService, "Delete")
+	defer func() { done(err) }()
+
+	return s.next.Delete(ctx, id)
+}
+
+func (s *WebhookService) GetDeliveries(ctx context.Context, filter port.GetDeliveriesFilter) (deliveries []*domain.WebhookDelivery, err error) {
+	ctx, done := observe(ctx, webhookService, "GetDeliveries")
+	defer func() { done(err) }()
+
+	return s.next.GetDeliveries(ctx, filter)
+}
+
+func (s *WebhookService) Publish(ctx context.Context, event domain.Event) (err error) {
+	ctx, done := observe(ctx, webhookService, "Publish")
+	defer func() { done(err) }()
+
+	return s.next.Publish(ctx, event)
+}
+
+func (s *WebhookService) DeliverDue(ctx context.Context) (count int, err error) {
+	ctx, done := observe(ctx, webhookService, "DeliverDue")
+	defer func() { done(err) }()
+
+	return s.next.DeliverDue(ctx)
+}
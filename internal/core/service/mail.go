@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	"github.com/eazygood/getground-app/internal/venue"
+)
+
+// ReminderSchedule tells when the guests are reminded of the event: Before lists how long before
+// EventDate the reminders are due, e.g. a week and a day
+type ReminderSchedule struct {
+	Event     string
+	EventDate time.Time
+	Before    []time.Duration
+	// BatchSize is how many reminders are sent by SendDueReminders
+	BatchSize int
+	// a reminder that could not be sent is retried after InitialBackoff, doubled on every attempt
+	// up to MaxBackoff, until MaxAttempts were made
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// remindedStatuses are the guests who get the reminders, the ones who did not answer for sure
+var remindedStatuses = []string{domain.GuestStatusInvited, domain.GuestStatusTentative}
+
+type MailService struct {
+	guests    port.GuestRepository
+	tables    port.TableRepository
+	reminders port.ReminderRepository
+	mailer    port.Mailer
+	templates port.MailTemplates
+	schedule  ReminderSchedule
+	now       func() time.Time
+}
+
+func NewMailService(
+	guests port.GuestRepository,
+	tables port.TableRepository,
+	reminders port.ReminderRepository,
+	mailer port.Mailer,
+	templates port.MailTemplates,
+	schedule ReminderSchedule,
+) port.MailService {
+	return &MailService{
+		guests:    guests,
+		tables:    tables,
+		reminders: reminders,
+		mailer:    mailer,
+		templates: templates,
+		schedule:  schedule,
+		now:       venue.Now,
+	}
+}
+
+func (srv *MailService) Send(ctx context.Context, guestID int64, kind string) error {
+	guest, err := srv.guests.GetById(ctx, guestID)
+	if err != nil {
+		return fmt.Errorf("send %s: %w", kind, err)
+	}
+
+	data := domain.EmailData{Guest: guest}
+
+	if kind == domain.EmailTableAssignment {
+		tables, err := srv.tables.GetAll(ctx, port.GetTableFilter{GuestIDs: []int64{guestID}})
+		if err != nil {
+			return fmt.Errorf("send %s: %w", kind, err)
+		}
+
+		if len(tables) == 0 {
+			return fmt.Errorf("send %s: %w", kind, domain.ErrNotSeated)
+		}
+
+		data.Table = tables[0]
+	}
+
+	if err := srv.send(ctx, kind, data); err != nil {
+		return fmt.Errorf("send %s: %w", kind, err)
+	}
+
+	return nil
+}
+
+func (srv *MailService) SendDueReminders(ctx context.Context) (int, error) {
+	dueAt, ok := srv.dueReminder()
+	if !ok {
+		return 0, nil
+	}
+
+	now := srv.now()
+
+	guests, err := srv.reminders.GetPending(ctx, dueAt, now, remindedStatuses, srv.schedule.MaxAttempts, srv.schedule.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("get pending reminders: %w", err)
+	}
+
+	attempted := 0
+	var failed []error
+	for _, guest := range guests {
+		// another instance may have sent it meanwhile
+		attempts, err := srv.reminders.Claim(ctx, guest.ID, dueAt, now, srv.schedule.MaxAttempts)
+		if err != nil {
+			return 0, fmt.Errorf("claim reminder: %w", err)
+		}
+
+		if attempts == 0 {
+			continue
+		}
+
+		attempted++
+
+		if err := srv.send(ctx, domain.EmailReminder, domain.EmailData{Guest: guest}); err != nil {
+			failed = append(failed, fmt.Errorf("guest %d: %w", guest.ID, err))
+
+			// a guest whose reminder keeps failing waits longer every time, and is left out once the attempts run out,
+			// so they do not hold back the reminders of the others
+			next := now.Add(backoff(srv.schedule.InitialBackoff, srv.schedule.MaxBackoff, attempts))
+			if err := srv.reminders.Fail(ctx, guest.ID, dueAt, next, err.Error()); err != nil {
+				return 0, fmt.Errorf("record failed reminder: %w", err)
+			}
+		}
+	}
+
+	if len(failed) > 0 {
+		return attempted, fmt.Errorf("send %d of %d reminders: %w", len(failed), attempted, failed[len(failed)-1])
+	}
+
+	return attempted, nil
+}
+
+// dueReminder returns when the latest reminder that is due was due, a guest who missed an earlier
+// one gets the latest only. No reminder is due once the event started.
+func (srv *MailService) dueReminder() (time.Time, bool) {
+	now := srv.now()
+	if !now.Before(srv.schedule.EventDate) {
+		return time.Time{}, false
+	}
+
+	before := append([]time.Duration{}, srv.schedule.Before...)
+	sort.Slice(before, func(i, j int) bool { return before[i] < before[j] })
+
+	for _, b := range before {
+		if dueAt := srv.schedule.EventDate.Add(-b); !now.Before(dueAt) {
+			return dueAt, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func (srv *MailService) send(ctx context.Context, kind string, data domain.EmailData) error {
+	if data.Guest.Email == "" {
+		return domain.ErrNoEmailAddress
+	}
+
+	data.Event = srv.schedule.Event
+	data.EventDate = srv.schedule.EventDate
+
+	email, err := srv.templates.Render(kind, data)
+	if err != nil {
+		return err
+	}
+
+	email.To = data.Guest.Email
+	email.ToName = data.Guest.Name
+
+	return srv.mailer.Send(ctx, *email)
+}
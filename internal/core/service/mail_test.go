@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	ports "github.com/eazygood/getground-app/mocks/core/port"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type MailServiceSuite struct {
+	suite.Suite
+	*require.Assertions
+	ctrl                   *gomock.Controller
+	mockGuestRepository    *ports.MockGuestRepository
+	mockTableRepository    *ports.MockTableRepository
+	mockReminderRepository *ports.MockReminderRepository
+	mockMailer             *ports.MockMailer
+	mockTemplates          *ports.MockMailTemplates
+	mailService            *MailService
+	eventDate              time.Time
+	now                    time.Time
+}
+
+func TestMailServiceSuite(t *testing.T) {
+	suite.Run(t, new(MailServiceSuite))
+}
+
+func (m *MailServiceSuite) SetupTest() {
+	m.Assertions = require.New(m.T())
+	m.ctrl = gomock.NewController(m.T())
+	m.mockGuestRepository = ports.NewMockGuestRepository(m.ctrl)
+	m.mockTableRepository = ports.NewMockTableRepository(m.ctrl)
+	m.mockReminderRepository = ports.NewMockReminderRepository(m.ctrl)
+	m.mockMailer = ports.NewMockMailer(m.ctrl)
+	m.mockTemplates = ports.NewMockMailTemplates(m.ctrl)
+	m.eventDate = time.Date(2023, 12, 15, 19, 0, 0, 0, time.UTC)
+	m.now = m.eventDate.Add(-36 * time.Hour)
+
+	m.mailService = NewMailService(
+		m.mockGuestRepository,
+		m.mockTableRepository,
+		m.mockReminderRepository,
+		m.mockMailer,
+		m.mockTemplates,
+		ReminderSchedule{
+			Event:          "The Party",
+			EventDate:      m.eventDate,
+			Before:         []time.Duration{24 * time.Hour, 7 * 24 * time.Hour},
+			BatchSize:      10,
+			MaxAttempts:    3,
+			InitialBackoff: 5 * time.Minute,
+			MaxBackoff:     time.Hour,
+		},
+	).(*MailService)
+	m.mailService.now = func() time.Time { return m.now }
+}
+
+func (m *MailServiceSuite) TearDownTest() {
+	m.ctrl.Finish()
+}
+
+func (m *MailServiceSuite) TestDueReminder() {
+	for _, tc := range []struct {
+		name  string
+		now   time.Time
+		due   time.Time
+		isDue bool
+	}{
+		{"before the first reminder", m.eventDate.Add(-8 * 24 * time.Hour), time.Time{}, false},
+		{"after the first reminder", m.eventDate.Add(-36 * time.Hour), m.eventDate.Add(-7 * 24 * time.Hour), true},
+		{"after the last reminder", m.eventDate.Add(-time.Hour), m.eventDate.Add(-24 * time.Hour), true},
+		{"once the event started", m.eventDate, time.Time{}, false},
+	} {
+		m.now = tc.now
+
+		due, isDue := m.mailService.dueReminder()
+		m.Equal(tc.isDue, isDue, tc.name)
+		m.Equal(tc.due, due, tc.name)
+	}
+}
+
+func (m *MailServiceSuite) TestSendTableAssignment() {
+	ctx := context.Background()
+	guest := &domain.Guest{ID: 1, Name: "Simon", Email: "simon@example.com"}
+	table := &domain.Table{ID: 3, Seats: 8}
+
+	m.mockGuestRepository.EXPECT().GetById(ctx, int64(1)).Return(guest, nil).Times(1)
+	m.mockTableRepository.EXPECT().GetAll(ctx, port.GetTableFilter{GuestIDs: []int64{1}}).Return([]*domain.Table{table}, nil).Times(1)
+	m.mockTemplates.EXPECT().Render(domain.EmailTableAssignment, domain.EmailData{
+		Guest:     guest,
+		Event:     "The Party",
+		EventDate: m.eventDate,
+		Table:     table,
+	}).Return(&domain.Email{Subject: "Your table", Text: "table 3", HTML: "<p>table 3</p>"}, nil).Times(1)
+	m.mockMailer.EXPECT().Send(ctx, domain.Email{
+		To:      "simon@example.com",
+		ToName:  "Simon",
+		Subject: "Your table",
+		Text:    "table 3",
+		HTML:    "<p>table 3</p>",
+	}).Return(nil).Times(1)
+
+	m.NoError(m.mailService.Send(ctx, 1, domain.EmailTableAssignment))
+}
+
+func (m *MailServiceSuite) TestSendRequiresTableAndAddress() {
+	ctx := context.Background()
+
+	m.mockGuestRepository.EXPECT().GetById(ctx, int64(1)).Return(&domain.Guest{ID: 1, Email: "simon@example.com"}, nil).Times(1)
+	m.mockTableRepository.EXPECT().GetAll(ctx, port.GetTableFilter{GuestIDs: []int64{1}}).Return(nil, nil).Times(1)
+
+	err := m.mailService.Send(ctx, 1, domain.EmailTableAssignment)
+	m.ErrorIs(err, domain.ErrNotSeated)
+
+	m.mockGuestRepository.EXPECT().GetById(ctx, int64(2)).Return(&domain.Guest{ID: 2}, nil).Times(1)
+
+	err = m.mailService.Send(ctx, 2, domain.EmailInvitation)
+	m.ErrorIs(err, domain.ErrNoEmailAddress)
+	m.EqualError(err, "send invitation: guest has no email address")
+}
+
+func (m *MailServiceSuite) TestSendDueReminders() {
+	ctx := context.Background()
+	dueAt := m.eventDate.Add(-7 * 24 * time.Hour)
+	simon := &domain.Guest{ID: 1, Name: "Simon", Email: "simon@example.com"}
+	carl := &domain.Guest{ID: 2, Name: "Carl", Email: "carl@example.com"}
+	paul := &domain.Guest{ID: 3, Name: "Paul", Email: "paul@example.com"}
+	email := &domain.Email{Subject: "Reminder"}
+
+	m.mockReminderRepository.EXPECT().GetPending(ctx, dueAt, m.now, remindedStatuses, 3, 10).Return([]*domain.Guest{simon, carl, paul}, nil).Times(1)
+	m.mockReminderRepository.EXPECT().Claim(ctx, int64(1), dueAt, m.now, 3).Return(1, nil).Times(1)
+	// sent by another instance meanwhile
+	m.mockReminderRepository.EXPECT().Claim(ctx, int64(2), dueAt, m.now, 3).Return(0, nil).Times(1)
+	// failed once before
+	m.mockReminderRepository.EXPECT().Claim(ctx, int64(3), dueAt, m.now, 3).Return(2, nil).Times(1)
+	m.mockTemplates.EXPECT().Render(domain.EmailReminder, gomock.Any()).Return(email, nil).Times(2)
+	m.mockMailer.EXPECT().Send(ctx, gomock.Any()).Return(nil).Times(1)
+	m.mockMailer.EXPECT().Send(ctx, gomock.Any()).Return(errors.New("connection refused")).Times(1)
+	m.mockReminderRepository.EXPECT().Fail(ctx, int64(3), dueAt, m.now.Add(10*time.Minute), "connection refused").Return(nil).Times(1)
+
+	attempted, err := m.mailService.SendDueReminders(ctx)
+	m.Equal(2, attempted)
+	m.EqualError(err, "send 1 of 2 reminders: guest 3: connection refused")
+}
+
+func (m *MailServiceSuite) TestSendDueRemindersBeforeTheFirstOne() {
+	m.now = m.eventDate.Add(-30 * 24 * time.Hour)
+
+	attempted, err := m.mailService.SendDueReminders(context.Background())
+	m.NoError(err)
+	m.Zero(attempted)
+}
@@ -0,0 +1,41 @@
+package notifying
+
+import (
+	"context"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+)
+
+type CompanionService struct {
+	next      port.CompanionService
+	occupancy *Occupancy
+}
+
+// NewCompanionService checks the occupancy of the venue when the party of a guest grows or shrinks
+func NewCompanionService(next port.CompanionService, occupancy *Occupancy) port.CompanionService {
+	return &CompanionService{next: next, occupancy: occupancy}
+}
+
+func (s *CompanionService) GetList(ctx context.Context, guestID int64) ([]*domain.Companion, error) {
+	return s.next.GetList(ctx, guestID)
+}
+
+func (s *CompanionService) Add(ctx context.Context, c *domain.Companion) (*domain.Companion, error) {
+	companion, err := s.next.Add(ctx, c)
+	if err == nil {
+		s.occupancy.check(ctx)
+	}
+
+	return companion, err
+}
+
+func (s *CompanionService) Remove(ctx context.Context, guestID int64, id int64) error {
+	if err := s.next.Remove(ctx, guestID, id); err != nil {
+		return err
+	}
+
+	s.occupancy.check(ctx)
+
+	return nil
+}
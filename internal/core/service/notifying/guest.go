@@ -0,0 +1,60 @@
+package notifying
+
+import (
+	"context"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+)
+
+type GuestService struct {
+	next      port.GuestService
+	occupancy *Occupancy
+}
+
+// NewGuestService checks the occupancy of the venue when a guest leaves and frees their table
+func NewGuestService(next port.GuestService, occupancy *Occupancy) port.GuestService {
+	return &GuestService{next: next, occupancy: occupancy}
+}
+
+func (s *GuestService) Create(ctx context.Context, g *domain.Guest) (*domain.Guest, error) {
+	return s.next.Create(ctx, g)
+}
+
+func (s *GuestService) Update(ctx context.Context, id int64, u *domain.Guest) error {
+	return s.next.Update(ctx, id, u)
+}
+
+func (s *GuestService) Patch(ctx context.Context, id int64, patch port.GuestPatch) error {
+	return s.next.Patch(ctx, id, patch)
+}
+
+func (s *GuestService) Delete(ctx context.Context, id int64, version int64) error {
+	if err := s.next.Delete(ctx, id, version); err != nil {
+		return err
+	}
+
+	s.occupancy.check(ctx)
+
+	return nil
+}
+
+func (s *GuestService) Restore(ctx context.Context, id int64) error {
+	return s.next.Restore(ctx, id)
+}
+
+func (s *GuestService) GetById(ctx context.Context, id int64) (*domain.Guest, error) {
+	return s.next.GetById(ctx, id)
+}
+
+func (s *GuestService) GetList(ctx context.Context, filter port.GetGuestFilter) ([]*domain.Guest, error) {
+	return s.next.GetList(ctx, filter)
+}
+
+func (s *GuestService) RSVP(ctx context.Context, id int64, rsvp domain.RSVP) (*domain.Guest, error) {
+	return s.next.RSVP(ctx, id, rsvp)
+}
+
+func (s *GuestService) MarkNoShow(ctx context.Context, id int64) (*domain.Guest, error) {
+	return s.next.MarkNoShow(ctx, id)
+}
@@ -0,0 +1,100 @@
+package notifying
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	ports "github.com/eazygood/getground-app/mocks/core/port"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type NotifyingSuite struct {
+	suite.Suite
+	*require.Assertions
+	ctrl                 *gomock.Controller
+	mockGuestService     *ports.MockGuestService
+	mockTableService     *ports.MockTableService
+	mockGuestListService *ports.MockGuestListService
+	mockOutboxService    *ports.MockOutboxService
+	guestService         port.GuestService
+	tableService         port.TableService
+}
+
+func TestNotifyingSuite(t *testing.T) {
+	suite.Run(t, new(NotifyingSuite))
+}
+
+func (n *NotifyingSuite) SetupTest() {
+	n.Assertions = require.New(n.T())
+	n.ctrl = gomock.NewController(n.T())
+	n.mockGuestService = ports.NewMockGuestService(n.ctrl)
+	n.mockTableService = ports.NewMockTableService(n.ctrl)
+	n.mockGuestListService = ports.NewMockGuestListService(n.ctrl)
+	n.mockOutboxService = ports.NewMockOutboxService(n.ctrl)
+
+	occupancy := NewOccupancy(0.8, n.mockTableService, n.mockGuestListService, n.mockOutboxService)
+	n.guestService = NewGuestService(n.mockGuestService, occupancy)
+	n.tableService = NewTableService(n.mockTableService, occupancy)
+}
+
+func (n *NotifyingSuite) TearDownTest() {
+	n.ctrl.Finish()
+}
+
+// expectOccupancy makes the venue 10 seats large with seated people taken
+func (n *NotifyingSuite) expectOccupancy(ctx context.Context, seated uint16) {
+	tables := []*domain.Table{}
+	if seated > 0 {
+		tables = append(tables, &domain.Table{ID: 1, Seats: seated, Guest: domain.Guest{AccompanyingGuests: seated - 1}})
+	}
+
+	n.mockGuestListService.EXPECT().GetOccupiedSeats(ctx).Return(tables, nil).Times(1)
+	n.mockTableService.EXPECT().GetEmptySeats(ctx).Return(int64(10-seated), nil).Times(1)
+}
+
+func (n *NotifyingSuite) TestDepartureChecksOccupancy() {
+	ctx := context.Background()
+
+	n.mockGuestService.EXPECT().Delete(ctx, int64(1), int64(2)).Return(nil).Times(1)
+	n.expectOccupancy(ctx, 0)
+
+	n.NoError(n.guestService.Delete(ctx, 1, 2))
+}
+
+func (n *NotifyingSuite) TestFailedMutationDoesNotCheckOccupancy() {
+	ctx := context.Background()
+
+	n.mockTableService.EXPECT().Delete(ctx, int64(1), int64(2)).Return(errors.New("Mock Service Error")).Times(1)
+
+	n.ErrorContains(n.tableService.Delete(ctx, 1, 2), "Mock Service Error")
+}
+
+func (n *NotifyingSuite) TestOccupancyCrossingIsPublishedOnce() {
+	ctx := context.Background()
+	n.mockTableService.EXPECT().Patch(ctx, int64(1), gomock.Any()).Return(nil).Times(4)
+
+	// the first check only records where the venue stands
+	n.expectOccupancy(ctx, 5)
+	n.NoError(n.tableService.Patch(ctx, 1, port.TablePatch{}))
+
+	n.expectOccupancy(ctx, 9)
+	n.mockOutboxService.EXPECT().Publish(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, event domain.Event) error {
+		n.Equal(domain.EventOccupancyExceeded, event.Type)
+		n.JSONEq(`{"threshold":0.8,"occupancy":0.9,"seats_taken":9,"seats_total":10}`, string(event.Data))
+
+		return nil
+	}).Times(1)
+	n.NoError(n.tableService.Patch(ctx, 1, port.TablePatch{}))
+
+	// still above, nothing new to tell
+	n.expectOccupancy(ctx, 10)
+	n.NoError(n.tableService.Patch(ctx, 1, port.TablePatch{}))
+
+	n.expectOccupancy(ctx, 4)
+	n.NoError(n.tableService.Patch(ctx, 1, port.TablePatch{}))
+}
@@ -0,0 +1,92 @@
+package notifying
+
+import (
+	"context"
+	"sync"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	"github.com/eazygood/getground-app/internal/infrastructure/log"
+	"github.com/eazygood/getground-app/internal/venue"
+)
+
+// OccupancyData is the payload of occupancy.threshold_crossed
+type OccupancyData struct {
+	Threshold  float64 `json:"threshold"`
+	Occupancy  float64 `json:"occupancy"`
+	SeatsTaken int64   `json:"seats_taken"`
+	SeatsTotal int64   `json:"seats_total"`
+}
+
+// Occupancy publishes occupancy.threshold_crossed when the share of the seats taken by seated
+// parties goes above the threshold. It is checked after every mutation that seats or frees a
+// table, and armed again once the occupancy falls back under the threshold.
+type Occupancy struct {
+	threshold        float64
+	tableService     port.TableService
+	guestListService port.GuestListService
+	outboxService    port.OutboxService
+
+	mu sync.Mutex
+	// above is nil until the first check, the occupancy the server starts with is not a crossing
+	above *bool
+}
+
+func NewOccupancy(threshold float64, table port.TableService, guestList port.GuestListService, outbox port.OutboxService) *Occupancy {
+	return &Occupancy{
+		threshold:        threshold,
+		tableService:     table,
+		guestListService: guestList,
+		outboxService:    outbox,
+	}
+}
+
+func (o *Occupancy) check(ctx context.Context) {
+	if o.threshold <= 0 {
+		return
+	}
+
+	occupied, err := o.guestListService.GetOccupiedSeats(ctx)
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to check occupancy")
+		return
+	}
+
+	emptySeats, err := o.tableService.GetEmptySeats(ctx)
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to check occupancy")
+		return
+	}
+
+	data := OccupancyData{Threshold: o.threshold, SeatsTotal: emptySeats}
+	for _, table := range occupied {
+		data.SeatsTotal += int64(table.Seats)
+		data.SeatsTaken += 1 + int64(table.Guest.AccompanyingGuests)
+	}
+
+	if data.SeatsTotal > 0 {
+		data.Occupancy = float64(data.SeatsTaken) / float64(data.SeatsTotal)
+	}
+
+	above := data.Occupancy > o.threshold
+
+	o.mu.Lock()
+	crossed := o.above != nil && !*o.above && above
+	o.above = &above
+	o.mu.Unlock()
+
+	if !crossed {
+		return
+	}
+
+	// derived from mutations that are already committed, so it is stored in the outbox on
+	// its own and a failure is only logged
+	event, err := domain.NewEvent(domain.EventOccupancyExceeded, venue.Now(), data)
+	if err == nil {
+		err = o.outboxService.Publish(ctx, event)
+	}
+
+	if err != nil {
+		log.FromContext(ctx).WithError(err).WithField("event", domain.EventOccupancyExceeded).Error("failed to publish event")
+	}
+}
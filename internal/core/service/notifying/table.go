@@ -0,0 +1,64 @@
+package notifying
+
+import (
+	"context"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+)
+
+type TableService struct {
+	next      port.TableService
+	occupancy *Occupancy
+}
+
+// NewTableService checks the occupancy of the venue after every table mutation, they seat and free guests
+// or change the number of seats
+func NewTableService(next port.TableService, occupancy *Occupancy) port.TableService {
+	return &TableService{next: next, occupancy: occupancy}
+}
+
+func (s *TableService) GetById(ctx context.Context, id int64) (*domain.Table, error) {
+	return s.next.GetById(ctx, id)
+}
+
+func (s *TableService) GetList(ctx context.Context, filter port.GetTableFilter) ([]*domain.Table, error) {
+	return s.next.GetList(ctx, filter)
+}
+
+func (s *TableService) GetEmptySeats(ctx context.Context) (int64, error) {
+	return s.next.GetEmptySeats(ctx)
+}
+
+func (s *TableService) Create(ctx context.Context, t *domain.Table) (*domain.Table, error) {
+	table, err := s.next.Create(ctx, t)
+	if err == nil {
+		s.occupancy.check(ctx)
+	}
+
+	return table, err
+}
+
+func (s *TableService) Update(ctx context.Context, id int64, table domain.Table) error {
+	return s.checked(ctx, s.next.Update(ctx, id, table))
+}
+
+func (s *TableService) Patch(ctx context.Context, id int64, patch port.TablePatch) error {
+	return s.checked(ctx, s.next.Patch(ctx, id, patch))
+}
+
+func (s *TableService) Delete(ctx context.Context, id int64, version int64) error {
+	return s.checked(ctx, s.next.Delete(ctx, id, version))
+}
+
+func (s *TableService) Restore(ctx context.Context, id int64) error {
+	return s.checked(ctx, s.next.Restore(ctx, id))
+}
+
+func (s *TableService) checked(ctx context.Context, err error) error {
+	if err == nil {
+		s.occupancy.check(ctx)
+	}
+
+	return err
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	"github.com/eazygood/getground-app/internal/venue"
+)
+
+// OutboxRetry schedules the publication of the messages: a message a sink failed to take is
+// retried after InitialBackoff, doubled on every attempt up to MaxBackoff, as long as it takes
+type OutboxRetry struct {
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// BatchSize is how many pending messages are published by Dispatch
+	BatchSize int
+}
+
+type OutboxService struct {
+	repository port.OutboxRepository
+	sinks      []port.EventSink
+	retry      OutboxRetry
+	now        func() time.Time
+}
+
+func NewOutboxService(repository port.OutboxRepository, sinks []port.EventSink, retry OutboxRetry) port.OutboxService {
+	return &OutboxService{
+		repository: repository,
+		sinks:      sinks,
+		retry:      retry,
+		now:        venue.Now,
+	}
+}
+
+func (srv *OutboxService) Publish(ctx context.Context, event domain.Event) error {
+	if err := srv.repository.Add(ctx, event); err != nil {
+		return fmt.Errorf("publish %s event: %w", event.Type, err)
+	}
+
+	return nil
+}
+
+func (srv *OutboxService) Dispatch(ctx context.Context) (int, error) {
+	messages, err := srv.repository.GetPending(ctx, srv.now(), srv.retry.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("get pending outbox messages: %w", err)
+	}
+
+	for _, message := range messages {
+		srv.publish(ctx, message)
+
+		if err := srv.repository.Update(ctx, message); err != nil {
+			return 0, fmt.Errorf("update outbox message: %w", err)
+		}
+	}
+
+	return len(messages), nil
+}
+
+// publish hands the message to every sink and records the outcome on it. A message is published
+// once all the sinks took it, until then it is handed to all of them again, which they tolerate
+// as they deduplicate events by id
+func (srv *OutboxService) publish(ctx context.Context, message *domain.OutboxMessage) {
+	message.Attempts++
+
+	var failures []string
+	event, err := message.Event()
+	if err != nil {
+		failures = append(failures, err.Error())
+	} else {
+		for _, sink := range srv.sinks {
+			if err := sink.Publish(ctx, event); err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", sink.Name(), err))
+			}
+		}
+	}
+
+	now := srv.now()
+
+	if len(failures) == 0 {
+		message.LastError = ""
+		message.PublishedAt = &now
+		return
+	}
+
+	message.LastError = strings.Join(failures, "; ")
+	message.NextAttemptAt = now.Add(backoff(srv.retry.InitialBackoff, srv.retry.MaxBackoff, message.Attempts))
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	ports "github.com/eazygood/getground-app/mocks/core/port"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type OutboxServiceSuite struct {
+	suite.Suite
+	*require.Assertions
+	ctrl                 *gomock.Controller
+	mockOutboxRepository *ports.MockOutboxRepository
+	mockWebhookSink      *ports.MockEventSink
+	mockLogSink          *ports.MockEventSink
+	outboxService        *OutboxService
+	now                  time.Time
+}
+
+func TestOutboxServiceSuite(t *testing.T) {
+	suite.Run(t, new(OutboxServiceSuite))
+}
+
+func (o *OutboxServiceSuite) SetupTest() {
+	o.Assertions = require.New(o.T())
+	o.ctrl = gomock.NewController(o.T())
+	o.mockOutboxRepository = ports.NewMockOutboxRepository(o.ctrl)
+	o.mockWebhookSink = ports.NewMockEventSink(o.ctrl)
+	o.mockLogSink = ports.NewMockEventSink(o.ctrl)
+	o.now = time.Date(2023, 1, 20, 19, 30, 0, 0, time.UTC)
+
+	o.mockWebhookSink.EXPECT().Name().Return("webhooks").AnyTimes()
+	o.mockLogSink.EXPECT().Name().Return("log").AnyTimes()
+
+	o.outboxService = NewOutboxService(o.mockOutboxRepository, []port.EventSink{o.mockWebhookSink, o.mockLogSink}, OutboxRetry{
+		InitialBackoff: time.Second,
+		MaxBackoff:     time.Minute,
+		BatchSize:      10,
+	}).(*OutboxService)
+	o.outboxService.now = func() time.Time { return o.now }
+}
+
+func (o *OutboxServiceSuite) TearDownTest() {
+	o.ctrl.Finish()
+}
+
+func (o *OutboxServiceSuite) message(eventType string) (*domain.OutboxMessage, domain.Event) {
+	event, err := domain.NewEvent(eventType, o.now, domain.GuestLeft{GuestID: 1})
+	o.NoError(err)
+
+	message, err := domain.NewOutboxMessage(event)
+	o.NoError(err)
+
+	return message, event
+}
+
+func (o *OutboxServiceSuite) TestPublishStoresEvent() {
+	ctx := context.Background()
+	_, event := o.message(domain.EventOccupancyExceeded)
+
+	o.mockOutboxRepository.EXPECT().Add(ctx, event).Return(nil).Times(1)
+
+	o.NoError(o.outboxService.Publish(ctx, event))
+}
+
+func (o *OutboxServiceSuite) TestDispatchToEverySink() {
+	ctx := context.Background()
+	message, event := o.message(domain.EventGuestLeft)
+
+	o.mockOutboxRepository.EXPECT().GetPending(ctx, o.now, 10).Return([]*domain.OutboxMessage{message}, nil).Times(1)
+	o.mockWebhookSink.EXPECT().Publish(ctx, event).Return(nil).Times(1)
+	o.mockLogSink.EXPECT().Publish(ctx, event).Return(nil).Times(1)
+	o.mockOutboxRepository.EXPECT().Update(ctx, message).Return(nil).Times(1)
+
+	dispatched, err := o.outboxService.Dispatch(ctx)
+
+	o.NoError(err)
+	o.Equal(1, dispatched)
+	o.Equal(o.now, *message.PublishedAt)
+	o.Equal(1, message.Attempts)
+	o.Empty(message.LastError)
+}
+
+func (o *OutboxServiceSuite) TestDispatchRetriesWhenASinkFails() {
+	ctx := context.Background()
+	message, event := o.message(domain.EventGuestLeft)
+	message.Attempts = 2
+
+	o.mockOutboxRepository.EXPECT().GetPending(ctx, o.now, 10).Return([]*domain.OutboxMessage{message}, nil).Times(1)
+	o.mockWebhookSink.EXPECT().Publish(ctx, event).Return(errors.New("database is gone")).Times(1)
+	o.mockLogSink.EXPECT().Publish(ctx, event).Return(nil).Times(1)
+	o.mockOutboxRepository.EXPECT().Update(ctx, message).Return(nil).Times(1)
+
+	_, err := o.outboxService.Dispatch(ctx)
+
+	o.NoError(err)
+	o.Nil(message.PublishedAt)
+	o.Equal(3, message.Attempts)
+	o.Equal("webhooks: database is gone", message.LastError)
+	o.Equal(o.now.Add(4*time.Second), message.NextAttemptAt)
+}
+
+func (o *OutboxServiceSuite) TestDispatchThrowError() {
+	ctx := context.Background()
+
+	o.mockOutboxRepository.EXPECT().GetPending(ctx, o.now, 10).Return(nil, errors.New("Mock Repository Error")).Times(1)
+
+	_, err := o.outboxService.Dispatch(ctx)
+
+	o.ErrorContains(err, "Mock Repository Error")
+}
@@ -0,0 +1,28 @@
+package overbooking
+
+import (
+	"context"
+	"sync"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+)
+
+type CheckinService struct {
+	next       port.CheckinService
+	admissions *sync.Mutex
+}
+
+// NewCheckinService checks in one guest at a time, and not while an acceptance is checked: the forecast a
+// walk-in is admitted by then counts every guest seated before them. Like the acceptances, the check-ins
+// wait for each other within an instance only
+func NewCheckinService(next port.CheckinService, admissions *sync.Mutex) port.CheckinService {
+	return &CheckinService{next: next, admissions: admissions}
+}
+
+func (s *CheckinService) CheckIn(ctx context.Context, guestID int64, accompanyingGuests uint16) (*domain.Table, error) {
+	s.admissions.Lock()
+	defer s.admissions.Unlock()
+
+	return s.next.CheckIn(ctx, guestID, accompanyingGuests)
+}
@@ -0,0 +1,88 @@
+package overbooking
+
+import (
+	"context"
+	"sync"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+)
+
+type GuestService struct {
+	next    port.GuestService
+	reports port.ReportService
+
+	// acceptances are checked and recorded one at a time, two of them cannot both take the last seats.
+	// Each instance holds its own, so the cap is best-effort when several instances take RSVPs.
+	admissions *sync.Mutex
+}
+
+// NewGuestService turns down the acceptances that would bring the forecast attendance over the capacity of
+// the overbooking policy. The forecast reads every guest and table for each acceptance, which parties of a
+// few thousand guests afford. admissions is shared with the check-ins, see NewCheckinService
+func NewGuestService(next port.GuestService, reports port.ReportService, admissions *sync.Mutex) port.GuestService {
+	return &GuestService{next: next, reports: reports, admissions: admissions}
+}
+
+func (s *GuestService) Create(ctx context.Context, g *domain.Guest) (*domain.Guest, error) {
+	return s.next.Create(ctx, g)
+}
+
+func (s *GuestService) Update(ctx context.Context, id int64, u *domain.Guest) error {
+	return s.next.Update(ctx, id, u)
+}
+
+func (s *GuestService) Patch(ctx context.Context, id int64, patch port.GuestPatch) error {
+	return s.next.Patch(ctx, id, patch)
+}
+
+func (s *GuestService) Delete(ctx context.Context, id int64, version int64) error {
+	return s.next.Delete(ctx, id, version)
+}
+
+func (s *GuestService) Restore(ctx context.Context, id int64) error {
+	return s.next.Restore(ctx, id)
+}
+
+func (s *GuestService) GetById(ctx context.Context, id int64) (*domain.Guest, error) {
+	return s.next.GetById(ctx, id)
+}
+
+func (s *GuestService) GetList(ctx context.Context, filter port.GetGuestFilter) ([]*domain.Guest, error) {
+	return s.next.GetList(ctx, filter)
+}
+
+func (s *GuestService) RSVP(ctx context.Context, id int64, rsvp domain.RSVP) (*domain.Guest, error) {
+	if rsvp.Status != domain.GuestStatusAccepted {
+		return s.next.RSVP(ctx, id, rsvp)
+	}
+
+	s.admissions.Lock()
+	defer s.admissions.Unlock()
+
+	guest, err := s.next.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	forecast, err := s.reports.LatestForecast(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	people := forecast.Expect(int(rsvp.PartySize))
+	// a guest changing the size of their party is expected already
+	if guest.Status == domain.GuestStatusAccepted {
+		people -= forecast.Expect(int(guest.PartySize))
+	}
+
+	if !forecast.Admits(people) {
+		return nil, &domain.OverbookedError{Expected: forecast.ExpectedPeople + people, Capacity: forecast.Capacity}
+	}
+
+	return s.next.RSVP(ctx, id, rsvp)
+}
+
+func (s *GuestService) MarkNoShow(ctx context.Context, id int64) (*domain.Guest, error) {
+	return s.next.MarkNoShow(ctx, id)
+}
@@ -0,0 +1,51 @@
+package overbooking
+
+import (
+	"context"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+)
+
+type GuestListService struct {
+	next    port.GuestListService
+	guests  port.GuestService
+	reports port.ReportService
+}
+
+// NewGuestListService holds the seats the accepted parties are expected to take back from the guests who
+// turn up without having accepted, as far as the overbooking policy allows. The guest is seated after the
+// check, the check-in wrapped by NewCheckinService keeps a second walk-in waiting until then
+func NewGuestListService(next port.GuestListService, guests port.GuestService, reports port.ReportService) port.GuestListService {
+	return &GuestListService{next: next, guests: guests, reports: reports}
+}
+
+func (s *GuestListService) FindAvailableTable(ctx context.Context, filter port.GetGuestListFilter) (*domain.Table, error) {
+	if filter.GuestID == 0 {
+		return s.next.FindAvailableTable(ctx, filter)
+	}
+
+	guest, err := s.guests.GetById(ctx, filter.GuestID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the accepted parties are expected by the forecast already
+	if guest.Status != domain.GuestStatusAccepted {
+		forecast, err := s.reports.LatestForecast(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		people := float64(1 + filter.AccompanyingGuests)
+		if !forecast.Admits(people) {
+			return nil, &domain.OverbookedError{Expected: forecast.ExpectedPeople + people, Capacity: forecast.Capacity}
+		}
+	}
+
+	return s.next.FindAvailableTable(ctx, filter)
+}
+
+func (s *GuestListService) GetOccupiedSeats(ctx context.Context) ([]*domain.Table, error) {
+	return s.next.GetOccupiedSeats(ctx)
+}
@@ -0,0 +1,241 @@
+package overbooking
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	ports "github.com/eazygood/getground-app/mocks/core/port"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type OverbookingSuite struct {
+	suite.Suite
+	*require.Assertions
+	ctrl                 *gomock.Controller
+	mockGuestService     *ports.MockGuestService
+	mockGuestListService *ports.MockGuestListService
+	mockReportService    *ports.MockReportService
+	mockCheckinService   *ports.MockCheckinService
+	guestService         port.GuestService
+	guestListService     port.GuestListService
+	checkinService       port.CheckinService
+}
+
+func TestOverbookingSuite(t *testing.T) {
+	suite.Run(t, new(OverbookingSuite))
+}
+
+func (o *OverbookingSuite) SetupTest() {
+	o.Assertions = require.New(o.T())
+	o.ctrl = gomock.NewController(o.T())
+	o.mockGuestService = ports.NewMockGuestService(o.ctrl)
+	o.mockGuestListService = ports.NewMockGuestListService(o.ctrl)
+	o.mockReportService = ports.NewMockReportService(o.ctrl)
+	o.mockCheckinService = ports.NewMockCheckinService(o.ctrl)
+
+	admissions := &sync.Mutex{}
+	o.guestService = NewGuestService(o.mockGuestService, o.mockReportService, admissions)
+	o.guestListService = NewGuestListService(o.mockGuestListService, o.mockGuestService, o.mockReportService)
+	o.checkinService = NewCheckinService(o.mockCheckinService, admissions)
+}
+
+func (o *OverbookingSuite) TearDownTest() {
+	o.ctrl.Finish()
+}
+
+// expectForecast expects 7 people at 8 seats booked up to 10 people, with half of the accepted parties not showing up
+func (o *OverbookingSuite) expectForecast(ctx context.Context) {
+	o.mockReportService.EXPECT().LatestForecast(ctx).Return(&domain.Forecast{
+		Seats:          8,
+		NoShowRate:     0.5,
+		EntourageRatio: 1,
+		ExpectedPeople: 7,
+		Ratio:          1.25,
+		Capacity:       10,
+	}, nil).Times(1)
+}
+
+func (o *OverbookingSuite) TestAcceptanceWithinCapacity() {
+	ctx := context.Background()
+	rsvp := domain.RSVP{Status: domain.GuestStatusAccepted, PartySize: 6}
+
+	o.mockGuestService.EXPECT().GetById(ctx, int64(1)).Return(&domain.Guest{ID: 1, Status: domain.GuestStatusInvited}, nil).Times(1)
+	o.expectForecast(ctx)
+	o.mockGuestService.EXPECT().RSVP(ctx, int64(1), rsvp).Return(&domain.Guest{ID: 1, Status: domain.GuestStatusAccepted}, nil).Times(1)
+
+	// half of the 6 people are expected
+	guest, err := o.guestService.RSVP(ctx, 1, rsvp)
+	o.NoError(err)
+	o.Equal(domain.GuestStatusAccepted, guest.Status)
+}
+
+func (o *OverbookingSuite) TestAcceptanceOverCapacity() {
+	ctx := context.Background()
+
+	o.mockGuestService.EXPECT().GetById(ctx, int64(1)).Return(&domain.Guest{ID: 1, Status: domain.GuestStatusTentative, PartySize: 2}, nil).Times(1)
+	o.expectForecast(ctx)
+
+	_, err := o.guestService.RSVP(ctx, 1, domain.RSVP{Status: domain.GuestStatusAccepted, PartySize: 8})
+
+	var overbooked *domain.OverbookedError
+	o.ErrorAs(err, &overbooked)
+	o.EqualError(err, "forecast attendance of 11.0 people would exceed the capacity of 10.0")
+}
+
+func (o *OverbookingSuite) TestAcceptedGuestGrowingTheirParty() {
+	ctx := context.Background()
+	rsvp := domain.RSVP{Status: domain.GuestStatusAccepted, PartySize: 10}
+
+	// 2 of the 7 expected people are theirs already
+	o.mockGuestService.EXPECT().GetById(ctx, int64(1)).Return(&domain.Guest{ID: 1, Status: domain.GuestStatusAccepted, PartySize: 4}, nil).Times(1)
+	o.expectForecast(ctx)
+	o.mockGuestService.EXPECT().RSVP(ctx, int64(1), rsvp).Return(&domain.Guest{ID: 1}, nil).Times(1)
+
+	_, err := o.guestService.RSVP(ctx, 1, rsvp)
+	o.NoError(err)
+}
+
+func (o *OverbookingSuite) TestAcceptancesAreCheckedOneAtATime() {
+	ctx := context.Background()
+	rsvp := domain.RSVP{Status: domain.GuestStatusAccepted, PartySize: 2}
+
+	var mu sync.Mutex
+	var calls []string
+	record := func(call string) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, call)
+	}
+
+	entered := make(chan struct{}, 2)
+	o.mockGuestService.EXPECT().GetById(ctx, gomock.Any()).Return(&domain.Guest{Status: domain.GuestStatusInvited}, nil).Times(2)
+	o.mockReportService.EXPECT().LatestForecast(ctx).
+		DoAndReturn(func(context.Context) (*domain.Forecast, error) {
+			record("forecast")
+			return &domain.Forecast{Seats: 8, EntourageRatio: 1, Ratio: 1.25, Capacity: 10}, nil
+		}).Times(2)
+	o.mockGuestService.EXPECT().RSVP(ctx, gomock.Any(), rsvp).
+		DoAndReturn(func(context.Context, int64, domain.RSVP) (*domain.Guest, error) {
+			record("rsvp")
+			entered <- struct{}{}
+			// the other acceptance would be forecast meanwhile without the lock
+			time.Sleep(20 * time.Millisecond)
+			record("accepted")
+			return &domain.Guest{Status: domain.GuestStatusAccepted}, nil
+		}).Times(2)
+
+	errs := make(chan error, 2)
+	accept := func(id int64) {
+		_, err := o.guestService.RSVP(ctx, id, rsvp)
+		errs <- err
+	}
+
+	go accept(1)
+	<-entered
+	go accept(2)
+	o.NoError(<-errs)
+	o.NoError(<-errs)
+
+	o.Equal([]string{"forecast", "rsvp", "accepted", "forecast", "rsvp", "accepted"}, calls)
+}
+
+func (o *OverbookingSuite) TestDeclineIsNotChecked() {
+	ctx := context.Background()
+	rsvp := domain.RSVP{Status: domain.GuestStatusDeclined}
+
+	o.mockGuestService.EXPECT().RSVP(ctx, int64(1), rsvp).Return(&domain.Guest{ID: 1}, nil).Times(1)
+
+	_, err := o.guestService.RSVP(ctx, 1, rsvp)
+	o.NoError(err)
+}
+
+func (o *OverbookingSuite) TestWalkInIsHeldBack() {
+	ctx := context.Background()
+	filter := port.GetGuestListFilter{AccompanyingGuests: 3, GuestID: 1}
+
+	o.mockGuestService.EXPECT().GetById(ctx, int64(1)).Return(&domain.Guest{ID: 1, Status: domain.GuestStatusInvited}, nil).Times(1)
+	o.expectForecast(ctx)
+
+	_, err := o.guestListService.FindAvailableTable(ctx, filter)
+
+	var overbooked *domain.OverbookedError
+	o.ErrorAs(err, &overbooked)
+}
+
+func (o *OverbookingSuite) TestWalkInWithinCapacity() {
+	ctx := context.Background()
+	filter := port.GetGuestListFilter{AccompanyingGuests: 2, GuestID: 1}
+	table := &domain.Table{ID: 2, Seats: 4}
+
+	o.mockGuestService.EXPECT().GetById(ctx, int64(1)).Return(&domain.Guest{ID: 1, Status: domain.GuestStatusTentative}, nil).Times(1)
+	o.expectForecast(ctx)
+	o.mockGuestListService.EXPECT().FindAvailableTable(ctx, filter).Return(table, nil).Times(1)
+
+	actual, err := o.guestListService.FindAvailableTable(ctx, filter)
+	o.NoError(err)
+	o.Equal(table, actual)
+}
+
+func (o *OverbookingSuite) TestAcceptedGuestIsSeatedWhateverTheForecast() {
+	ctx := context.Background()
+	filter := port.GetGuestListFilter{AccompanyingGuests: 9, GuestID: 1}
+	table := &domain.Table{ID: 2, Seats: 10}
+
+	o.mockGuestService.EXPECT().GetById(ctx, int64(1)).Return(&domain.Guest{ID: 1, Status: domain.GuestStatusAccepted, PartySize: 4}, nil).Times(1)
+	o.mockGuestListService.EXPECT().FindAvailableTable(ctx, filter).Return(table, nil).Times(1)
+
+	actual, err := o.guestListService.FindAvailableTable(ctx, filter)
+	o.NoError(err)
+	o.Equal(table, actual)
+}
+
+func (o *OverbookingSuite) TestCheckInWaitsForTheAcceptance() {
+	ctx := context.Background()
+	rsvp := domain.RSVP{Status: domain.GuestStatusAccepted, PartySize: 2}
+
+	var mu sync.Mutex
+	var calls []string
+	record := func(call string) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, call)
+	}
+
+	entered := make(chan struct{}, 1)
+	o.mockGuestService.EXPECT().GetById(ctx, int64(1)).Return(&domain.Guest{ID: 1, Status: domain.GuestStatusInvited}, nil).Times(1)
+	o.expectForecast(ctx)
+	o.mockGuestService.EXPECT().RSVP(ctx, int64(1), rsvp).
+		DoAndReturn(func(context.Context, int64, domain.RSVP) (*domain.Guest, error) {
+			entered <- struct{}{}
+			// the walk-in would be forecast without counting this party meanwhile without the lock
+			time.Sleep(20 * time.Millisecond)
+			record("accepted")
+			return &domain.Guest{ID: 1, Status: domain.GuestStatusAccepted}, nil
+		}).Times(1)
+	o.mockCheckinService.EXPECT().CheckIn(ctx, int64(2), uint16(1)).
+		DoAndReturn(func(context.Context, int64, uint16) (*domain.Table, error) {
+			record("checked in")
+			return &domain.Table{ID: 3}, nil
+		}).Times(1)
+
+	errs := make(chan error, 2)
+	go func() {
+		_, err := o.guestService.RSVP(ctx, 1, rsvp)
+		errs <- err
+	}()
+	<-entered
+	go func() {
+		_, err := o.checkinService.CheckIn(ctx, 2, 1)
+		errs <- err
+	}()
+	o.NoError(<-errs)
+	o.NoError(<-errs)
+
+	o.Equal([]string{"accepted", "checked in"}, calls)
+}
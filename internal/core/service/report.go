@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+)
+
+type ReportService struct {
+	guestRepository     port.GuestRepository
+	guestListRepository port.GuesListRepository
+	companionRepository port.CompanionRepository
+	tableRepository     port.TableRepository
+	overbooking         domain.OverbookingPolicy
+}
+
+func NewReportService(
+	guests port.GuestRepository,
+	guestList port.GuesListRepository,
+	companions port.CompanionRepository,
+	tables port.TableRepository,
+	overbooking domain.OverbookingPolicy,
+) port.ReportService {
+	return &ReportService{
+		guestRepository:     guests,
+		guestListRepository: guestList,
+		companionRepository: companions,
+		tableRepository:     tables,
+		overbooking:         overbooking,
+	}
+}
+
+// dietaryStatuses are the statuses of the guests who are there or may still come, the kitchen does not cook
+// for the guests who declined or did not turn up
+var dietaryStatuses = map[string]bool{
+	domain.GuestStatusInvited:   true,
+	domain.GuestStatusAccepted:  true,
+	domain.GuestStatusTentative: true,
+	domain.GuestStatusArrived:   true,
+}
+
+func (r *ReportService) Dietary(ctx context.Context) (*domain.DietaryReport, error) {
+	tables, err := r.guestListRepository.GetOccupiedSeats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get dietary report: %w", err)
+	}
+
+	all, err := r.guestRepository.GetAll(ctx, port.GetGuestFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("get dietary report: %w", err)
+	}
+
+	guests := make([]*domain.Guest, 0, len(all))
+	ids := make([]int64, 0, len(all))
+	for _, guest := range all {
+		if dietaryStatuses[guest.Status] {
+			guests = append(guests, guest)
+			ids = append(ids, guest.ID)
+		}
+	}
+
+	companions := map[int64][]*domain.Companion{}
+	if len(ids) > 0 {
+		all, err := r.companionRepository.GetAll(ctx, ids...)
+		if err != nil {
+			return nil, fmt.Errorf("get dietary report: %w", err)
+		}
+
+		for _, companion := range all {
+			if companion.LeftAt == nil {
+				companions[companion.GuestID] = append(companions[companion.GuestID], companion)
+			}
+		}
+	}
+
+	sort.Slice(tables, func(i, j int) bool { return tables[i].ID < tables[j].ID })
+
+	report := &domain.DietaryReport{
+		Tables:   make([]domain.DietaryGroup, 0, len(tables)),
+		Unseated: newDietaryGroup(nil),
+		Totals:   map[string]int{},
+	}
+
+	seated := map[int64]bool{}
+	for _, table := range tables {
+		if !dietaryStatuses[table.Guest.Status] {
+			continue
+		}
+
+		id := table.ID
+		group := newDietaryGroup(&id)
+		addToDietaryGroup(&group, report.Totals, &table.Guest, companions[table.Guest.ID])
+
+		report.Tables = append(report.Tables, group)
+		seated[table.Guest.ID] = true
+	}
+
+	for _, guest := range guests {
+		if !seated[guest.ID] {
+			addToDietaryGroup(&report.Unseated, report.Totals, guest, companions[guest.ID])
+		}
+	}
+
+	return report, nil
+}
+
+func newDietaryGroup(tableID *int64) domain.DietaryGroup {
+	return domain.DietaryGroup{
+		TableID: tableID,
+		Counts:  map[string]int{},
+		Guests:  []domain.DietaryGuest{},
+	}
+}
+
+// addToDietaryGroup counts guest and their accompanying guests, the requirements are known for the guest
+// and their named companions only
+func addToDietaryGroup(group *domain.DietaryGroup, totals map[string]int, guest *domain.Guest, companions []*domain.Companion) {
+	group.People += 1 + int(guest.AccompanyingGuests)
+
+	addDietaryGuest(group, totals, domain.DietaryGuest{
+		GuestID:      guest.ID,
+		Name:         guest.Name,
+		Dietary:      guest.Dietary,
+		DietaryNotes: guest.DietaryNotes,
+	})
+
+	for _, companion := range companions {
+		id := companion.ID
+		addDietaryGuest(group, totals, domain.DietaryGuest{
+			GuestID:      guest.ID,
+			CompanionID:  &id,
+			Name:         companion.Name,
+			Dietary:      companion.Dietary,
+			DietaryNotes: companion.DietaryNotes,
+		})
+	}
+}
+
+func addDietaryGuest(group *domain.DietaryGroup, totals map[string]int, person domain.DietaryGuest) {
+	for _, requirement := range person.Dietary {
+		group.Counts[requirement]++
+		totals[requirement]++
+	}
+
+	if len(person.Dietary) > 0 || person.DietaryNotes != "" {
+		group.Guests = append(group.Guests, person)
+	}
+}
+
+func (r *ReportService) Capacity(ctx context.Context) (*domain.CapacityReport, error) {
+	// the guests who left are counted in the statuses
+	guests, err := r.guestRepository.GetAll(ctx, port.GetGuestFilter{IncludeDeleted: true})
+	if err != nil {
+		return nil, fmt.Errorf("get capacity report: %w", err)
+	}
+
+	tables, err := r.tableRepository.GetAll(ctx, port.GetTableFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("get capacity report: %w", err)
+	}
+
+	report := &domain.CapacityReport{Tables: len(tables), Statuses: map[string]int{}}
+	for _, status := range domain.GuestStatuses {
+		report.Statuses[status] = 0
+	}
+
+	seats := make([]int, 0, len(tables))
+	for _, table := range tables {
+		seats = append(seats, int(table.Seats))
+		report.Seats += int(table.Seats)
+	}
+
+	var parties []int
+	for _, guest := range guests {
+		report.Statuses[guest.Status]++
+
+		switch guest.Status {
+		case domain.GuestStatusAccepted:
+			parties = append(parties, int(guest.PartySize))
+		case domain.GuestStatusArrived:
+			parties = append(parties, 1+int(guest.AccompanyingGuests))
+		case domain.GuestStatusTentative:
+			report.TentativeParties++
+			report.TentativePeople += int(guest.PartySize)
+		}
+	}
+
+	report.Parties = len(parties)
+	for _, size := range parties {
+		report.People += size
+	}
+
+	report.FreeSeats = report.Seats - report.People
+	report.Unseatable = len(parties) - seatParties(parties, seats)
+
+	return report, nil
+}
+
+// Forecast predicts the attendance from the outcomes of the guests who accepted so far, the ones who left
+// included since they arrived
+func (r *ReportService) Forecast(ctx context.Context) (*domain.Forecast, error) {
+	return r.forecast(ctx, false)
+}
+
+func (r *ReportService) LatestForecast(ctx context.Context) (*domain.Forecast, error) {
+	return r.forecast(ctx, true)
+}
+
+func (r *ReportService) forecast(ctx context.Context, latest bool) (*domain.Forecast, error) {
+	guests, err := r.guestRepository.GetAll(ctx, port.GetGuestFilter{IncludeDeleted: true, Latest: latest})
+	if err != nil {
+		return nil, fmt.Errorf("get forecast: %w", err)
+	}
+
+	tables, err := r.tableRepository.GetAll(ctx, port.GetTableFilter{Latest: latest})
+	if err != nil {
+		return nil, fmt.Errorf("get forecast: %w", err)
+	}
+
+	seats := 0
+	for _, table := range tables {
+		seats += int(table.Seats)
+	}
+
+	return domain.NewForecast(guests, seats, r.overbooking), nil
+}
+
+// seatParties counts how many parties get a table of their own with enough seats. Going through the parties
+// from the smallest, each taking the smallest table it fits, seats as many parties as possible.
+func seatParties(parties []int, seats []int) int {
+	sort.Ints(parties)
+	sort.Ints(seats)
+
+	seated, table := 0, 0
+	for _, size := range parties {
+		for table < len(seats) && seats[table] < size {
+			table++
+		}
+
+		if table == len(seats) {
+			break
+		}
+
+		seated++
+		table++
+	}
+
+	return seated
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	ports "github.com/eazygood/getground-app/mocks/core/port"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDietaryReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	guests := ports.NewMockGuestRepository(ctrl)
+	guestList := ports.NewMockGuesListRepository(ctrl)
+	companions := ports.NewMockCompanionRepository(ctrl)
+	ctx := context.Background()
+
+	ada := domain.Guest{ID: 1, Name: "Ada", AccompanyingGuests: 2, Dietary: domain.Dietary{domain.DietaryVegan}, DietaryNotes: "no sesame", Status: domain.GuestStatusArrived}
+	bob := domain.Guest{ID: 2, Name: "Bob", AccompanyingGuests: 1, Status: domain.GuestStatusArrived}
+	eve := domain.Guest{ID: 3, Name: "Eve", Dietary: domain.Dietary{domain.DietaryVegan, domain.DietaryHalal}, Status: domain.GuestStatusAccepted}
+	dan := domain.Guest{ID: 8, Name: "Dan", Dietary: domain.Dietary{domain.DietaryHalal}, Status: domain.GuestStatusDeclined}
+	fay := domain.Guest{ID: 9, Name: "Fay", Dietary: domain.Dietary{domain.DietaryVegan}, Status: domain.GuestStatusNoShow}
+
+	guestList.EXPECT().GetOccupiedSeats(ctx).Return([]*domain.Table{{ID: 7, Guest: bob}, {ID: 4, Guest: ada}}, nil).Times(1)
+	guests.EXPECT().GetAll(ctx, port.GetGuestFilter{}).Return([]*domain.Guest{&ada, &bob, &eve, &dan, &fay}, nil).Times(1)
+
+	left := time.Now()
+	companions.EXPECT().GetAll(ctx, int64(1), int64(2), int64(3)).Return([]*domain.Companion{
+		{ID: 5, GuestID: 2, Name: "Carl", Dietary: domain.Dietary{domain.DietaryHalal}},
+		{ID: 6, GuestID: 2, Name: "Dana", Dietary: domain.Dietary{domain.DietaryVegan}, LeftAt: &left},
+	}, nil).Times(1)
+
+	report, err := NewReportService(guests, guestList, companions, nil, domain.OverbookingPolicy{}).Dietary(ctx)
+	require.NoError(t, err)
+
+	tableAda, tableBob, carl := int64(4), int64(7), int64(5)
+	require.Equal(t, &domain.DietaryReport{
+		Tables: []domain.DietaryGroup{
+			{
+				TableID: &tableAda,
+				People:  3,
+				Counts:  map[string]int{domain.DietaryVegan: 1},
+				Guests:  []domain.DietaryGuest{{GuestID: 1, Name: "Ada", Dietary: ada.Dietary, DietaryNotes: "no sesame"}},
+			},
+			{
+				TableID: &tableBob,
+				People:  2,
+				Counts:  map[string]int{domain.DietaryHalal: 1},
+				Guests:  []domain.DietaryGuest{{GuestID: 2, CompanionID: &carl, Name: "Carl", Dietary: domain.Dietary{domain.DietaryHalal}}},
+			},
+		},
+		Unseated: domain.DietaryGroup{
+			People: 1,
+			Counts: map[string]int{domain.DietaryVegan: 1, domain.DietaryHalal: 1},
+			Guests: []domain.DietaryGuest{{GuestID: 3, Name: "Eve", Dietary: eve.Dietary}},
+		},
+		Totals: map[string]int{domain.DietaryVegan: 2, domain.DietaryHalal: 2},
+	}, report)
+}
+
+func TestCapacityReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	guests := ports.NewMockGuestRepository(ctrl)
+	tables := ports.NewMockTableRepository(ctrl)
+	ctx := context.Background()
+
+	guests.EXPECT().GetAll(ctx, port.GetGuestFilter{IncludeDeleted: true}).Return([]*domain.Guest{
+		{ID: 1, Status: domain.GuestStatusAccepted, PartySize: 4},
+		{ID: 2, Status: domain.GuestStatusAccepted, PartySize: 2},
+		{ID: 3, Status: domain.GuestStatusArrived, AccompanyingGuests: 1},
+		{ID: 4, Status: domain.GuestStatusTentative, PartySize: 3},
+		{ID: 5, Status: domain.GuestStatusDeclined},
+		{ID: 6, Status: domain.GuestStatusInvited},
+		{ID: 7, Status: domain.GuestStatusLeft, AccompanyingGuests: 5},
+	}, nil).Times(1)
+	tables.EXPECT().GetAll(ctx, port.GetTableFilter{}).Return([]*domain.Table{{ID: 1, Seats: 2}, {ID: 2, Seats: 3}, {ID: 3, Seats: 3}}, nil).Times(1)
+
+	report, err := NewReportService(guests, nil, nil, tables, domain.OverbookingPolicy{}).Capacity(ctx)
+	require.NoError(t, err)
+
+	require.Equal(t, &domain.CapacityReport{
+		Tables: 3,
+		Seats:  8,
+		Statuses: map[string]int{
+			domain.GuestStatusInvited:   1,
+			domain.GuestStatusAccepted:  2,
+			domain.GuestStatusDeclined:  1,
+			domain.GuestStatusTentative: 1,
+			domain.GuestStatusArrived:   1,
+			domain.GuestStatusLeft:      1,
+			domain.GuestStatusNoShow:    0,
+		},
+		Parties:          3,
+		People:           8,
+		TentativeParties: 1,
+		TentativePeople:  3,
+		FreeSeats:        0,
+		// the party of 4 fits no table
+		Unseatable: 1,
+	}, report)
+}
+
+func TestSeatParties(t *testing.T) {
+	require.Equal(t, 3, seatParties([]int{2, 3, 1}, []int{3, 1, 2}))
+	require.Equal(t, 1, seatParties([]int{2, 2}, []int{1, 2}))
+	require.Equal(t, 0, seatParties([]int{1}, nil))
+}
+
+func TestForecastReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	guests := ports.NewMockGuestRepository(ctrl)
+	tables := ports.NewMockTableRepository(ctrl)
+	ctx := context.Background()
+	arrived := time.Date(2023, 12, 15, 19, 30, 0, 0, time.UTC)
+
+	history := []*domain.Guest{
+		// accepted and came with fewer people than planned, then left
+		{ID: 1, Status: domain.GuestStatusLeft, PartySize: 4, AccompanyingGuests: 2, TimeArrived: &arrived},
+		{ID: 2, Status: domain.GuestStatusArrived, PartySize: 2, TimeArrived: &arrived},
+		// turned up without answering, present but telling nothing of the accepted parties
+		{ID: 3, Status: domain.GuestStatusArrived, AccompanyingGuests: 1, TimeArrived: &arrived},
+		{ID: 4, Status: domain.GuestStatusNoShow, PartySize: 3},
+		{ID: 5, Status: domain.GuestStatusNoShow},
+		{ID: 6, Status: domain.GuestStatusAccepted, PartySize: 5},
+		{ID: 7, Status: domain.GuestStatusAccepted, PartySize: 3},
+		{ID: 8, Status: domain.GuestStatusDeclined},
+	}
+
+	guests.EXPECT().GetAll(ctx, port.GetGuestFilter{IncludeDeleted: true}).Return(history, nil).Times(2)
+	tables.EXPECT().GetAll(ctx, port.GetTableFilter{}).Return([]*domain.Table{{ID: 1, Seats: 5}, {ID: 2, Seats: 3}}, nil).Times(2)
+
+	forecast, err := NewReportService(guests, nil, nil, tables, domain.OverbookingPolicy{Ratio: 1, MinSamples: 3}).Forecast(ctx)
+	require.NoError(t, err)
+
+	require.Equal(t, 3, forecast.Samples)
+	require.True(t, forecast.Trusted)
+	require.Equal(t, 2, forecast.Arrivals)
+	require.Equal(t, 1, forecast.NoShows)
+	require.Equal(t, 6, forecast.PlannedPeople)
+	require.Equal(t, 4, forecast.ActualPeople)
+	require.InDelta(t, 1.0/3, forecast.NoShowRate, 1e-9)
+	require.InDelta(t, 2.0/3, forecast.EntourageRatio, 1e-9)
+	require.Equal(t, 3, forecast.PresentPeople)
+	require.Equal(t, 2, forecast.PendingParties)
+	require.Equal(t, 8, forecast.PendingPeople)
+	// 3 present and 8 accepted, of which a third do not come and the rest bring two thirds of their party
+	require.InDelta(t, 3+8*2.0/3*2.0/3, forecast.ExpectedPeople, 1e-9)
+	require.Equal(t, 8.0, forecast.Capacity)
+	require.True(t, forecast.Admits(1))
+	require.False(t, forecast.Admits(2))
+
+	// too little history to go by, every accepted party is expected in full
+	forecast, err = NewReportService(guests, nil, nil, tables, domain.OverbookingPolicy{MinSamples: 4}).Forecast(ctx)
+	require.NoError(t, err)
+
+	require.False(t, forecast.Trusted)
+	require.Zero(t, forecast.NoShowRate)
+	require.Equal(t, 1.0, forecast.EntourageRatio)
+	require.Equal(t, 11.0, forecast.ExpectedPeople)
+	require.Zero(t, forecast.Capacity)
+	require.True(t, forecast.Admits(100))
+
+	// the forecast admitting a guest reads the guests and tables as last written
+	guests.EXPECT().GetAll(ctx, port.GetGuestFilter{IncludeDeleted: true, Latest: true}).Return(history, nil).Times(1)
+	tables.EXPECT().GetAll(ctx, port.GetTableFilter{Latest: true}).Return([]*domain.Table{{ID: 1, Seats: 5}, {ID: 2, Seats: 3}}, nil).Times(1)
+
+	forecast, err = NewReportService(guests, nil, nil, tables, domain.OverbookingPolicy{MinSamples: 4}).LatestForecast(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 11.0, forecast.ExpectedPeople)
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+)
+
+type GuestSearchService struct {
+	searcher port.GuestSearcher
+}
+
+func NewGuestSearchService(searcher port.GuestSearcher) port.GuestSearchService {
+	return &GuestSearchService{
+		searcher: searcher,
+	}
+}
+
+func (g *GuestSearchService) Search(ctx context.Context, query string, limit int) ([]*domain.GuestMatch, error) {
+	matches, err := g.searcher.Search(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("search guests: %w", err)
+	}
+
+	return matches, nil
+}
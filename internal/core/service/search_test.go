@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	ports "github.com/eazygood/getground-app/mocks/core/port"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuestSearch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	searcher := ports.NewMockGuestSearcher(ctrl)
+	ctx := context.Background()
+
+	want := []*domain.GuestMatch{{Guest: &domain.Guest{ID: 1, Name: "Jon"}, Score: 1}}
+	searcher.EXPECT().Search(ctx, "jon", 10).Return(want, nil).Times(1)
+
+	matches, err := NewGuestSearchService(searcher).Search(ctx, "jon", 10)
+	require.NoError(t, err)
+	require.Equal(t, want, matches)
+
+	searcher.EXPECT().Search(ctx, "jon", 10).Return(nil, errors.New("failed to search guests: timeout")).Times(1)
+
+	_, err = NewGuestSearchService(searcher).Search(ctx, "jon", 10)
+	require.EqualError(t, err, "search guests: failed to search guests: timeout")
+}
@@ -27,8 +27,8 @@ func (srv *TableService) Create(ctx context.Context, table *domain.Table) (*doma
 	return t, nil
 }
 
-func (srv *TableService) Delete(ctx context.Context, id int64) error {
-	if err := srv.repository.Delete(ctx, id); err != nil {
+func (srv *TableService) Delete(ctx context.Context, id int64, version int64) error {
+	if err := srv.repository.Delete(ctx, id, version); err != nil {
 		return fmt.Errorf("delete table: %w", err)
 	}
 
@@ -44,6 +44,14 @@ func (srv *TableService) GetEmptySeats(ctx context.Context) (int64, error) {
 	return count, nil
 }
 
+func (srv *TableService) Restore(ctx context.Context, id int64) error {
+	if err := srv.repository.Restore(ctx, id); err != nil {
+		return fmt.Errorf("restore table: %w", err)
+	}
+
+	return nil
+}
+
 func (srv *TableService) GetById(ctx context.Context, id int64) (*domain.Table, error) {
 	table, err := srv.repository.GetById(ctx, id)
 	if err != nil {
@@ -53,6 +61,15 @@ func (srv *TableService) GetById(ctx context.Context, id int64) (*domain.Table,
 	return table, nil
 }
 
+func (srv *TableService) GetList(ctx context.Context, filter port.GetTableFilter) ([]*domain.Table, error) {
+	tables, err := srv.repository.GetAll(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("get all tables: %w", err)
+	}
+
+	return tables, nil
+}
+
 func (srv *TableService) Update(ctx context.Context, id int64, table domain.Table) error {
 	if err := srv.repository.Update(ctx, id, table); err != nil {
 		return fmt.Errorf("update table: %w", err)
@@ -60,3 +77,11 @@ func (srv *TableService) Update(ctx context.Context, id int64, table domain.Tabl
 
 	return nil
 }
+
+func (srv *TableService) Patch(ctx context.Context, id int64, patch port.TablePatch) error {
+	if err := srv.repository.Patch(ctx, id, patch); err != nil {
+		return fmt.Errorf("patch table: %w", err)
+	}
+
+	return nil
+}
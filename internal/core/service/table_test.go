@@ -82,9 +82,9 @@ func (t *TableServiceSuite) TestTableDelete() {
 	gin.SetMode(gin.TestMode)
 	c, _ := gin.CreateTestContext(httptest.NewRecorder())
 
-	t.mockTableRepository.EXPECT().Delete(c, int64(1)).Return(nil).Times(1)
+	t.mockTableRepository.EXPECT().Delete(c, int64(1), int64(1)).Return(nil).Times(1)
 
-	err := t.tableService.Delete(c, int64(1))
+	err := t.tableService.Delete(c, int64(1), int64(1))
 
 	t.NoError(err)
 }
@@ -131,3 +131,19 @@ func (t *TableServiceSuite) TestGuestUpdate() {
 
 	t.NoError(err)
 }
+
+func (t *TableServiceSuite) TestTablePatch() {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+
+	patch := port.TablePatch{
+		Table:  domain.Table{Version: 1},
+		Fields: []string{"guest_id"},
+	}
+
+	t.mockTableRepository.EXPECT().Patch(c, int64(1), patch).Return(nil).Times(1)
+
+	err := t.tableService.Patch(c, int64(1), patch)
+
+	t.NoError(err)
+}
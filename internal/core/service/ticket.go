@@ -0,0 +1,61 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/eazygood/getground-app/internal/core/port"
+)
+
+// ErrInvalidTicket is returned for tokens that were not issued by this service
+var ErrInvalidTicket = errors.New("invalid ticket")
+
+// TicketService signs the id of a guest with HMAC-SHA256 along with the event, a token is
+// "<guest id>.<signature>" so it fits a small QR code and needs no storage to be checked
+type TicketService struct {
+	secret []byte
+	event  string
+}
+
+func NewTicketService(secret []byte, event string) port.TicketService {
+	return &TicketService{
+		secret: secret,
+		event:  event,
+	}
+}
+
+func (srv *TicketService) Issue(guestID int64) string {
+	id := strconv.FormatInt(guestID, 10)
+
+	return id + "." + base64.RawURLEncoding.EncodeToString(srv.sign(id))
+}
+
+func (srv *TicketService) Verify(token string) (int64, error) {
+	id, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return 0, ErrInvalidTicket
+	}
+
+	guestID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || guestID < 1 {
+		return 0, ErrInvalidTicket
+	}
+
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, srv.sign(id)) {
+		return 0, ErrInvalidTicket
+	}
+
+	return guestID, nil
+}
+
+func (srv *TicketService) sign(id string) []byte {
+	mac := hmac.New(sha256.New, srv.secret)
+	mac.Write([]byte(srv.event + "\n" + id))
+
+	return mac.Sum(nil)
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTicketRoundTrip(t *testing.T) {
+	tickets := NewTicketService([]byte("secret"), "party")
+
+	token := tickets.Issue(42)
+	require.True(t, strings.HasPrefix(token, "42."))
+
+	guestID, err := tickets.Verify(token)
+	require.NoError(t, err)
+	require.Equal(t, int64(42), guestID)
+}
+
+func TestTicketRejectsForgedTokens(t *testing.T) {
+	tickets := NewTicketService([]byte("secret"), "party")
+	token := tickets.Issue(42)
+	_, signature, _ := strings.Cut(token, ".")
+
+	for name, forged := range map[string]string{
+		"other guest":  "43." + signature,
+		"other secret": NewTicketService([]byte("guessed"), "party").Issue(42),
+		"other event":  NewTicketService([]byte("secret"), "last year").Issue(42),
+		"no signature": "42",
+		"bad encoding": "42.!!!",
+		"bad id":       "-1." + signature,
+		"empty":        "",
+	} {
+		_, err := tickets.Verify(forged)
+		require.ErrorIs(t, err, ErrInvalidTicket, name)
+	}
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	apperrors "github.com/eazygood/getground-app/internal/errors"
+	"github.com/eazygood/getground-app/internal/venue"
+)
+
+// WebhookRetry schedules the attempts of a delivery: a failed attempt is retried after
+// InitialBackoff, doubled on every attempt up to MaxBackoff, until MaxAttempts were made
+type WebhookRetry struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// BatchSize is how many due deliveries are attempted by DeliverDue
+	BatchSize int
+}
+
+// Backoff returns how long to wait after the given number of failed attempts
+func (r WebhookRetry) Backoff(attempts int) time.Duration {
+	return backoff(r.InitialBackoff, r.MaxBackoff, attempts)
+}
+
+// backoff doubles initial for every failed attempt after the first one, up to max
+func backoff(initial, max time.Duration, attempts int) time.Duration {
+	wait := initial
+	for i := 1; i < attempts && wait < max; i++ {
+		wait *= 2
+	}
+
+	if wait > max {
+		return max
+	}
+
+	return wait
+}
+
+type WebhookService struct {
+	repository port.WebhookRepository
+	sender     port.WebhookSender
+	retry      WebhookRetry
+	now        func() time.Time
+}
+
+func NewWebhookService(repository port.WebhookRepository, sender port.WebhookSender, retry WebhookRetry) port.WebhookService {
+	return &WebhookService{
+		repository: repository,
+		sender:     sender,
+		retry:      retry,
+		now:        venue.Now,
+	}
+}
+
+// Register stores the webhook once its url is known to reach a public address, the deliveries check it again
+// as the address a host resolves to may change
+func (srv *WebhookService) Register(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error) {
+	if err := srv.sender.Check(ctx, webhook.URL); err != nil {
+		return nil, fmt.Errorf("register webhook: %v: %w", err, apperrors.NewValidationError(apperrors.FieldError{
+			Field:   "url",
+			Rule:    "public_url",
+			Message: "url must resolve to public addresses only",
+		}))
+	}
+
+	if webhook.Secret == "" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, fmt.Errorf("generate webhook secret: %w", err)
+		}
+
+		webhook.Secret = hex.EncodeToString(secret)
+	}
+
+	w, err := srv.repository.Create(ctx, webhook)
+	if err != nil {
+		return nil, fmt.Errorf("register webhook: %w", err)
+	}
+
+	return w, nil
+}
+
+func (srv *WebhookService) GetById(ctx context.Context, id int64) (*domain.Webhook, error) {
+	webhook, err := srv.repository.GetById(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+func (srv *WebhookService) GetList(ctx context.Context) ([]*domain.Webhook, error) {
+	webhooks, err := srv.repository.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get all webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+func (srv *WebhookService) Delete(ctx context.Context, id int64) error {
+	if err := srv.repository.Delete(ctx, id); err != nil {
+		return fmt.Errorf("delete webhook: %w", err)
+	}
+
+	return nil
+}
+
+func (srv *WebhookService) GetDeliveries(ctx context.Context, filter port.GetDeliveriesFilter) ([]*domain.WebhookDelivery, error) {
+	deliveries, err := srv.repository.GetDeliveries(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("get webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func (srv *WebhookService) Publish(ctx context.Context, event domain.Event) error {
+	if _, err := srv.repository.Enqueue(ctx, event); err != nil {
+		return fmt.Errorf("publish %s event: %w", event.Type, err)
+	}
+
+	return nil
+}
+
+func (srv *WebhookService) DeliverDue(ctx context.Context) (int, error) {
+	deliveries, err := srv.repository.GetDueDeliveries(ctx, srv.now(), srv.retry.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("get due webhook deliveries: %w", err)
+	}
+
+	if len(deliveries) == 0 {
+		return 0, nil
+	}
+
+	registered, err := srv.repository.GetAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("get all webhooks: %w", err)
+	}
+
+	webhooks := make(map[int64]*domain.Webhook, len(registered))
+	for _, webhook := range registered {
+		webhooks[webhook.ID] = webhook
+	}
+
+	for _, delivery := range deliveries {
+		// a webhook removed since the event was queued has its deliveries failed
+		srv.attempt(ctx, webhooks[delivery.WebhookID], delivery)
+
+		if err := srv.repository.UpdateDelivery(ctx, delivery); err != nil {
+			return 0, fmt.Errorf("update webhook delivery: %w", err)
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+// attempt sends the delivery and records the outcome on it, the next attempt is scheduled with
+// an exponential backoff until the attempts run out
+func (srv *WebhookService) attempt(ctx context.Context, webhook *domain.Webhook, delivery *domain.WebhookDelivery) {
+	delivery.Attempts++
+
+	if webhook == nil {
+		delivery.Status = domain.DeliveryFailed
+		delivery.LastError = "webhook was removed"
+		delivery.NextAttemptAt = nil
+		return
+	}
+
+	status, err := srv.sender.Send(ctx, webhook, delivery)
+	delivery.ResponseStatus = status
+	now := srv.now()
+
+	if err == nil && status >= http.StatusOK && status < http.StatusMultipleChoices {
+		delivery.Status = domain.DeliveryDelivered
+		delivery.LastError = ""
+		delivery.NextAttemptAt = nil
+		delivery.DeliveredAt = &now
+		return
+	}
+
+	if err != nil {
+		delivery.LastError = err.Error()
+	} else {
+		delivery.LastError = fmt.Sprintf("webhook answered %d", status)
+	}
+
+	if delivery.Attempts >= srv.retry.MaxAttempts {
+		delivery.Status = domain.DeliveryFailed
+		delivery.NextAttemptAt = nil
+		return
+	}
+
+	next := now.Add(srv.retry.Backoff(delivery.Attempts))
+	delivery.NextAttemptAt = &next
+}
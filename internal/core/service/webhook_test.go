@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	apperrors "github.com/eazygood/getground-app/internal/errors"
+	ports "github.com/eazygood/getground-app/mocks/core/port"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type WebhookServiceSuite struct {
+	suite.Suite
+	*require.Assertions
+	ctrl                  *gomock.Controller
+	mockWebhookRepository *ports.MockWebhookRepository
+	mockSender            *ports.MockWebhookSender
+	webhookService        *WebhookService
+	now                   time.Time
+}
+
+func TestWebhookServiceSuite(t *testing.T) {
+	suite.Run(t, new(WebhookServiceSuite))
+}
+
+func (w *WebhookServiceSuite) SetupTest() {
+	w.Assertions = require.New(w.T())
+	w.ctrl = gomock.NewController(w.T())
+	w.mockWebhookRepository = ports.NewMockWebhookRepository(w.ctrl)
+	w.mockSender = ports.NewMockWebhookSender(w.ctrl)
+	w.now = time.Date(2023, 1, 20, 19, 30, 0, 0, time.UTC)
+
+	w.webhookService = NewWebhookService(w.mockWebhookRepository, w.mockSender, WebhookRetry{
+		MaxAttempts:    3,
+		InitialBackoff: 10 * time.Second,
+		MaxBackoff:     time.Minute,
+		BatchSize:      10,
+	}).(*WebhookService)
+	w.webhookService.now = func() time.Time { return w.now }
+}
+
+func (w *WebhookServiceSuite) TearDownTest() {
+	w.ctrl.Finish()
+}
+
+func (w *WebhookServiceSuite) TestBackoff() {
+	retry := WebhookRetry{InitialBackoff: 10 * time.Second, MaxBackoff: time.Minute}
+
+	w.Equal(10*time.Second, retry.Backoff(1))
+	w.Equal(20*time.Second, retry.Backoff(2))
+	w.Equal(40*time.Second, retry.Backoff(3))
+	w.Equal(time.Minute, retry.Backoff(4))
+	w.Equal(time.Minute, retry.Backoff(40))
+}
+
+func (w *WebhookServiceSuite) TestRegisterGeneratesSecret() {
+	ctx := context.Background()
+
+	w.mockSender.EXPECT().Check(ctx, "https://hr.example.com/hooks").Return(nil).Times(1)
+	w.mockWebhookRepository.EXPECT().Create(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, webhook *domain.Webhook) (*domain.Webhook, error) {
+			webhook.ID = 1
+			return webhook, nil
+		}).Times(1)
+
+	webhook, err := w.webhookService.Register(ctx, &domain.Webhook{URL: "https://hr.example.com/hooks"})
+
+	w.NoError(err)
+	w.Len(webhook.Secret, 64)
+}
+
+func (w *WebhookServiceSuite) TestRegisterKeepsSecret() {
+	ctx := context.Background()
+	webhook := &domain.Webhook{URL: "https://hr.example.com/hooks", Secret: "0123456789abcdef"}
+
+	w.mockSender.EXPECT().Check(ctx, webhook.URL).Return(nil).Times(1)
+	w.mockWebhookRepository.EXPECT().Create(ctx, webhook).Return(webhook, nil).Times(1)
+
+	registered, err := w.webhookService.Register(ctx, webhook)
+
+	w.NoError(err)
+	w.Equal("0123456789abcdef", registered.Secret)
+}
+
+func (w *WebhookServiceSuite) TestRegisterPrivateAddress() {
+	ctx := context.Background()
+
+	w.mockSender.EXPECT().Check(ctx, "http://169.254.169.254/latest/meta-data/").
+		Return(errors.New("169.254.169.254 is not a public address")).Times(1)
+
+	_, err := w.webhookService.Register(ctx, &domain.Webhook{URL: "http://169.254.169.254/latest/meta-data/"})
+
+	var validation *apperrors.ValidationError
+	w.ErrorAs(err, &validation)
+	w.Equal("public_url", validation.Fields[0].Rule)
+}
+
+func (w *WebhookServiceSuite) TestDeliverDueWithoutDeliveries() {
+	ctx := context.Background()
+
+	w.mockWebhookRepository.EXPECT().GetDueDeliveries(ctx, w.now, 10).Return(nil, nil).Times(1)
+
+	attempted, err := w.webhookService.DeliverDue(ctx)
+
+	w.NoError(err)
+	w.Zero(attempted)
+}
+
+func (w *WebhookServiceSuite) TestDeliverDue() {
+	ctx := context.Background()
+	webhook := &domain.Webhook{ID: 1, URL: "https://hr.example.com/hooks"}
+	delivered := &domain.WebhookDelivery{ID: 1, WebhookID: 1, Status: domain.DeliveryPending}
+	retried := &domain.WebhookDelivery{ID: 2, WebhookID: 1, Status: domain.DeliveryPending, Attempts: 1}
+	orphan := &domain.WebhookDelivery{ID: 3, WebhookID: 2, Status: domain.DeliveryPending}
+
+	w.mockWebhookRepository.EXPECT().GetDueDeliveries(ctx, w.now, 10).
+		Return([]*domain.WebhookDelivery{delivered, retried, orphan}, nil).Times(1)
+	w.mockWebhookRepository.EXPECT().GetAll(ctx).Return([]*domain.Webhook{webhook}, nil).Times(1)
+	w.mockSender.EXPECT().Send(ctx, webhook, delivered).Return(http.StatusNoContent, nil).Times(1)
+	w.mockSender.EXPECT().Send(ctx, webhook, retried).Return(http.StatusServiceUnavailable, nil).Times(1)
+	w.mockWebhookRepository.EXPECT().UpdateDelivery(ctx, gomock.Any()).Return(nil).Times(3)
+
+	attempted, err := w.webhookService.DeliverDue(ctx)
+
+	w.NoError(err)
+	w.Equal(3, attempted)
+
+	w.Equal(domain.DeliveryDelivered, delivered.Status)
+	w.Equal(1, delivered.Attempts)
+	w.Equal(w.now, *delivered.DeliveredAt)
+	w.Nil(delivered.NextAttemptAt)
+
+	w.Equal(domain.DeliveryPending, retried.Status)
+	w.Equal(2, retried.Attempts)
+	w.Equal("webhook answered 503", retried.LastError)
+	w.Equal(w.now.Add(20*time.Second), *retried.NextAttemptAt)
+
+	w.Equal(domain.DeliveryFailed, orphan.Status)
+	w.Equal("webhook was removed", orphan.LastError)
+}
+
+func (w *WebhookServiceSuite) TestDeliverDueFailsAfterMaxAttempts() {
+	ctx := context.Background()
+	webhook := &domain.Webhook{ID: 1, URL: "https://hr.example.com/hooks"}
+	delivery := &domain.WebhookDelivery{ID: 1, WebhookID: 1, Status: domain.DeliveryPending, Attempts: 2}
+
+	w.mockWebhookRepository.EXPECT().GetDueDeliveries(ctx, w.now, 10).Return([]*domain.WebhookDelivery{delivery}, nil).Times(1)
+	w.mockWebhookRepository.EXPECT().GetAll(ctx).Return([]*domain.Webhook{webhook}, nil).Times(1)
+	w.mockSender.EXPECT().Send(ctx, webhook, delivery).Return(0, errors.New("connection refused")).Times(1)
+	w.mockWebhookRepository.EXPECT().UpdateDelivery(ctx, delivery).Return(nil).Times(1)
+
+	_, err := w.webhookService.DeliverDue(ctx)
+
+	w.NoError(err)
+	w.Equal(domain.DeliveryFailed, delivery.Status)
+	w.Equal(3, delivery.Attempts)
+	w.Equal("connection refused", delivery.LastError)
+	w.Nil(delivery.NextAttemptAt)
+}
+
+func (w *WebhookServiceSuite) TestDeliverDueThrowError() {
+	ctx := context.Background()
+
+	w.mockWebhookRepository.EXPECT().GetDueDeliveries(ctx, w.now, 10).Return(nil, errors.New("Mock Repository Error")).Times(1)
+
+	_, err := w.webhookService.DeliverDue(ctx)
+
+	w.ErrorContains(err, "Mock Repository Error")
+}
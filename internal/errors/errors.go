@@ -1,7 +1,12 @@
 package errors
 
 import (
+	stderrors "errors"
+	"fmt"
 	"net/http"
+	"strings"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
 )
 
 // All possible errors withing the service
@@ -9,24 +14,100 @@ import (
 type errorCode string
 
 var (
-	Internal     errorCode = "internal"
-	NotFound     errorCode = "not_found"
-	InvalidInput errorCode = "invalid_input"
+	Internal             errorCode = "internal"
+	NotFound             errorCode = "not_found"
+	InvalidInput         errorCode = "invalid_input"
+	PreconditionFailed   errorCode = "precondition_failed"
+	Conflict             errorCode = "conflict"
+	PreconditionRequired errorCode = "precondition_required"
+	TooManyRequests      errorCode = "too_many_requests"
+	Unprocessable        errorCode = "unprocessable"
 )
 
+// ConflictError is returned by repositories when a record was modified by someone
+// else since the caller read it, i.e. its stored version no longer matches.
+type ConflictError struct {
+	Entity  string
+	ID      int64
+	Version int64
+}
+
+func NewConflictError(entity string, id int64, version int64) *ConflictError {
+	return &ConflictError{Entity: entity, ID: id, Version: version}
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s %d does not match version %d", e.Entity, e.ID, e.Version)
+}
+
+// FieldError describes why the value of a request field was rejected
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// ValidationError is returned when a request payload breaks one or more validation rules
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func NewValidationError(fields ...FieldError) *ValidationError {
+	return &ValidationError{Fields: fields}
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		messages = append(messages, field.Message)
+	}
+
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
 // ApiError encapsulates error data to be sent out of the service via HTTP
 type ApiError struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
+	Code    int          `json:"code"`
+	Message string       `json:"message"`
+	Errors  []FieldError `json:"errors,omitempty"`
 }
 
 func NewApiError(code errorCode, err error) ApiError {
+	// version conflicts are always reported as such, whatever the caller expected
+	var conflict *ConflictError
+	if stderrors.As(err, &conflict) {
+		code = PreconditionFailed
+	}
+
+	// and lookups that found nothing as missing records
+	if stderrors.Is(err, domain.ErrNotFound) {
+		code = NotFound
+	}
+
 	apiError := ApiError{Message: err.Error()}
+
+	// so are invalid payloads, with the list of the rejected fields
+	var validation *ValidationError
+	if stderrors.As(err, &validation) {
+		code = Unprocessable
+		apiError.Message = "validation failed"
+		apiError.Errors = validation.Fields
+	}
 	switch code {
 	case InvalidInput:
 		apiError.Code = http.StatusBadRequest
 	case NotFound:
 		apiError.Code = http.StatusNotFound
+	case PreconditionFailed:
+		apiError.Code = http.StatusPreconditionFailed
+	case Conflict:
+		apiError.Code = http.StatusConflict
+	case PreconditionRequired:
+		apiError.Code = http.StatusPreconditionRequired
+	case TooManyRequests:
+		apiError.Code = http.StatusTooManyRequests
+	case Unprocessable:
+		apiError.Code = http.StatusUnprocessableEntity
 	default:
 		apiError.Code = http.StatusInternalServerError
 	}
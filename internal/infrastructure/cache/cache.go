@@ -0,0 +1,44 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eazygood/getground-app/internal/config"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	StoreNone   = "none"
+	StoreMemory = "memory"
+	StoreRedis  = "redis"
+)
+
+// Store keeps encoded values under a key. Values are bytes so the same store
+// can live in-process or in any Redis-compatible server shared by instances.
+type Store interface {
+	// Get returns ok=false when the key is missing or expired
+	Get(ctx context.Context, key string) (value []byte, ok bool, err error)
+	Set(ctx context.Context, key string, value []byte) error
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// NewStore builds the store selected in the configuration, nil when caching is disabled
+func NewStore(cfg config.Cache) (Store, error) {
+	switch cfg.Store {
+	case "", StoreNone:
+		return nil, nil
+	case StoreMemory:
+		return NewLRU(cfg.Size, cfg.TTL), nil
+	case StoreRedis:
+		client := redis.NewClient(&redis.Options{
+			Addr:     cfg.Redis.Addr,
+			Password: cfg.Redis.Password,
+			DB:       cfg.Redis.DB,
+		})
+
+		return NewRedis(client, cfg.Redis.Prefix, cfg.TTL), nil
+	default:
+		return nil, fmt.Errorf("unknown cache store: %s", cfg.Store)
+	}
+}
@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	store := NewLRU(2, 0)
+
+	require.NoError(t, store.Set(ctx, "a", []byte("1")))
+	require.NoError(t, store.Set(ctx, "b", []byte("2")))
+
+	_, ok, _ := store.Get(ctx, "a")
+	require.True(t, ok)
+
+	require.NoError(t, store.Set(ctx, "c", []byte("3")))
+
+	_, ok, _ = store.Get(ctx, "b")
+	require.False(t, ok)
+
+	value, ok, _ := store.Get(ctx, "a")
+	require.True(t, ok)
+	require.Equal(t, []byte("1"), value)
+}
+
+func TestLRUExpiresKeys(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	store := NewLRU(2, time.Minute)
+	store.now = func() time.Time { return now }
+
+	require.NoError(t, store.Set(ctx, "a", []byte("1")))
+
+	_, ok, _ := store.Get(ctx, "a")
+	require.True(t, ok)
+
+	now = now.Add(time.Minute)
+
+	_, ok, _ = store.Get(ctx, "a")
+	require.False(t, ok)
+	require.Zero(t, store.order.Len())
+}
+
+func TestLRUDelete(t *testing.T) {
+	ctx := context.Background()
+	store := NewLRU(2, 0)
+
+	require.NoError(t, store.Set(ctx, "a", []byte("1")))
+	require.NoError(t, store.Delete(ctx, "a", "missing"))
+
+	_, ok, _ := store.Get(ctx, "a")
+	require.False(t, ok)
+}
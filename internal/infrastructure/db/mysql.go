@@ -1,7 +1,9 @@
 package infra
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"gorm.io/gorm"
 
@@ -11,35 +13,131 @@ import (
 	gormMySql "gorm.io/driver/mysql"
 )
 
+const (
+	defaultMaxAttempts    = 1
+	defaultInitialBackoff = 500 * time.Millisecond
+	defaultMaxBackoff     = 15 * time.Second
+)
+
 var (
 	db *gorm.DB
 )
 
-func InitDb(cfg *config.App) *gorm.DB {
+// InitDb connects to MySQL, retrying with exponential backoff while the database is not reachable,
+// and configures the connection pool and the query timeout
+func InitDb(ctx context.Context, cfg *config.App) (*gorm.DB, error) {
+	if cfg.Database.TLS.Enabled {
+		if err := registerTLSConfig(cfg.Database.TLS); err != nil {
+			return nil, err
+		}
+	}
+
+	config := gormMySql.Config{
+		DSN: dsn(cfg.Database, cfg.Database.Host, cfg.Database.Port),
+	}
+
+	initDB, err := openWithRetry(ctx, cfg.Database.Connect, func() (*gorm.DB, error) {
+		return gorm.Open(gormMySql.New(config), &gorm.Config{
+			// Logger: logger.Default.LogMode(logger.Info),
+		})
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to init database: %w", err)
+	}
+
+	if err := configurePool(initDB, cfg.Database); err != nil {
+		return nil, err
+	}
+
+	if err := registerReplicas(initDB, cfg.Database); err != nil {
+		return nil, err
+	}
+
+	if err := initDB.Use(NewQueryTimeout(cfg.Database.QueryTimeout)); err != nil {
+		return nil, fmt.Errorf("failed to register query timeout: %w", err)
+	}
+
+	db = initDB
+
+	return db, nil
+}
+
+func dsn(cfg config.Database, host string, port string) string {
 	dbConfig := mysql.Config{
-		User:                 cfg.Database.User,
-		Passwd:               cfg.Database.Password,
+		User:                 cfg.User,
+		Passwd:               cfg.Password,
 		Net:                  "tcp",
-		Addr:                 fmt.Sprintf("%s:%s", cfg.Database.Host, cfg.Database.Port),
-		DBName:               cfg.Database.Name,
+		Addr:                 fmt.Sprintf("%s:%s", host, port),
+		DBName:               cfg.Name,
 		Collation:            "utf8_general_ci",
 		AllowNativePasswords: true,
 		ParseTime:            true,
+		// timestamps are written and read in UTC, whatever the zone of the server or the app
+		Loc:    time.UTC,
+		Params: map[string]string{"time_zone": "'+00:00'"},
 	}
 
-	config := gormMySql.Config{
-		DSN: dbConfig.FormatDSN(),
+	if cfg.TLS.Enabled {
+		dbConfig.TLSConfig = tlsConfigName
 	}
 
-	initDB, err := gorm.Open(gormMySql.New(config), &gorm.Config{
-		// Logger: logger.Default.LogMode(logger.Info),
-	})
+	return dbConfig.FormatDSN()
+}
 
+func configurePool(conn *gorm.DB, cfg config.Database) error {
+	sqlDB, err := conn.DB()
 	if err != nil {
-		log.Panicf("failed to init database: %v\n", err)
+		return fmt.Errorf("failed to get connection pool: %w", err)
 	}
 
-	db = initDB
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	return nil
+}
+
+// openWithRetry calls open until it succeeds, MaxAttempts is reached or ctx is cancelled,
+// doubling the wait between attempts up to MaxBackoff
+func openWithRetry(ctx context.Context, cfg config.DatabaseConnect, open func() (*gorm.DB, error)) (*gorm.DB, error) {
+	maxAttempts := cfg.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = defaultMaxAttempts
+	}
 
-	return db
+	backoff := cfg.InitialBackoff
+	if backoff <= 0 {
+		backoff = defaultInitialBackoff
+	}
+
+	maxBackoff := cfg.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultMaxBackoff
+	}
+
+	for attempt := 1; ; attempt++ {
+		conn, err := open()
+		if err == nil {
+			return conn, nil
+		}
+
+		if attempt >= maxAttempts {
+			return nil, fmt.Errorf("giving up after %d attempts: %w", attempt, err)
+		}
+
+		log.WithError(err).Warnf("database not ready (attempt %d/%d), retrying in %s", attempt, maxAttempts, backoff)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
 }
@@ -0,0 +1,55 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eazygood/getground-app/internal/config"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestOpenWithRetrySucceedsAfterFailures(t *testing.T) {
+	attempts := 0
+	cfg := config.DatabaseConnect{MaxAttempts: 5, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+
+	conn, err := openWithRetry(context.Background(), cfg, func() (*gorm.DB, error) {
+		attempts++
+		if attempts < 3 {
+			return nil, errors.New("connection refused")
+		}
+
+		return &gorm.DB{}, nil
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, conn)
+	require.Equal(t, 3, attempts)
+}
+
+func TestOpenWithRetryGivesUp(t *testing.T) {
+	attempts := 0
+	cfg := config.DatabaseConnect{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+
+	_, err := openWithRetry(context.Background(), cfg, func() (*gorm.DB, error) {
+		attempts++
+		return nil, errors.New("connection refused")
+	})
+
+	require.EqualError(t, err, "giving up after 3 attempts: connection refused")
+	require.Equal(t, 3, attempts)
+}
+
+func TestOpenWithRetryStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cfg := config.DatabaseConnect{MaxAttempts: 10, InitialBackoff: time.Hour}
+
+	_, err := openWithRetry(ctx, cfg, func() (*gorm.DB, error) {
+		cancel()
+		return nil, errors.New("connection refused")
+	})
+
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,116 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/eazygood/getground-app/internal/config"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	gormMySql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// registerReplicas routes the reads to the configured replicas, writes stay on the primary.
+// Without replicas every statement goes to the primary.
+func registerReplicas(conn *gorm.DB, cfg config.Database) error {
+	if len(cfg.Replicas) == 0 {
+		return nil
+	}
+
+	replicas := make([]gorm.Dialector, 0, len(cfg.Replicas))
+	for _, replica := range cfg.Replicas {
+		replicas = append(replicas, gormMySql.Open(dsn(cfg, replica.Host, replica.Port)))
+	}
+
+	resolver := dbresolver.Register(dbresolver.Config{
+		Replicas: replicas,
+		Policy:   dbresolver.RandomPolicy{},
+	}).
+		SetMaxOpenConns(cfg.MaxOpenConns).
+		SetMaxIdleConns(cfg.MaxIdleConns).
+		SetConnMaxLifetime(cfg.ConnMaxLifetime).
+		SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	if err := conn.Use(resolver); err != nil {
+		return fmt.Errorf("failed to register database replicas: %w", err)
+	}
+
+	return nil
+}
+
+type writesKey struct{}
+
+type primaryKey struct{}
+
+// WithWriteTracking returns a copy of ctx that remembers whether a write happened, so the reads
+// that follow within the same request can be sent to the primary
+func WithWriteTracking(ctx context.Context) context.Context {
+	return context.WithValue(ctx, writesKey{}, new(atomic.Bool))
+}
+
+// TrackWrites is the gin middleware enabling read-your-writes for every request
+func TrackWrites() gin.HandlerFunc {
+	return func(request *gin.Context) {
+		request.Request = request.Request.WithContext(WithWriteTracking(request.Request.Context()))
+		request.Next()
+	}
+}
+
+// UnaryServerInterceptor enables read-your-writes for every gRPC call, as TrackWrites does for HTTP requests
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(WithWriteTracking(ctx), req)
+	}
+}
+
+// StreamServerInterceptor enables read-your-writes for every gRPC stream
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &trackingStream{ServerStream: stream, ctx: WithWriteTracking(stream.Context())})
+	}
+}
+
+// trackingStream hands the context tracking the writes to stream handlers
+type trackingStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *trackingStream) Context() context.Context {
+	return s.ctx
+}
+
+// MarkWrite records that the request of ctx wrote to the primary
+func MarkWrite(ctx context.Context) {
+	if wrote, ok := ctx.Value(writesKey{}).(*atomic.Bool); ok {
+		wrote.Store(true)
+	}
+}
+
+// WithPrimaryReads returns a copy of ctx whose reads all go to the primary, for the reads that outlive
+// the request such as the values a cache is filled with
+func WithPrimaryReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// Replica returns conn bound to ctx for a read that can be served by a replica, unless
+// the request of ctx already wrote something and tracks its writes, or reads from the primary only
+func Replica(ctx context.Context, conn *gorm.DB) *gorm.DB {
+	if wrote, ok := ctx.Value(writesKey{}).(*atomic.Bool); ok && wrote.Load() {
+		return Primary(ctx, conn)
+	}
+
+	if primary, _ := ctx.Value(primaryKey{}).(bool); primary {
+		return Primary(ctx, conn)
+	}
+
+	return conn.WithContext(ctx).Clauses(dbresolver.Read)
+}
+
+// Primary returns conn bound to ctx for a read that must see the latest writes
+func Primary(ctx context.Context, conn *gorm.DB) *gorm.DB {
+	return conn.WithContext(ctx).Clauses(dbresolver.Write)
+}
@@ -0,0 +1,106 @@
+package infra
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+func openWithReplica(t *testing.T) (*gorm.DB, sqlmock.Sqlmock, sqlmock.Sqlmock) {
+	primaryDb, primary, err := sqlmock.New()
+	require.NoError(t, err)
+	replicaDb, replica, err := sqlmock.New()
+	require.NoError(t, err)
+
+	conn, err := gorm.Open(mysql.New(mysql.Config{Conn: primaryDb, SkipInitializeWithVersion: true}), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, conn.Use(dbresolver.Register(dbresolver.Config{
+		Replicas: []gorm.Dialector{mysql.New(mysql.Config{Conn: replicaDb, SkipInitializeWithVersion: true})},
+	})))
+
+	return conn, primary, replica
+}
+
+func TestReplicaServesReads(t *testing.T) {
+	conn, primary, replica := openWithReplica(t)
+
+	replica.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `guests`")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John"))
+
+	var guests []guest
+	require.NoError(t, Replica(context.Background(), conn).Find(&guests).Error)
+	require.Len(t, guests, 1)
+
+	require.NoError(t, replica.ExpectationsWereMet())
+	require.NoError(t, primary.ExpectationsWereMet())
+}
+
+func TestReplicaReadsYourWrites(t *testing.T) {
+	conn, primary, replica := openWithReplica(t)
+	ctx := WithWriteTracking(context.Background())
+
+	replica.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `guests`")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+	primary.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `guests`")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John"))
+
+	require.NoError(t, Replica(ctx, conn).Find(&[]guest{}).Error)
+
+	MarkWrite(ctx)
+	var guests []guest
+	require.NoError(t, Replica(ctx, conn).Find(&guests).Error)
+	require.Len(t, guests, 1)
+
+	require.NoError(t, replica.ExpectationsWereMet())
+	require.NoError(t, primary.ExpectationsWereMet())
+}
+
+func TestMarkWriteWithoutTrackingKeepsReplica(t *testing.T) {
+	conn, primary, replica := openWithReplica(t)
+	ctx := context.Background()
+
+	replica.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `guests`")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+
+	MarkWrite(ctx)
+	require.NoError(t, Replica(ctx, conn).Find(&[]guest{}).Error)
+
+	require.NoError(t, replica.ExpectationsWereMet())
+	require.NoError(t, primary.ExpectationsWereMet())
+}
+
+func TestUnaryServerInterceptorReadsYourWrites(t *testing.T) {
+	conn, primary, replica := openWithReplica(t)
+
+	primary.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `guests`")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John"))
+
+	_, err := UnaryServerInterceptor()(context.Background(), nil, &grpc.UnaryServerInfo{},
+		func(ctx context.Context, _ interface{}) (interface{}, error) {
+			MarkWrite(ctx)
+			return nil, Replica(ctx, conn).Find(&[]guest{}).Error
+		})
+	require.NoError(t, err)
+
+	require.NoError(t, replica.ExpectationsWereMet())
+	require.NoError(t, primary.ExpectationsWereMet())
+}
+
+func TestPrimaryReadsSkipReplica(t *testing.T) {
+	conn, primary, replica := openWithReplica(t)
+
+	primary.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `guests`")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John"))
+
+	require.NoError(t, Replica(WithPrimaryReads(context.Background()), conn).Find(&[]guest{}).Error)
+
+	require.NoError(t, replica.ExpectationsWereMet())
+	require.NoError(t, primary.ExpectationsWereMet())
+}
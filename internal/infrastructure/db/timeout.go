@@ -0,0 +1,72 @@
+package infra
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const cancelKey = "getground:query_timeout_cancel"
+
+// QueryTimeout is a gorm plugin bounding every statement by a timeout. The timeout is derived
+// from the statement context, i.e. the request context given to WithContext, so a request
+// deadline shorter than the timeout still wins.
+type QueryTimeout struct {
+	timeout time.Duration
+}
+
+func NewQueryTimeout(timeout time.Duration) *QueryTimeout {
+	return &QueryTimeout{timeout: timeout}
+}
+
+func (q *QueryTimeout) Name() string {
+	return "query_timeout"
+}
+
+func (q *QueryTimeout) Initialize(db *gorm.DB) error {
+	if q.timeout <= 0 {
+		return nil
+	}
+
+	// Row is left out, its result is scanned after the callbacks ran and cancelling
+	// the context there would close the row before it is read
+	callback := db.Callback()
+	errs := []error{
+		callback.Create().Before("gorm:create").Register("query_timeout:before_create", q.before),
+		callback.Create().After("gorm:create").Register("query_timeout:after_create", q.after),
+		callback.Query().Before("gorm:query").Register("query_timeout:before_query", q.before),
+		callback.Query().After("gorm:query").Register("query_timeout:after_query", q.after),
+		callback.Update().Before("gorm:update").Register("query_timeout:before_update", q.before),
+		callback.Update().After("gorm:update").Register("query_timeout:after_update", q.after),
+		callback.Delete().Before("gorm:delete").Register("query_timeout:before_delete", q.before),
+		callback.Delete().After("gorm:delete").Register("query_timeout:after_delete", q.after),
+		callback.Raw().Before("gorm:raw").Register("query_timeout:before_raw", q.before),
+		callback.Raw().After("gorm:raw").Register("query_timeout:after_raw", q.after),
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (q *QueryTimeout) before(db *gorm.DB) {
+	ctx := db.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, q.timeout)
+	db.Statement.Context = ctx
+	db.InstanceSet(cancelKey, cancel)
+}
+
+func (q *QueryTimeout) after(db *gorm.DB) {
+	if cancel, ok := db.InstanceGet(cancelKey); ok {
+		cancel.(context.CancelFunc)()
+	}
+}
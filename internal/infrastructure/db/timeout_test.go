@@ -0,0 +1,69 @@
+package infra
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type guest struct {
+	ID   int64
+	Name string
+}
+
+func openMock(t *testing.T, timeout time.Duration) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	conn, err := gorm.Open(mysql.New(mysql.Config{Conn: db, SkipInitializeWithVersion: true}), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, conn.Use(NewQueryTimeout(timeout)))
+
+	return conn, mock
+}
+
+func TestQueryTimeoutCancelsSlowQuery(t *testing.T) {
+	conn, mock := openMock(t, 10*time.Millisecond)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `guests`")).
+		WillDelayFor(time.Second).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+
+	err := conn.WithContext(context.Background()).Find(&[]guest{}).Error
+
+	require.ErrorIs(t, err, sqlmock.ErrCancelled)
+}
+
+func TestQueryTimeoutKeepsShorterRequestDeadline(t *testing.T) {
+	conn, mock := openMock(t, time.Hour)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `guests`")).
+		WillDelayFor(time.Second).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := conn.WithContext(ctx).Find(&[]guest{}).Error
+
+	require.ErrorIs(t, err, sqlmock.ErrCancelled)
+}
+
+func TestQueryTimeoutLetsFastQueryThrough(t *testing.T) {
+	conn, mock := openMock(t, time.Second)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `guests`")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Simon"))
+
+	guests := []guest{}
+	err := conn.WithContext(context.Background()).Find(&guests).Error
+
+	require.NoError(t, err)
+	require.Len(t, guests, 1)
+}
@@ -0,0 +1,19 @@
+package health
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// PingDatabase probes the connection pool behind db
+func PingDatabase(db *gorm.DB) Check {
+	return func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+
+		return sqlDB.PingContext(ctx)
+	}
+}
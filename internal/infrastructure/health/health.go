@@ -0,0 +1,144 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+
+	defaultTimeout = 2 * time.Second
+)
+
+// Check probes a single dependency, it returns an error when the dependency is not usable
+type Check func(ctx context.Context) error
+
+// CheckResult is the outcome of a single probe
+type CheckResult struct {
+	Name    string  `json:"name"`
+	Status  string  `json:"status"`
+	Latency float64 `json:"latency_ms"`
+	Error   string  `json:"error,omitempty"`
+}
+
+// Report is the body of the readiness endpoint
+type Report struct {
+	Status string        `json:"status"`
+	Checks []CheckResult `json:"checks"`
+}
+
+// HealthChecker is a registry of dependency probes backing the readiness endpoint
+type HealthChecker struct {
+	timeout  time.Duration
+	mu       sync.RWMutex
+	checks   map[string]Check
+	draining atomic.Bool
+}
+
+// NewHealthChecker creates a registry whose probes are each given at most timeout to answer
+func NewHealthChecker(timeout time.Duration) *HealthChecker {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	return &HealthChecker{
+		timeout: timeout,
+		checks:  map[string]Check{},
+	}
+}
+
+// Register adds a probe, a probe registered twice under the same name replaces the first one
+func (h *HealthChecker) Register(name string, check Check) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.checks[name] = check
+}
+
+// Drain makes readiness fail from now on, so the orchestrator stops routing traffic before shutdown
+func (h *HealthChecker) Drain() {
+	h.draining.Store(true)
+}
+
+// Check runs all probes concurrently and reports the service down if any of them fails
+func (h *HealthChecker) Check(ctx context.Context) Report {
+	h.mu.RLock()
+	names := make([]string, 0, len(h.checks))
+	for name := range h.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	results := make([]CheckResult, len(names))
+	wg := sync.WaitGroup{}
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string, check Check) {
+			defer wg.Done()
+			results[i] = h.run(ctx, name, check)
+		}(i, name, h.checks[name])
+	}
+	h.mu.RUnlock()
+	wg.Wait()
+
+	report := Report{Status: StatusUp, Checks: results}
+	if h.draining.Load() {
+		report.Status = StatusDown
+		report.Checks = append(report.Checks, CheckResult{Name: "shutdown", Status: StatusDown, Error: "server is shutting down"})
+	}
+
+	for _, result := range results {
+		if result.Status != StatusUp {
+			report.Status = StatusDown
+		}
+	}
+
+	return report
+}
+
+func (h *HealthChecker) run(ctx context.Context, name string, check Check) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+
+	result := CheckResult{
+		Name:    name,
+		Status:  StatusUp,
+		Latency: float64(time.Since(start).Microseconds()) / 1000,
+	}
+
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+// Liveness only tells that the process is able to serve requests, dependencies are not probed
+// so a database outage does not get the pod restarted
+func (h *HealthChecker) Liveness(request *gin.Context) {
+	request.JSON(http.StatusOK, Report{Status: StatusUp, Checks: []CheckResult{}})
+}
+
+// Readiness probes all registered dependencies and answers 503 when any of them is down
+func (h *HealthChecker) Readiness(request *gin.Context) {
+	report := h.Check(request.Request.Context())
+
+	code := http.StatusOK
+	if report.Status != StatusUp {
+		code = http.StatusServiceUnavailable
+	}
+
+	request.JSON(code, report)
+}
@@ -0,0 +1,119 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type HealthCheckerSuite struct {
+	suite.Suite
+	*require.Assertions
+	checker *HealthChecker
+}
+
+func TestHealthCheckerSuite(t *testing.T) {
+	suite.Run(t, new(HealthCheckerSuite))
+}
+
+func (h *HealthCheckerSuite) SetupTest() {
+	h.Assertions = require.New(h.T())
+	h.checker = NewHealthChecker(50 * time.Millisecond)
+}
+
+func (h *HealthCheckerSuite) readiness() (int, Report) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/readyz", nil)
+
+	h.checker.Readiness(c)
+
+	report := Report{}
+	h.NoError(json.Unmarshal(w.Body.Bytes(), &report))
+
+	return w.Code, report
+}
+
+func (h *HealthCheckerSuite) TestReadinessUp() {
+	h.checker.Register("mysql", func(ctx context.Context) error { return nil })
+
+	code, report := h.readiness()
+
+	h.Equal(http.StatusOK, code)
+	h.Equal(StatusUp, report.Status)
+	h.Len(report.Checks, 1)
+	h.Equal("mysql", report.Checks[0].Name)
+}
+
+func (h *HealthCheckerSuite) TestReadinessDownWhenAnyCheckFails() {
+	h.checker.Register("mysql", func(ctx context.Context) error { return nil })
+	h.checker.Register("cache", func(ctx context.Context) error { return errors.New("connection refused") })
+
+	code, report := h.readiness()
+
+	h.Equal(http.StatusServiceUnavailable, code)
+	h.Equal(StatusDown, report.Status)
+	h.Equal(CheckResult{Name: "cache", Status: StatusDown, Latency: report.Checks[0].Latency, Error: "connection refused"}, report.Checks[0])
+	h.Equal(StatusUp, report.Checks[1].Status)
+}
+
+func (h *HealthCheckerSuite) TestReadinessTimesOutSlowCheck() {
+	h.checker.Register("mysql", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	code, report := h.readiness()
+
+	h.Equal(http.StatusServiceUnavailable, code)
+	h.Equal(context.DeadlineExceeded.Error(), report.Checks[0].Error)
+}
+
+func (h *HealthCheckerSuite) TestReadinessDownWhileDraining() {
+	h.checker.Register("mysql", func(ctx context.Context) error { return nil })
+	h.checker.Drain()
+
+	code, report := h.readiness()
+
+	h.Equal(http.StatusServiceUnavailable, code)
+	h.Equal(StatusDown, report.Status)
+}
+
+func (h *HealthCheckerSuite) TestLivenessIgnoresChecks() {
+	h.checker.Register("mysql", func(ctx context.Context) error { return errors.New("down") })
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	h.checker.Liveness(c)
+
+	h.Equal(http.StatusOK, w.Code)
+}
+
+func (h *HealthCheckerSuite) TestPingDatabase() {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	h.NoError(err)
+
+	// gorm pings once when opening the connection
+	mock.ExpectPing()
+
+	conn, err := gorm.Open(mysql.New(mysql.Config{Conn: db, SkipInitializeWithVersion: true}), &gorm.Config{})
+	h.NoError(err)
+
+	mock.ExpectPing().WillReturnError(errors.New("server has gone away"))
+
+	h.EqualError(PingDatabase(conn)(context.Background()), "server has gone away")
+}
@@ -0,0 +1,79 @@
+package log
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	logger "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor is the gRPC counterpart of Middleware: it assigns or propagates the
+// x-request-id metadata of every call, binds a log entry to the call context and writes one
+// access log line once the call is served
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		ctx, entry := grpcEntry(ctx, info.FullMethod)
+
+		resp, err := handler(ctx, req)
+
+		logServed(entry, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor binds a log entry to the context of every stream, as UnaryServerInterceptor does for calls
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx, entry := grpcEntry(stream.Context(), info.FullMethod)
+
+		err := handler(srv, &entryStream{ServerStream: stream, ctx: ctx})
+
+		logServed(entry, start, err)
+		return err
+	}
+}
+
+func grpcEntry(ctx context.Context, method string) (context.Context, *logger.Entry) {
+	var requestID string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(strings.ToLower(RequestIDHeader)); len(ids) > 0 {
+			requestID = ids[0]
+		}
+	}
+
+	if !isValidRequestID(requestID) {
+		requestID = newRequestID()
+	}
+
+	entry := logger.WithFields(logger.Fields{
+		"request_id": requestID,
+		"method":     method,
+	})
+
+	_ = grpc.SetHeader(ctx, metadata.Pairs(strings.ToLower(RequestIDHeader), requestID))
+
+	return WithEntry(ctx, entry), entry
+}
+
+func logServed(entry *logger.Entry, start time.Time, err error) {
+	entry.WithFields(logger.Fields{
+		"status":     status.Code(err).String(),
+		"latency_ms": float64(time.Since(start).Microseconds()) / 1000,
+	}).Info("request served")
+}
+
+// entryStream hands the context carrying the log entry to stream handlers
+type entryStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *entryStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,98 @@
+package log
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	logger "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RequestIDHeader carries the correlation id of a request, it is echoed back in the response
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength keeps client supplied ids from flooding the logs
+const maxRequestIDLength = 128
+
+type entryKey struct{}
+
+// WithEntry returns a copy of ctx carrying the request scoped log entry
+func WithEntry(ctx context.Context, entry *logger.Entry) context.Context {
+	return context.WithValue(ctx, entryKey{}, entry)
+}
+
+// FromContext returns the log entry of the request ctx belongs to, or an entry of the
+// global logger when ctx is not bound to a request
+func FromContext(ctx context.Context) *logger.Entry {
+	if ctx != nil {
+		if entry, ok := ctx.Value(entryKey{}).(*logger.Entry); ok {
+			return entry
+		}
+	}
+
+	return logger.NewEntry(logger.StandardLogger())
+}
+
+// Middleware assigns or propagates the X-Request-ID of every request, binds a log entry
+// to the request context and writes one access log line once the request is served.
+// The engine must have ContextWithFallback enabled for the entry to be reachable from *gin.Context.
+func Middleware() gin.HandlerFunc {
+	return func(request *gin.Context) {
+		start := time.Now()
+
+		requestID := request.GetHeader(RequestIDHeader)
+		if !isValidRequestID(requestID) {
+			requestID = newRequestID()
+		}
+
+		route := request.FullPath()
+		entry := logger.WithFields(logger.Fields{
+			"request_id": requestID,
+			"method":     request.Request.Method,
+			"route":      route,
+			"client_ip":  request.ClientIP(),
+		})
+
+		// ties the log lines to the trace started by the tracing middleware, when there is one
+		if span := trace.SpanContextFromContext(request.Request.Context()); span.IsValid() {
+			entry = entry.WithField("trace_id", span.TraceID().String())
+		}
+
+		request.Request = request.Request.WithContext(WithEntry(request.Request.Context(), entry))
+		request.Header(RequestIDHeader, requestID)
+
+		request.Next()
+
+		entry.WithFields(logger.Fields{
+			"path":       request.Request.URL.Path,
+			"status":     request.Writer.Status(),
+			"latency_ms": float64(time.Since(start).Microseconds()) / 1000,
+		}).Info("request served")
+	}
+}
+
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for _, r := range id {
+		if r < '!' || r > '~' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+
+	return hex.EncodeToString(b)
+}
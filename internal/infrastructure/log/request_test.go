@@ -0,0 +1,105 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	logger "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type RequestLogSuite struct {
+	suite.Suite
+	*require.Assertions
+	output *bytes.Buffer
+	router *gin.Engine
+	entry  *logger.Entry
+}
+
+func TestRequestLogSuite(t *testing.T) {
+	suite.Run(t, new(RequestLogSuite))
+}
+
+func (r *RequestLogSuite) SetupTest() {
+	r.Assertions = require.New(r.T())
+
+	r.output = &bytes.Buffer{}
+	logger.SetOutput(r.output)
+	logger.SetFormatter(&logger.JSONFormatter{})
+
+	gin.SetMode(gin.TestMode)
+	r.router = gin.New()
+	r.router.ContextWithFallback = true
+	r.router.Use(Middleware())
+	r.router.GET("/guests/:guest_id", func(request *gin.Context) {
+		// what a service receiving the *gin.Context as context.Context would see
+		var ctx context.Context = request
+		r.entry = FromContext(ctx)
+		request.Status(http.StatusTeapot)
+	})
+}
+
+func (r *RequestLogSuite) TearDownTest() {
+	logger.SetOutput(os.Stderr)
+}
+
+func (r *RequestLogSuite) serve(requestID string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/guests/7", nil)
+	if requestID != "" {
+		req.Header.Set(RequestIDHeader, requestID)
+	}
+
+	r.router.ServeHTTP(w, req)
+
+	return w
+}
+
+func (r *RequestLogSuite) TestPropagatesRequestID() {
+	w := r.serve("abc-123")
+
+	r.Equal("abc-123", w.Header().Get(RequestIDHeader))
+	r.Equal("abc-123", r.entry.Data["request_id"])
+	r.Equal("/guests/:guest_id", r.entry.Data["route"])
+}
+
+func (r *RequestLogSuite) TestGeneratesRequestID() {
+	w := r.serve("")
+
+	r.Len(w.Header().Get(RequestIDHeader), 32)
+	r.Equal(w.Header().Get(RequestIDHeader), r.entry.Data["request_id"])
+}
+
+func (r *RequestLogSuite) TestReplacesInvalidRequestID() {
+	w := r.serve(strings.Repeat("a", maxRequestIDLength+1))
+
+	r.Len(w.Header().Get(RequestIDHeader), 32)
+}
+
+func (r *RequestLogSuite) TestWritesAccessLog() {
+	r.serve("abc-123")
+
+	line := map[string]interface{}{}
+	r.NoError(json.Unmarshal(r.output.Bytes(), &line))
+
+	r.Equal("request served", line["msg"])
+	r.Equal("abc-123", line["request_id"])
+	r.Equal("GET", line["method"])
+	r.Equal("/guests/:guest_id", line["route"])
+	r.Equal("/guests/7", line["path"])
+	r.EqualValues(http.StatusTeapot, line["status"])
+	r.Contains(line, "latency_ms")
+	r.Contains(line, "client_ip")
+}
+
+func (r *RequestLogSuite) TestFromContextWithoutRequest() {
+	r.NotNil(FromContext(context.Background()))
+}
@@ -0,0 +1,49 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"os"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	"github.com/eazygood/getground-app/internal/venue"
+)
+
+type FileMailer struct {
+	dir  string
+	from *mail.Address
+}
+
+// NewFileMailer writes every email to dir as an .eml file instead of sending it, for development,
+// the files open in any mail client
+func NewFileMailer(dir string, from *mail.Address) (port.Mailer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create mail directory: %w", err)
+	}
+
+	return &FileMailer{dir: dir, from: from}, nil
+}
+
+func (m *FileMailer) Send(_ context.Context, email domain.Email) error {
+	now := venue.Now()
+
+	message, err := compose(m.from, email, now)
+	if err != nil {
+		return fmt.Errorf("compose email: %w", err)
+	}
+
+	// named after the time they were sent, so they list in that order
+	file, err := os.CreateTemp(m.dir, now.UTC().Format("20060102T150405.000")+"-*.eml")
+	if err != nil {
+		return fmt.Errorf("create email file: %w", err)
+	}
+
+	if _, err := file.Write(message); err != nil {
+		file.Close()
+		return fmt.Errorf("write email file: %w", err)
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,42 @@
+package mail
+
+import (
+	"context"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/eazygood/getground-app/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileMailerWritesEmlFiles(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+
+	mailer, err := NewMailer(config.Mail{Adapter: FileAdapter, From: "The Party <party@example.com>", Dir: dir})
+	require.NoError(t, err)
+
+	require.NoError(t, mailer.Send(context.Background(), testEmail))
+	require.NoError(t, mailer.Send(context.Background(), testEmail))
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	file, err := os.Open(files[0])
+	require.NoError(t, err)
+	defer file.Close()
+
+	message, err := mail.ReadMessage(file)
+	require.NoError(t, err)
+	require.Equal(t, "Your table at the party", message.Header.Get("Subject"))
+}
+
+func TestNewMailerRejectsUnknownAdapter(t *testing.T) {
+	_, err := NewMailer(config.Mail{Adapter: "pigeon", From: "party@example.com"})
+	require.ErrorContains(t, err, `unknown mail adapter "pigeon"`)
+
+	_, err = NewMailer(config.Mail{Adapter: FileAdapter, From: "not an address"})
+	require.ErrorContains(t, err, "invalid mail sender")
+}
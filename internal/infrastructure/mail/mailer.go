@@ -0,0 +1,32 @@
+package mail
+
+import (
+	"fmt"
+	"net/mail"
+
+	"github.com/eazygood/getground-app/internal/config"
+	"github.com/eazygood/getground-app/internal/core/port"
+)
+
+// Adapters the emails can be sent with
+const (
+	SMTPAdapter = "smtp"
+	FileAdapter = "file"
+)
+
+// NewMailer builds the adapter named in the config
+func NewMailer(cfg config.Mail) (port.Mailer, error) {
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mail sender %q: %w", cfg.From, err)
+	}
+
+	switch cfg.Adapter {
+	case SMTPAdapter:
+		return NewSMTPMailer(cfg.SMTP, from), nil
+	case FileAdapter:
+		return NewFileMailer(cfg.Dir, from)
+	default:
+		return nil, fmt.Errorf("unknown mail adapter %q, expected %s or %s", cfg.Adapter, SMTPAdapter, FileAdapter)
+	}
+}
@@ -0,0 +1,111 @@
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"time"
+
+	"github.com/eazygood/getground-app/internal/config"
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	"github.com/eazygood/getground-app/internal/venue"
+)
+
+// defaultTimeout bounds the conversation with the SMTP server when the config leaves it out
+const defaultTimeout = 10 * time.Second
+
+type SMTPMailer struct {
+	cfg  config.MailSMTP
+	from *mail.Address
+}
+
+// NewSMTPMailer sends every email in a conversation of its own with the server, authenticated with PLAIN
+// when a username is configured
+func NewSMTPMailer(cfg config.MailSMTP, from *mail.Address) port.Mailer {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+
+	return &SMTPMailer{cfg: cfg, from: from}
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, email domain.Email) error {
+	message, err := compose(m.from, email, venue.Now())
+	if err != nil {
+		return fmt.Errorf("compose email: %w", err)
+	}
+
+	dialer := net.Dialer{Timeout: m.cfg.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(m.cfg.Host, m.cfg.Port))
+	if err != nil {
+		return fmt.Errorf("dial smtp server: %w", err)
+	}
+
+	deadline := time.Now().Add(m.cfg.Timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+
+	client, err := smtp.NewClient(conn, m.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("greet smtp server: %w", err)
+	}
+	defer client.Close()
+
+	if err := m.send(client, email.To, message); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+func (m *SMTPMailer) send(client *smtp.Client, to string, message []byte) error {
+	if m.cfg.StartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("smtp server does not support STARTTLS")
+		}
+
+		if err := client.StartTLS(&tls.Config{ServerName: m.cfg.Host}); err != nil {
+			return fmt.Errorf("start tls: %w", err)
+		}
+	}
+
+	if m.cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)); err != nil {
+			return fmt.Errorf("authenticate to smtp server: %w", err)
+		}
+	}
+
+	if err := client.Mail(m.from.Address); err != nil {
+		return fmt.Errorf("smtp MAIL FROM: %w", err)
+	}
+
+	if err := client.Rcpt(to); err != nil {
+		return fmt.Errorf("smtp RCPT TO %s: %w", to, err)
+	}
+
+	data, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp DATA: %w", err)
+	}
+
+	if _, err := data.Write(message); err != nil {
+		return fmt.Errorf("write email: %w", err)
+	}
+
+	if err := data.Close(); err != nil {
+		return fmt.Errorf("smtp DATA: %w", err)
+	}
+
+	return nil
+}
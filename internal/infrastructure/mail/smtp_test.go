@@ -0,0 +1,225 @@
+package mail
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eazygood/getground-app/internal/config"
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/stretchr/testify/require"
+)
+
+// received is a message the fake SMTP server accepted
+type received struct {
+	auth string
+	from string
+	to   []string
+	data []byte
+}
+
+// fakeSMTP speaks just enough SMTP to take the messages of the SMTP mailer, recipients
+// containing "reject" are refused
+type fakeSMTP struct {
+	addr     string
+	messages chan received
+}
+
+func startFakeSMTP(t *testing.T) *fakeSMTP {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	server := &fakeSMTP{addr: listener.Addr().String(), messages: make(chan received, 10)}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go server.serve(conn)
+		}
+	}()
+
+	return server
+}
+
+func (s *fakeSMTP) serve(conn net.Conn) {
+	defer conn.Close()
+
+	text := textproto.NewConn(conn)
+	text.PrintfLine("220 localhost fake ESMTP")
+
+	var message received
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+
+		command := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch command {
+		case "EHLO":
+			text.PrintfLine("250-localhost")
+			text.PrintfLine("250 AUTH PLAIN")
+		case "AUTH":
+			message.auth = line
+			text.PrintfLine("235 2.7.0 Authentication successful")
+		case "MAIL":
+			message.from = line
+			text.PrintfLine("250 OK")
+		case "RCPT":
+			if strings.Contains(line, "reject") {
+				text.PrintfLine("550 5.1.1 No such user")
+				continue
+			}
+
+			message.to = append(message.to, line)
+			text.PrintfLine("250 OK")
+		case "DATA":
+			text.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+			message.data, _ = text.ReadDotBytes()
+			text.PrintfLine("250 OK")
+			s.messages <- message
+			message = received{}
+		case "RSET", "NOOP":
+			message = received{}
+			text.PrintfLine("250 OK")
+		case "QUIT":
+			text.PrintfLine("221 Bye")
+			return
+		default:
+			text.PrintfLine("502 Command not implemented")
+		}
+	}
+}
+
+func (s *fakeSMTP) config(t *testing.T) config.MailSMTP {
+	host, port, err := net.SplitHostPort(s.addr)
+	require.NoError(t, err)
+
+	return config.MailSMTP{Host: host, Port: port, Timeout: time.Second}
+}
+
+var testEmail = domain.Email{
+	To:      "simon@example.com",
+	ToName:  "Simon Müller",
+	Subject: "Your table at the party",
+	Text:    "You are seated at table 3.",
+	HTML:    "<p>You are seated at <strong>table 3</strong>.</p>",
+}
+
+func TestSMTPMailerSendsMultipartEmail(t *testing.T) {
+	server := startFakeSMTP(t)
+	from := &mail.Address{Name: "The Party", Address: "party@example.com"}
+
+	err := NewSMTPMailer(server.config(t), from).Send(context.Background(), testEmail)
+	require.NoError(t, err)
+
+	message := <-server.messages
+	require.Equal(t, "MAIL FROM:<party@example.com>", message.from)
+	require.Equal(t, []string{"RCPT TO:<simon@example.com>"}, message.to)
+	require.Empty(t, message.auth)
+
+	parsed, err := mail.ReadMessage(strings.NewReader(string(message.data)))
+	require.NoError(t, err)
+
+	require.Equal(t, `"The Party" <party@example.com>`, parsed.Header.Get("From"))
+	require.Equal(t, "Your table at the party", parsed.Header.Get("Subject"))
+	require.True(t, strings.HasSuffix(parsed.Header.Get("Message-ID"), "@example.com>"))
+
+	to, err := parsed.Header.AddressList("To")
+	require.NoError(t, err)
+	require.Equal(t, []*mail.Address{{Name: "Simon Müller", Address: "simon@example.com"}}, to)
+
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/alternative", mediaType)
+
+	parts := multipart.NewReader(parsed.Body, params["boundary"])
+	for _, expected := range []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", testEmail.Text},
+		{"text/html; charset=utf-8", testEmail.HTML},
+	} {
+		part, err := parts.NextPart()
+		require.NoError(t, err)
+		require.Equal(t, expected.contentType, part.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(part)
+		require.NoError(t, err)
+		require.Equal(t, expected.body, string(body))
+	}
+
+	_, err = parts.NextPart()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestSMTPMailerAuthenticates(t *testing.T) {
+	server := startFakeSMTP(t)
+	cfg := server.config(t)
+	cfg.Username, cfg.Password = "party", "s3cr3t"
+
+	err := NewSMTPMailer(cfg, &mail.Address{Address: "party@example.com"}).Send(context.Background(), testEmail)
+	require.NoError(t, err)
+
+	message := <-server.messages
+	require.True(t, strings.HasPrefix(message.auth, "AUTH PLAIN "), message.auth)
+}
+
+func TestSMTPMailerRequiresStartTLS(t *testing.T) {
+	server := startFakeSMTP(t)
+	cfg := server.config(t)
+	cfg.StartTLS = true
+
+	err := NewSMTPMailer(cfg, &mail.Address{Address: "party@example.com"}).Send(context.Background(), testEmail)
+	require.ErrorContains(t, err, "does not support STARTTLS")
+}
+
+func TestSMTPMailerReportsRejectedRecipient(t *testing.T) {
+	server := startFakeSMTP(t)
+	email := testEmail
+	email.To = "reject@example.com"
+
+	err := NewSMTPMailer(server.config(t), &mail.Address{Address: "party@example.com"}).Send(context.Background(), email)
+	require.ErrorContains(t, err, "RCPT TO reject@example.com")
+	require.ErrorContains(t, err, "550")
+}
+
+func TestSMTPMailerTimesOutOnSilentServer(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			// never greets
+			bufio.NewReader(conn).ReadString('\n')
+			conn.Close()
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+	cfg := config.MailSMTP{Host: host, Port: port, Timeout: 100 * time.Millisecond}
+
+	err = NewSMTPMailer(cfg, &mail.Address{Address: "party@example.com"}).Send(context.Background(), testEmail)
+	require.ErrorContains(t, err, "greet smtp server")
+}
+
+func TestComposeRejectsHeaderInjection(t *testing.T) {
+	email := testEmail
+	email.To = "simon@example.com\r\nBcc: everyone@example.com"
+
+	_, err := compose(&mail.Address{Address: "party@example.com"}, email, time.Now())
+	require.Error(t, err)
+}
@@ -0,0 +1,102 @@
+package mail
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"os"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	"github.com/eazygood/getground-app/internal/venue"
+)
+
+//go:embed templates
+var builtin embed.FS
+
+// dateLayout is how the templates render the time of the event, in the timezone of the venue
+const dateLayout = "Monday 2 January 2006 at 15:04"
+
+var funcs = map[string]interface{}{
+	"date": func(t time.Time) string {
+		return t.In(venue.Location()).Format(dateLayout)
+	},
+}
+
+// Templates renders every kind of email from <kind>.txt and <kind>.html, the text template defines
+// the subject in a "subject" template of its own
+type Templates struct {
+	text map[string]*texttemplate.Template
+	html map[string]*htmltemplate.Template
+}
+
+// NewTemplates parses the templates in dir, or the built-in ones when dir is empty, so a missing
+// or broken template fails on startup rather than on the first email
+func NewTemplates(dir string) (port.MailTemplates, error) {
+	fsys, err := fs.Sub(builtin, "templates")
+	if err != nil {
+		return nil, err
+	}
+
+	if dir != "" {
+		fsys = os.DirFS(dir)
+	}
+
+	templates := &Templates{
+		text: make(map[string]*texttemplate.Template, len(domain.EmailKinds)),
+		html: make(map[string]*htmltemplate.Template, len(domain.EmailKinds)),
+	}
+
+	for _, kind := range domain.EmailKinds {
+		text, err := texttemplate.New(kind+".txt").Funcs(funcs).ParseFS(fsys, kind+".txt")
+		if err != nil {
+			return nil, fmt.Errorf("parse %s text template: %w", kind, err)
+		}
+
+		if text.Lookup("subject") == nil {
+			return nil, fmt.Errorf("%s text template does not define a subject", kind)
+		}
+
+		html, err := htmltemplate.New(kind+".html").Funcs(funcs).ParseFS(fsys, kind+".html")
+		if err != nil {
+			return nil, fmt.Errorf("parse %s html template: %w", kind, err)
+		}
+
+		templates.text[kind] = text
+		templates.html[kind] = html
+	}
+
+	return templates, nil
+}
+
+func (t *Templates) Render(kind string, data domain.EmailData) (*domain.Email, error) {
+	text, ok := t.text[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown email kind %q", kind)
+	}
+
+	var subject, body, html bytes.Buffer
+
+	if err := text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, fmt.Errorf("render %s subject: %w", kind, err)
+	}
+
+	if err := text.Execute(&body, data); err != nil {
+		return nil, fmt.Errorf("render %s text: %w", kind, err)
+	}
+
+	if err := t.html[kind].Execute(&html, data); err != nil {
+		return nil, fmt.Errorf("render %s html: %w", kind, err)
+	}
+
+	return &domain.Email{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    body.String(),
+		HTML:    html.String(),
+	}, nil
+}
@@ -0,0 +1,78 @@
+package mail
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/stretchr/testify/require"
+)
+
+var eventDate = time.Date(2023, 12, 15, 19, 0, 0, 0, time.UTC)
+
+func TestBuiltinTemplatesRenderEveryKind(t *testing.T) {
+	templates, err := NewTemplates("")
+	require.NoError(t, err)
+
+	guest := &domain.Guest{ID: 1, Name: "Simon <3", AccompanyingGuests: 2, Status: domain.GuestStatusTentative}
+	data := domain.EmailData{Guest: guest, Event: "The Party", EventDate: eventDate, Table: &domain.Table{ID: 3}}
+
+	invitation, err := templates.Render(domain.EmailInvitation, data)
+	require.NoError(t, err)
+	require.Equal(t, "You are invited to The Party", invitation.Subject)
+	require.Contains(t, invitation.Text, "Dear Simon <3,")
+	require.Contains(t, invitation.Text, "you and 2 accompanying guests")
+	require.Contains(t, invitation.HTML, "Dear Simon &lt;3,")
+	require.NotContains(t, invitation.Text, "subject")
+
+	reminder, err := templates.Render(domain.EmailReminder, data)
+	require.NoError(t, err)
+	require.Equal(t, "The Party is coming up", reminder.Subject)
+	require.Contains(t, reminder.Text, "You told us you might come")
+
+	assignment, err := templates.Render(domain.EmailTableAssignment, data)
+	require.NoError(t, err)
+	require.Equal(t, "Your table at The Party", assignment.Subject)
+	require.Contains(t, assignment.Text, "You are seated at table 3")
+	require.Contains(t, assignment.HTML, "table 3")
+
+	_, err = templates.Render("postcard", data)
+	require.ErrorContains(t, err, `unknown email kind "postcard"`)
+}
+
+func TestTemplatesFromDirectory(t *testing.T) {
+	dir := t.TempDir()
+	for _, kind := range domain.EmailKinds {
+		write(t, dir, kind+".txt", `{{define "subject"}}Hi {{.Guest.Name}}{{end -}}`+kind)
+		write(t, dir, kind+".html", "<p>"+kind+"</p>")
+	}
+
+	templates, err := NewTemplates(dir)
+	require.NoError(t, err)
+
+	email, err := templates.Render(domain.EmailReminder, domain.EmailData{Guest: &domain.Guest{Name: "Simon"}})
+	require.NoError(t, err)
+	require.Equal(t, "Hi Simon", email.Subject)
+	require.Equal(t, "reminder", email.Text)
+	require.Equal(t, "<p>reminder</p>", email.HTML)
+}
+
+func TestTemplatesRequireEveryKindAndSubject(t *testing.T) {
+	dir := t.TempDir()
+	write(t, dir, domain.EmailInvitation+".txt", "no subject")
+	write(t, dir, domain.EmailInvitation+".html", "<p>no subject</p>")
+
+	_, err := NewTemplates(dir)
+	require.ErrorContains(t, err, "invitation text template does not define a subject")
+
+	write(t, dir, domain.EmailInvitation+".txt", `{{define "subject"}}Hi{{end}}`)
+
+	_, err = NewTemplates(dir)
+	require.ErrorContains(t, err, "parse reminder text template")
+}
+
+func write(t *testing.T, dir, name, content string) {
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+}
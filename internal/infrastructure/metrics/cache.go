@@ -0,0 +1,11 @@
+package metrics
+
+// ObserveCache counts a lookup of the named cache as a hit or a miss
+func ObserveCache(cache string, hit bool) {
+	if hit {
+		cacheHits.WithLabelValues(cache).Inc()
+		return
+	}
+
+	cacheMisses.WithLabelValues(cache).Inc()
+}
@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// unmatchedRoute labels requests that did not match any route, so that random paths
+// cannot blow up the label cardinality
+const unmatchedRoute = "unmatched"
+
+// Middleware counts requests and observes their latency per gin route
+func Middleware() gin.HandlerFunc {
+	return func(request *gin.Context) {
+		start := time.Now()
+
+		request.Next()
+
+		route := request.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+
+		method := request.Request.Method
+		httpRequests.WithLabelValues(method, route, strconv.Itoa(request.Writer.Status())).Inc()
+		httpDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,101 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/eazygood/getground-app/internal/core/port"
+	"github.com/prometheus/client_golang/prometheus"
+	logger "github.com/sirupsen/logrus"
+)
+
+// occupancyTimeout bounds the service calls made on every scrape
+const occupancyTimeout = 5 * time.Second
+
+var (
+	guestsArrivedDesc = prometheus.NewDesc(namespace+"_party_guests_arrived",
+		"People at the party, arrived guests including their entourage.", nil, nil)
+	guestsExpectedDesc = prometheus.NewDesc(namespace+"_party_guests_expected",
+		"People expected at the party, invited guests including their entourage.", nil, nil)
+	totalSeatsDesc = prometheus.NewDesc(namespace+"_party_seats_total",
+		"Seats over all tables.", nil, nil)
+	emptySeatsDesc = prometheus.NewDesc(namespace+"_party_seats_empty",
+		"Seats at tables without a guest.", nil, nil)
+	waitlistDesc = prometheus.NewDesc(namespace+"_party_waitlist_length",
+		"Arrived guests that are not seated at any table.", nil, nil)
+)
+
+// OccupancyCollector reports the state of the party, it is refreshed from the services on every scrape
+type OccupancyCollector struct {
+	guestService     port.GuestService
+	tableService     port.TableService
+	guestListService port.GuestListService
+}
+
+func NewOccupancyCollector(guest port.GuestService, table port.TableService, guestList port.GuestListService) *OccupancyCollector {
+	return &OccupancyCollector{
+		guestService:     guest,
+		tableService:     table,
+		guestListService: guestList,
+	}
+}
+
+func (o *OccupancyCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- guestsArrivedDesc
+	ch <- guestsExpectedDesc
+	ch <- totalSeatsDesc
+	ch <- emptySeatsDesc
+	ch <- waitlistDesc
+}
+
+func (o *OccupancyCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), occupancyTimeout)
+	defer cancel()
+
+	guests, err := o.guestService.GetList(ctx, port.GetGuestFilter{})
+	if err != nil {
+		logger.WithError(err).Error("failed to collect guest metrics")
+		return
+	}
+
+	occupied, err := o.guestListService.GetOccupiedSeats(ctx)
+	if err != nil {
+		logger.WithError(err).Error("failed to collect occupied seats metrics")
+		return
+	}
+
+	emptySeats, err := o.tableService.GetEmptySeats(ctx)
+	if err != nil {
+		logger.WithError(err).Error("failed to collect empty seats metrics")
+		return
+	}
+
+	seated := make(map[int64]bool, len(occupied))
+	totalSeats := emptySeats
+	for _, table := range occupied {
+		totalSeats += int64(table.Seats)
+		if table.GuestID != nil {
+			seated[*table.GuestID] = true
+		}
+	}
+
+	var arrived, expected, waitlist int
+	for _, guest := range guests {
+		party := 1 + int(guest.AccompanyingGuests)
+		expected += party
+
+		if guest.IsArrived {
+			arrived += party
+
+			if !seated[guest.ID] {
+				waitlist++
+			}
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(guestsArrivedDesc, prometheus.GaugeValue, float64(arrived))
+	ch <- prometheus.MustNewConstMetric(guestsExpectedDesc, prometheus.GaugeValue, float64(expected))
+	ch <- prometheus.MustNewConstMetric(totalSeatsDesc, prometheus.GaugeValue, float64(totalSeats))
+	ch <- prometheus.MustNewConstMetric(emptySeatsDesc, prometheus.GaugeValue, float64(emptySeats))
+	ch <- prometheus.MustNewConstMetric(waitlistDesc, prometheus.GaugeValue, float64(waitlist))
+}
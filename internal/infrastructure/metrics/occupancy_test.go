@@ -0,0 +1,86 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	mockPort "github.com/eazygood/getground-app/mocks/core/port"
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type OccupancyCollectorSuite struct {
+	suite.Suite
+	*require.Assertions
+	ctrl                 *gomock.Controller
+	mockGuestService     *mockPort.MockGuestService
+	mockTableService     *mockPort.MockTableService
+	mockGuestListService *mockPort.MockGuestListService
+	collector            *OccupancyCollector
+}
+
+func TestOccupancyCollectorSuite(t *testing.T) {
+	suite.Run(t, new(OccupancyCollectorSuite))
+}
+
+func (o *OccupancyCollectorSuite) SetupTest() {
+	o.Assertions = require.New(o.T())
+	o.ctrl = gomock.NewController(o.T())
+	o.mockGuestService = mockPort.NewMockGuestService(o.ctrl)
+	o.mockTableService = mockPort.NewMockTableService(o.ctrl)
+	o.mockGuestListService = mockPort.NewMockGuestListService(o.ctrl)
+	o.collector = NewOccupancyCollector(o.mockGuestService, o.mockTableService, o.mockGuestListService)
+}
+
+func (o *OccupancyCollectorSuite) TearDownTest() {
+	o.ctrl.Finish()
+}
+
+func (o *OccupancyCollectorSuite) TestCollect() {
+	seatedGuestID := int64(1)
+
+	guests := []*domain.Guest{
+		{ID: 1, AccompanyingGuests: 3, IsArrived: true},
+		{ID: 2, AccompanyingGuests: 1, IsArrived: true},
+		{ID: 3, AccompanyingGuests: 4},
+	}
+
+	occupied := []*domain.Table{
+		{ID: 1, Seats: 5, GuestID: &seatedGuestID},
+	}
+
+	o.mockGuestService.EXPECT().GetList(gomock.Any(), port.GetGuestFilter{}).Return(guests, nil).Times(1)
+	o.mockGuestListService.EXPECT().GetOccupiedSeats(gomock.Any()).Return(occupied, nil).Times(1)
+	o.mockTableService.EXPECT().GetEmptySeats(gomock.Any()).Return(int64(12), nil).Times(1)
+
+	want := `
+# HELP getground_party_guests_arrived People at the party, arrived guests including their entourage.
+# TYPE getground_party_guests_arrived gauge
+getground_party_guests_arrived 6
+# HELP getground_party_guests_expected People expected at the party, invited guests including their entourage.
+# TYPE getground_party_guests_expected gauge
+getground_party_guests_expected 11
+# HELP getground_party_seats_empty Seats at tables without a guest.
+# TYPE getground_party_seats_empty gauge
+getground_party_seats_empty 12
+# HELP getground_party_seats_total Seats over all tables.
+# TYPE getground_party_seats_total gauge
+getground_party_seats_total 17
+# HELP getground_party_waitlist_length Arrived guests that are not seated at any table.
+# TYPE getground_party_waitlist_length gauge
+getground_party_waitlist_length 1
+`
+
+	o.NoError(testutil.CollectAndCompare(o.collector, strings.NewReader(want)))
+}
+
+func (o *OccupancyCollectorSuite) TestCollectSkipsOnServiceError() {
+	o.mockGuestService.EXPECT().GetList(gomock.Any(), port.GetGuestFilter{}).Return(nil, context.DeadlineExceeded).Times(1)
+
+	o.Equal(0, testutil.CollectAndCount(o.collector))
+}
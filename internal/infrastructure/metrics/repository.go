@@ -0,0 +1,12 @@
+package metrics
+
+import "time"
+
+// ObserveQuery records the duration of a repository call and counts it as failed when err is set
+func ObserveQuery(repository string, method string, start time.Time, err error) {
+	queryDuration.WithLabelValues(repository, method).Observe(time.Since(start).Seconds())
+
+	if err != nil {
+		queryErrors.WithLabelValues(repository, method).Inc()
+	}
+}
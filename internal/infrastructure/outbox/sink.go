@@ -0,0 +1,73 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	logger "github.com/sirupsen/logrus"
+)
+
+// Names of the sinks the outbox can publish to
+const (
+	WebhookSinkName = "webhooks"
+	LogSinkName     = "log"
+)
+
+// NewSinks builds the sinks listed in the config, in that order
+func NewSinks(names []string, webhookService port.WebhookService) ([]port.EventSink, error) {
+	sinks := make([]port.EventSink, 0, len(names))
+	for _, name := range names {
+		switch name {
+		case WebhookSinkName:
+			sinks = append(sinks, NewWebhookSink(webhookService))
+		case LogSinkName:
+			sinks = append(sinks, NewLogSink())
+		default:
+			return nil, fmt.Errorf("unknown outbox sink %q, expected %s or %s", name, WebhookSinkName, LogSinkName)
+		}
+	}
+
+	return sinks, nil
+}
+
+type WebhookSink struct {
+	webhookService port.WebhookService
+}
+
+// NewWebhookSink queues the events for the webhooks subscribed to them, an event queued twice
+// is delivered once
+func NewWebhookSink(webhookService port.WebhookService) port.EventSink {
+	return &WebhookSink{webhookService: webhookService}
+}
+
+func (s *WebhookSink) Name() string {
+	return WebhookSinkName
+}
+
+func (s *WebhookSink) Publish(ctx context.Context, event domain.Event) error {
+	return s.webhookService.Publish(ctx, event)
+}
+
+type LogSink struct{}
+
+// NewLogSink writes the events to the application log, where they can be shipped to a log pipeline
+func NewLogSink() port.EventSink {
+	return &LogSink{}
+}
+
+func (s *LogSink) Name() string {
+	return LogSinkName
+}
+
+func (s *LogSink) Publish(_ context.Context, event domain.Event) error {
+	logger.WithFields(logger.Fields{
+		"event_id":    event.ID,
+		"event_type":  event.Type,
+		"occurred_at": event.OccurredAt,
+		"data":        string(event.Data),
+	}).Info("event published")
+
+	return nil
+}
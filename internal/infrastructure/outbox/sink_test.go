@@ -0,0 +1,32 @@
+package outbox
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	ports "github.com/eazygood/getground-app/mocks/core/port"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSinks(t *testing.T) {
+	sinks, err := NewSinks([]string{"webhooks", "log"}, nil)
+	require.NoError(t, err)
+	require.Len(t, sinks, 2)
+	require.Equal(t, WebhookSinkName, sinks[0].Name())
+	require.Equal(t, LogSinkName, sinks[1].Name())
+
+	_, err = NewSinks([]string{"kafka"}, nil)
+	require.EqualError(t, err, `unknown outbox sink "kafka", expected webhooks or log`)
+}
+
+func TestWebhookSinkQueuesDeliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	webhookService := ports.NewMockWebhookService(ctrl)
+	event := domain.Event{ID: "4f1c", Type: domain.EventGuestArrived}
+
+	webhookService.EXPECT().Publish(gomock.Any(), event).Return(nil).Times(1)
+
+	require.NoError(t, NewWebhookSink(webhookService).Publish(context.Background(), event))
+}
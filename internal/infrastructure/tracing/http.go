@@ -0,0 +1,46 @@
+package tracing
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Middleware starts a server span for every request, continuing the trace of the incoming
+// traceparent header if any. The span is the parent of the service and repository spans,
+// which reach it through the *gin.Context when the engine has ContextWithFallback enabled.
+func Middleware() gin.HandlerFunc {
+	return func(request *gin.Context) {
+		ctx := otel.GetTextMapPropagator().Extract(request.Request.Context(), propagation.HeaderCarrier(request.Request.Header))
+
+		route := request.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		ctx, span := Start(ctx, request.Request.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPMethodKey.String(request.Request.Method),
+				semconv.HTTPRouteKey.String(route),
+				semconv.HTTPTargetKey.String(request.Request.URL.Path),
+			),
+		)
+		defer span.End()
+
+		request.Request = request.Request.WithContext(ctx)
+
+		request.Next()
+
+		status := request.Writer.Status()
+		span.SetAttributes(semconv.HTTPStatusCodeKey.Int(status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	}
+}
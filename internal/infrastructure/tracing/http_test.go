@@ -0,0 +1,80 @@
+package tracing
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type TracingMiddlewareSuite struct {
+	suite.Suite
+	*require.Assertions
+	exporter *tracetest.InMemoryExporter
+	router   *gin.Engine
+}
+
+func TestTracingMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(TracingMiddlewareSuite))
+}
+
+func (t *TracingMiddlewareSuite) SetupTest() {
+	t.Assertions = require.New(t.T())
+	t.exporter = InitInMemory()
+
+	gin.SetMode(gin.TestMode)
+	t.router = gin.New()
+	t.router.ContextWithFallback = true
+	t.router.Use(Middleware())
+	t.router.GET("/guests/:guest_id", func(request *gin.Context) {
+		// what a service decorator receiving the *gin.Context does
+		_, span := Start(request, "service.guest.GetById")
+		End(span, errors.New("record not found by id: 7"))
+
+		request.Status(http.StatusInternalServerError)
+	})
+}
+
+func (t *TracingMiddlewareSuite) TestContinuesIncomingTrace() {
+	traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/guests/7", nil)
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+
+	t.router.ServeHTTP(w, req)
+
+	spans := t.exporter.GetSpans()
+	t.Len(spans, 2)
+
+	service, server := spans[0], spans[1]
+
+	t.Equal("GET /guests/:guest_id", server.Name)
+	t.Equal(trace.SpanKindServer, server.SpanKind)
+	t.Equal(traceID, server.SpanContext.TraceID().String())
+	t.Equal("00f067aa0ba902b7", server.Parent.SpanID().String())
+	t.Equal(codes.Error, server.Status.Code)
+
+	t.Equal("service.guest.GetById", service.Name)
+	t.Equal(server.SpanContext.SpanID(), service.Parent.SpanID())
+	t.Equal(codes.Error, service.Status.Code)
+	t.Len(service.Events, 1)
+}
+
+func (t *TracingMiddlewareSuite) TestStartsNewTrace() {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/guests/7", nil)
+
+	t.router.ServeHTTP(w, req)
+
+	spans := t.exporter.GetSpans()
+	t.Len(spans, 2)
+	t.False(spans[1].Parent.IsValid())
+}
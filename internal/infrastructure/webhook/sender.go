@@ -0,0 +1,156 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+)
+
+// Headers of every delivery, receivers check the signature with the secret of the webhook
+// and drop the events whose id they already handled, as a delivery may be made more than once
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	EventHeader     = "X-Webhook-Event"
+	EventIDHeader   = "X-Webhook-Event-Id"
+	DeliveryHeader  = "X-Webhook-Delivery"
+)
+
+// maxResponseBody is how much of a response is read before the connection is released
+const maxResponseBody = 4 << 10
+
+// sharedAddressSpace is the carrier-grade NAT range, not routed on the internet either
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+type HTTPSender struct {
+	client       *http.Client
+	allowPrivate bool
+}
+
+// NewHTTPSender posts the payloads of the deliveries, every request is bounded by timeout. Unless allowPrivate,
+// the receivers must be on public addresses: the address of every connection is checked once it is resolved, so
+// that a webhook cannot reach the services next to the app nor the cloud metadata endpoint. Redirects are never
+// followed, a receiver answering one fails the delivery.
+func NewHTTPSender(timeout time.Duration, allowPrivate bool) port.WebhookSender {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivate {
+		dialer.Control = controlPublic
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// a proxy would make the checked connection the one to the proxy rather than to the receiver
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &HTTPSender{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		allowPrivate: allowPrivate,
+	}
+}
+
+// Check resolves the host of rawURL and tells whether one of its addresses is not public
+func (s *HTTPSender) Check(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("parse webhook url: %w", err)
+	}
+
+	if s.allowPrivate {
+		return nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, u.Hostname())
+	if err != nil {
+		return fmt.Errorf("resolve webhook host: %w", err)
+	}
+
+	for _, addr := range addrs {
+		if err := checkPublic(addr.IP); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *HTTPSender) Send(ctx context.Context, webhook *domain.Webhook, delivery *domain.WebhookDelivery) (int, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("build webhook request: %w", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "getground-webhooks/1.0")
+	request.Header.Set(SignatureHeader, Sign(webhook.Secret, delivery.Payload))
+	request.Header.Set(EventHeader, delivery.EventType)
+	request.Header.Set(EventIDHeader, delivery.EventID)
+	request.Header.Set(DeliveryHeader, strconv.FormatInt(delivery.ID, 10))
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return 0, fmt.Errorf("post webhook: %w", err)
+	}
+	defer response.Body.Close()
+
+	// drained so that the connection is reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, maxResponseBody))
+
+	return response.StatusCode, nil
+}
+
+// controlPublic refuses to connect to an address that is not public, it is called with the resolved address
+// right before every connection, redirects and DNS answers changing after Check included
+func controlPublic(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("%s is not an IP address", host)
+	}
+
+	return checkPublic(ip)
+}
+
+func checkPublic(ip net.IP) error {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() || sharedAddressSpace.Contains(ip) {
+		return fmt.Errorf("%s is not a public address", ip)
+	}
+
+	return nil
+}
+
+// Sign returns the signature header value of payload, the hex encoded HMAC-SHA256 of the payload
+// keyed with the secret of the webhook, prefixed with the algorithm
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify tells whether signature is the signature of payload, in constant time
+func Verify(secret string, payload []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, payload)), []byte(signature))
+}
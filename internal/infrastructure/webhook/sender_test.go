@@ -0,0 +1,132 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendSignsPayload(t *testing.T) {
+	received := make(chan *http.Request, 1)
+	bodies := make(chan []byte, 1)
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- r
+		bodies <- body
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer receiver.Close()
+
+	webhook := &domain.Webhook{ID: 1, URL: receiver.URL, Secret: "s3cr3t-s3cr3t-s3cr3t"}
+	delivery := &domain.WebhookDelivery{
+		ID:        7,
+		EventID:   "4f1c",
+		EventType: domain.EventGuestArrived,
+		Payload:   []byte(`{"id":"4f1c","type":"guest.arrived","data":{"id":1,"name":"Simon"}}`),
+	}
+
+	status, err := NewHTTPSender(time.Second, true).Send(context.Background(), webhook, delivery)
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusAccepted, status)
+
+	request, body := <-received, <-bodies
+	require.Equal(t, http.MethodPost, request.Method)
+	require.Equal(t, "application/json", request.Header.Get("Content-Type"))
+	require.Equal(t, domain.EventGuestArrived, request.Header.Get(EventHeader))
+	require.Equal(t, "4f1c", request.Header.Get(EventIDHeader))
+	require.Equal(t, "7", request.Header.Get(DeliveryHeader))
+	require.JSONEq(t, string(delivery.Payload), string(body))
+	require.True(t, Verify(webhook.Secret, body, request.Header.Get(SignatureHeader)))
+	require.False(t, Verify("another-secret", body, request.Header.Get(SignatureHeader)))
+}
+
+func TestSendReportsFailures(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	webhook := &domain.Webhook{ID: 1, URL: receiver.URL, Secret: "s3cr3t-s3cr3t-s3cr3t"}
+	delivery := &domain.WebhookDelivery{ID: 1, Payload: []byte(`{}`)}
+
+	status, err := NewHTTPSender(time.Second, true).Send(context.Background(), webhook, delivery)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusInternalServerError, status)
+
+	receiver.Close()
+
+	_, err = NewHTTPSender(time.Second, true).Send(context.Background(), webhook, delivery)
+	require.Error(t, err)
+}
+
+func TestSendRefusesPrivateAddresses(t *testing.T) {
+	hit := false
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hit = true
+	}))
+	defer receiver.Close()
+
+	webhook := &domain.Webhook{ID: 1, URL: receiver.URL, Secret: "s3cr3t-s3cr3t-s3cr3t"}
+	delivery := &domain.WebhookDelivery{ID: 1, Payload: []byte(`{}`)}
+
+	_, err := NewHTTPSender(time.Second, false).Send(context.Background(), webhook, delivery)
+
+	require.ErrorContains(t, err, "127.0.0.1 is not a public address")
+	require.False(t, hit)
+}
+
+func TestSendDoesNotFollowRedirects(t *testing.T) {
+	hit := false
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hit = true
+	}))
+	defer target.Close()
+
+	receiver := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusTemporaryRedirect))
+	defer receiver.Close()
+
+	webhook := &domain.Webhook{ID: 1, URL: receiver.URL, Secret: "s3cr3t-s3cr3t-s3cr3t"}
+	delivery := &domain.WebhookDelivery{ID: 1, Payload: []byte(`{}`)}
+
+	status, err := NewHTTPSender(time.Second, true).Send(context.Background(), webhook, delivery)
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusTemporaryRedirect, status)
+	require.False(t, hit)
+}
+
+func TestCheck(t *testing.T) {
+	sender := NewHTTPSender(time.Second, false)
+	ctx := context.Background()
+
+	for _, url := range []string{
+		"http://127.0.0.1:8081/hooks",
+		"http://169.254.169.254/latest/meta-data/",
+		"https://10.0.0.7/hooks",
+		"https://192.168.1.20/hooks",
+		"https://100.64.0.1/hooks",
+		"http://[::1]/hooks",
+		"http://[fd00::1]/hooks",
+		"http://0.0.0.0/hooks",
+	} {
+		require.Error(t, sender.Check(ctx, url), url)
+	}
+
+	require.NoError(t, sender.Check(ctx, "https://93.184.216.34/hooks"))
+	require.NoError(t, NewHTTPSender(time.Second, true).Check(ctx, "http://127.0.0.1:8081/hooks"))
+}
+
+func TestSign(t *testing.T) {
+	// echo -n '{}' | openssl dgst -sha256 -hmac secret
+	require.Equal(t, "sha256=77325902caca812dc259733aacd046b73817372c777b8d95b402647474516e13", Sign("secret", []byte(`{}`)))
+	require.True(t, Verify("secret", []byte(`{}`), Sign("secret", []byte(`{}`))))
+	require.False(t, Verify("secret", []byte(`{}`), "sha256=00"))
+	require.False(t, Verify("secret", []byte(`{}`), "not a signature"))
+}
@@ -0,0 +1,56 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	logger "github.com/sirupsen/logrus"
+)
+
+// defaultInterval is used when the config leaves the poll interval out
+const defaultInterval = time.Second
+
+// Poll handles a batch of pending work and returns how much of it there was
+type Poll func(ctx context.Context) (int, error)
+
+type Poller struct {
+	name     string
+	poll     Poll
+	interval time.Duration
+}
+
+// NewPoller runs poll every interval, name tells the pollers apart in the logs
+func NewPoller(name string, poll Poll, interval time.Duration) *Poller {
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
+	return &Poller{name: name, poll: poll, interval: interval}
+}
+
+// Run polls until ctx is done, it polls again right away as long as there is work,
+// so that a backlog is not drained one batch per interval
+func (p *Poller) Run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		handled, err := p.poll(ctx)
+		if err != nil && ctx.Err() == nil {
+			logger.WithError(err).WithField("worker", p.name).Error("failed to poll")
+		}
+
+		if handled > 0 && err == nil {
+			timer.Reset(0)
+			continue
+		}
+
+		timer.Reset(p.interval)
+	}
+}
@@ -0,0 +1,61 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPollerDrainsBacklog(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var polls int32
+	backlog := int32(3)
+	done := make(chan struct{})
+
+	poller := NewPoller("test", func(context.Context) (int, error) {
+		atomic.AddInt32(&polls, 1)
+		if atomic.AddInt32(&backlog, -1) >= 0 {
+			return 1, nil
+		}
+
+		close(done)
+		return 0, nil
+	}, time.Hour)
+
+	go poller.Run(ctx)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("the backlog was not drained without waiting for the interval")
+	}
+
+	require.EqualValues(t, 4, atomic.LoadInt32(&polls))
+}
+
+func TestPollerWaitsAfterFailure(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	var polls int32
+	stopped := make(chan struct{})
+
+	poller := NewPoller("test", func(context.Context) (int, error) {
+		atomic.AddInt32(&polls, 1)
+		return 1, errors.New("database is gone")
+	}, time.Hour)
+
+	go func() {
+		poller.Run(ctx)
+		close(stopped)
+	}()
+
+	<-stopped
+	require.EqualValues(t, 1, atomic.LoadInt32(&polls))
+}
@@ -0,0 +1,145 @@
+package companion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	infra "github.com/eazygood/getground-app/internal/infrastructure/db"
+	"github.com/eazygood/getground-app/internal/repository/outbox"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MysqlCompanionAdapter struct {
+	Conn *gorm.DB
+}
+
+func NewMysqlCompanionAdapter(Conn *gorm.DB) port.CompanionRepository {
+	return &MysqlCompanionAdapter{
+		Conn: Conn,
+	}
+}
+
+func (m *MysqlCompanionAdapter) GetAll(ctx context.Context, guestIDs ...int64) ([]*domain.Companion, error) {
+	var companions []*domain.Companion
+
+	err := infra.Replica(ctx, m.Conn).Where("guest_id IN ?", guestIDs).Order("id").Find(&companions).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get list of companions: %v", err.Error())
+	}
+
+	return companions, nil
+}
+
+func (m *MysqlCompanionAdapter) Create(ctx context.Context, companion *domain.Companion) (*domain.Companion, error) {
+	err := m.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// only the guests who did not leave can bring someone, their row stays locked until the companion
+		// is counted so that two companions named at once are checked against the table one after the other
+		guest := &domain.Guest{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "accompanying_guests", "unnamed_guests").
+			First(guest, companion.GuestID).Error
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w by id: %v", domain.ErrNotFound, companion.GuestID)
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to get guest by id (%v) %v", companion.GuestID, err.Error())
+		}
+
+		if err := fitsTable(tx, guest); err != nil {
+			return err
+		}
+
+		if err := tx.Create(companion).Error; err != nil {
+			return fmt.Errorf("failed to insert companion: %v", err.Error())
+		}
+
+		// the companion is one of the unnamed guests if any are left, one more person otherwise
+		err = tx.Unscoped().Model(&domain.Guest{}).Where("id = ? AND unnamed_guests > 0", companion.GuestID).
+			Update("unnamed_guests", gorm.Expr("unnamed_guests - 1")).Error
+
+		if err != nil {
+			return fmt.Errorf("failed to name guest of guest (%v) %v", companion.GuestID, err.Error())
+		}
+
+		return countCompanions(tx, companion.GuestID)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	infra.MarkWrite(ctx)
+
+	return companion, nil
+}
+
+func (m *MysqlCompanionAdapter) Delete(ctx context.Context, guestID int64, id int64) error {
+	err := m.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("guest_id = ?", guestID).Delete(&domain.Companion{}, id)
+
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete companion by id (%v) %v", id, result.Error.Error())
+		}
+
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("record not found by id: %v", id)
+		}
+
+		return countCompanions(tx, guestID)
+	})
+
+	if err == nil {
+		infra.MarkWrite(ctx)
+	}
+
+	return err
+}
+
+// fitsTable checks that the table of a seated guest still fits their party once one more companion is named.
+// Naming a companion takes one of the unnamed guests first, the party only grows when none is left.
+func fitsTable(tx *gorm.DB, guest *domain.Guest) error {
+	if guest.UnnamedGuests > 0 {
+		return nil
+	}
+
+	var tables []*domain.Table
+	if err := tx.Where("guest_id = ?", guest.ID).Find(&tables).Error; err != nil {
+		return fmt.Errorf("failed to get table of guest (%v) %v", guest.ID, err.Error())
+	}
+
+	party := 1 + int(guest.AccompanyingGuests) + 1
+	for _, table := range tables {
+		if party > int(table.Seats) {
+			return &domain.PartyTooLargeError{Party: party, Seats: table.Seats, TableID: table.ID}
+		}
+	}
+
+	return nil
+}
+
+// countCompanions derives the accompanying guests of a guest from their companions and the unnamed guests
+// they bring on top. The guest is updated like any other change of the guest: its version is bumped and
+// guest.updated published
+func countCompanions(tx *gorm.DB, guestID int64) error {
+	err := tx.Unscoped().Model(&domain.Guest{}).Where("id = ?", guestID).Updates(map[string]interface{}{
+		"accompanying_guests": gorm.Expr("unnamed_guests + (SELECT COUNT(*) FROM companions WHERE guest_id = ?)", guestID),
+		"version":             gorm.Expr("version + 1"),
+	}).Error
+
+	if err != nil {
+		return fmt.Errorf("failed to count companions of guest (%v) %v", guestID, err.Error())
+	}
+
+	guest := &domain.Guest{}
+	if err := tx.Unscoped().First(guest, guestID).Error; err != nil {
+		return fmt.Errorf("failed to get guest by id (%v) %v", guestID, err.Error())
+	}
+
+	return outbox.Append(tx, domain.EventGuestUpdated, domain.NewGuestEvent(guest))
+}
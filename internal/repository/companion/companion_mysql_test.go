@@ -0,0 +1,199 @@
+package companion
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type CompanionMysqlRepositorySuite struct {
+	suite.Suite
+	*require.Assertions
+	DB                    *gorm.DB
+	mock                  sqlmock.Sqlmock
+	mySqlCompanionAdapter port.CompanionRepository
+}
+
+func TestCompanionMysqlRepositorySuite(t *testing.T) {
+	suite.Run(t, new(CompanionMysqlRepositorySuite))
+}
+
+func (t *CompanionMysqlRepositorySuite) SetupTest() {
+	var (
+		db  *sql.DB
+		err error
+	)
+
+	t.Assertions = require.New(t.T())
+
+	db, t.mock, err = sqlmock.New()
+	t.NoError(err)
+
+	t.DB, err = gorm.Open(mysql.New(mysql.Config{Conn: db, SkipInitializeWithVersion: true}), &gorm.Config{})
+	t.NoError(err)
+
+	t.mySqlCompanionAdapter = NewMysqlCompanionAdapter(t.DB)
+}
+
+func (t *CompanionMysqlRepositorySuite) TearDownTest() {
+	t.NoError(t.mock.ExpectationsWereMet())
+}
+
+func (t *CompanionMysqlRepositorySuite) TestGetAll() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	rows := sqlmock.NewRows([]string{"id", "guest_id", "name", "dietary"}).
+		AddRow(1, 4, "Carl", "vegan,halal").
+		AddRow(2, 5, "Dana", "")
+
+	t.mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `companions` WHERE guest_id IN (?,?) ORDER BY id")).
+		WithArgs(4, 5).
+		WillReturnRows(rows)
+
+	companions, err := t.mySqlCompanionAdapter.GetAll(c, 4, 5)
+
+	t.NoError(err)
+	t.Len(companions, 2)
+	t.Equal(domain.Dietary{domain.DietaryVegan, domain.DietaryHalal}, companions[0].Dietary)
+	t.Equal("Dana", companions[1].Name)
+}
+
+func (t *CompanionMysqlRepositorySuite) TestCreateCountsTheAccompanyingGuests() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	t.mock.ExpectBegin()
+	t.mock.ExpectQuery(regexp.QuoteMeta(lockGuest)).
+		WithArgs(4).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "accompanying_guests", "unnamed_guests"}).AddRow(4, 3, 1))
+	t.mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `companions` (`guest_id`,`name`,`dietary`,`dietary_notes`,`is_arrived`,`left_at`) VALUES (?,?,?,?,?,?)")).
+		WithArgs(4, "Carl", "vegan", "", false, nil).
+		WillReturnResult(sqlmock.NewResult(7, 1))
+	t.mock.ExpectExec(regexp.QuoteMeta("UPDATE `guests` SET `unnamed_guests`=unnamed_guests - 1 WHERE id = ? AND unnamed_guests > 0")).
+		WithArgs(4).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectCount(t.mock, 4)
+	t.mock.ExpectCommit()
+
+	companion, err := t.mySqlCompanionAdapter.Create(c, &domain.Companion{GuestID: 4, Name: "Carl", Dietary: domain.Dietary{domain.DietaryVegan}})
+
+	t.NoError(err)
+	t.EqualValues(7, companion.ID)
+}
+
+func (t *CompanionMysqlRepositorySuite) TestCreateGrowingThePartyOfASeatedGuest() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	t.mock.ExpectBegin()
+	t.mock.ExpectQuery(regexp.QuoteMeta(lockGuest)).
+		WithArgs(4).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "accompanying_guests", "unnamed_guests"}).AddRow(4, 2, 0))
+	t.mock.ExpectQuery(regexp.QuoteMeta(tablesOfGuest)).
+		WithArgs(4).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "seats", "guest_id"}).AddRow(2, 4, 4))
+	t.mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `companions` (`guest_id`,`name`,`dietary`,`dietary_notes`,`is_arrived`,`left_at`) VALUES (?,?,?,?,?,?)")).
+		WithArgs(4, "Carl", "", "", false, nil).
+		WillReturnResult(sqlmock.NewResult(7, 1))
+	t.mock.ExpectExec(regexp.QuoteMeta("UPDATE `guests` SET `unnamed_guests`=unnamed_guests - 1 WHERE id = ? AND unnamed_guests > 0")).
+		WithArgs(4).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	expectCount(t.mock, 4)
+	t.mock.ExpectCommit()
+
+	_, err := t.mySqlCompanionAdapter.Create(c, &domain.Companion{GuestID: 4, Name: "Carl"})
+
+	t.NoError(err)
+}
+
+func (t *CompanionMysqlRepositorySuite) TestCreateBeyondTheSeatsOfTheTable() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	t.mock.ExpectBegin()
+	t.mock.ExpectQuery(regexp.QuoteMeta(lockGuest)).
+		WithArgs(4).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "accompanying_guests", "unnamed_guests"}).AddRow(4, 3, 0))
+	t.mock.ExpectQuery(regexp.QuoteMeta(tablesOfGuest)).
+		WithArgs(4).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "seats", "guest_id"}).AddRow(2, 4, 4))
+	t.mock.ExpectRollback()
+
+	_, err := t.mySqlCompanionAdapter.Create(c, &domain.Companion{GuestID: 4, Name: "Carl"})
+
+	var tooLarge *domain.PartyTooLargeError
+	t.ErrorAs(err, &tooLarge)
+	t.EqualError(err, "guest party of 5 exceeds the 4 seats of table 2")
+}
+
+func (t *CompanionMysqlRepositorySuite) TestCreateForUnknownGuest() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	t.mock.ExpectBegin()
+	t.mock.ExpectQuery(regexp.QuoteMeta(lockGuest)).
+		WithArgs(4).
+		WillReturnError(gorm.ErrRecordNotFound)
+	t.mock.ExpectRollback()
+
+	_, err := t.mySqlCompanionAdapter.Create(c, &domain.Companion{GuestID: 4, Name: "Carl"})
+
+	t.ErrorIs(err, domain.ErrNotFound)
+	t.EqualError(err, "record not found by id: 4")
+}
+
+func (t *CompanionMysqlRepositorySuite) TestDeleteCountsTheAccompanyingGuests() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	t.mock.ExpectBegin()
+	t.mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `companions` WHERE guest_id = ? AND `companions`.`id` = ?")).
+		WithArgs(4, 7).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectCount(t.mock, 4)
+	t.mock.ExpectCommit()
+
+	t.NoError(t.mySqlCompanionAdapter.Delete(c, 4, 7))
+}
+
+func (t *CompanionMysqlRepositorySuite) TestDeleteOfAnotherGuest() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	t.mock.ExpectBegin()
+	t.mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `companions` WHERE guest_id = ? AND `companions`.`id` = ?")).
+		WithArgs(5, 7).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	t.mock.ExpectRollback()
+
+	t.EqualError(t.mySqlCompanionAdapter.Delete(c, 5, 7), "record not found by id: 7")
+}
+
+// expectCount expects the accompanying guests of the guest to be recounted from the companions and the unnamed guests and guest.updated to be published
+const (
+	lockGuest     = "SELECT `id`,`accompanying_guests`,`unnamed_guests` FROM `guests` WHERE `guests`.`id` = ? AND `guests`.`deleted_at` IS NULL ORDER BY `guests`.`id` LIMIT 1 FOR UPDATE"
+	tablesOfGuest = "SELECT * FROM `tables` WHERE guest_id = ? AND `tables`.`deleted_at` IS NULL"
+)
+
+func expectCount(mock sqlmock.Sqlmock, guestID int64) {
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `guests` SET `accompanying_guests`=unnamed_guests + (SELECT COUNT(*) FROM companions WHERE guest_id = ?),`version`=version + 1 WHERE id = ?")).
+		WithArgs(guestID, guestID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `guests` WHERE `guests`.`id` = ? ORDER BY `guests`.`id` LIMIT 1")).
+		WithArgs(guestID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "accompanying_guests"}).AddRow(guestID, "Ada", 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `outbox` (`event_id`,`event_type`,`payload`,`attempts`,`next_attempt_at`,`last_error`,`created_at`,`published_at`) VALUES (?,?,?,?,?,?,?,?)")).
+		WithArgs(sqlmock.AnyArg(), domain.EventGuestUpdated, sqlmock.AnyArg(), 0, sqlmock.AnyArg(), "", sqlmock.AnyArg(), nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
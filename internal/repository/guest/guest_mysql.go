@@ -4,12 +4,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"time"
 
 	"github.com/eazygood/getground-app/internal/core/domain"
 	"github.com/eazygood/getground-app/internal/core/port"
+	apperrors "github.com/eazygood/getground-app/internal/errors"
+	infra "github.com/eazygood/getground-app/internal/infrastructure/db"
+	"github.com/eazygood/getground-app/internal/repository/outbox"
 	v "github.com/eazygood/getground-app/internal/validator"
+	"github.com/eazygood/getground-app/internal/venue"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type MysqlGuestAdapter struct {
@@ -27,14 +31,37 @@ func (m *MysqlGuestAdapter) Create(ctx context.Context, guest *domain.Guest) (*d
 		return nil, fmt.Errorf("failed to insert guest due to validation: %v", err)
 	}
 
-	err := m.Conn.Create(guest).Error
+	if guest.Version == 0 {
+		guest.Version = 1
+	}
+
+	// a new guest has no companions named yet
+	guest.UnnamedGuests = guest.AccompanyingGuests
+
+	if guest.Status == "" {
+		guest.Status = domain.GuestStatusInvited
+		if guest.IsArrived {
+			guest.Status = domain.GuestStatusArrived
+		}
+	}
+
+	err := m.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(guest).Error; err != nil {
+			return fmt.Errorf("failed to insert guest: %v", err.Error())
+		}
+
+		return outbox.Append(tx, domain.EventGuestCreated, domain.NewGuestEvent(guest))
+	})
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to insert guest: %v", err.Error())
+		return nil, err
 	}
 
+	infra.MarkWrite(ctx)
+
+	// read back from the primary, a replica may not have the new row yet
 	g := &domain.Guest{}
-	err = m.Conn.First(g, guest.ID).Error
+	err = infra.Primary(ctx, m.Conn).First(g, guest.ID).Error
 
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, fmt.Errorf("record not found by id: %v", guest.ID)
@@ -47,22 +74,98 @@ func (m *MysqlGuestAdapter) Create(ctx context.Context, guest *domain.Guest) (*d
 	return guest, nil
 }
 
-func (m *MysqlGuestAdapter) Delete(ctx context.Context, id int64) error {
-	err := m.Conn.Delete(&domain.Guest{}, id).Error
+// Delete soft deletes the guest and frees their table, the way the foreign key did for hard deletes
+func (m *MysqlGuestAdapter) Delete(ctx context.Context, id int64, version int64) error {
+	err := m.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("version = ?", version).Delete(&domain.Guest{}, id)
 
-	if err != nil {
-		return fmt.Errorf("failed to delete guest by id (%v) %v", id, err.Error())
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete guest by id (%v) %v", id, result.Error.Error())
+		}
+
+		if result.RowsAffected == 0 {
+			return conflictOrMissing(tx, id, version)
+		}
+
+		err := tx.Unscoped().Model(&domain.Guest{}).Where("id = ?", id).Update("status", domain.GuestStatusLeft).Error
+
+		if err != nil {
+			return fmt.Errorf("failed to mark guest (%v) as left %v", id, err.Error())
+		}
+
+		err = tx.Unscoped().Model(&domain.Table{}).Where("guest_id = ?", id).
+			Updates(map[string]interface{}{"guest_id": nil, "version": gorm.Expr("version + 1")}).Error
+
+		if err != nil {
+			return fmt.Errorf("failed to free table of guest (%v) %v", id, err.Error())
+		}
+
+		// the companions leave with their guest
+		err = tx.Model(&domain.Companion{}).Where("guest_id = ? AND left_at IS NULL", id).Update("left_at", venue.Now()).Error
+
+		if err != nil {
+			return fmt.Errorf("failed to mark companions of guest (%v) as left %v", id, err.Error())
+		}
+
+		return outbox.Append(tx, domain.EventGuestLeft, domain.GuestLeft{GuestID: id})
+	})
+
+	if err == nil {
+		infra.MarkWrite(ctx)
 	}
 
-	return nil
+	return err
+}
+
+func (m *MysqlGuestAdapter) Restore(ctx context.Context, id int64) error {
+	err := m.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Model(&domain.Guest{}).Where("id = ? AND deleted_at IS NOT NULL", id).
+			Updates(map[string]interface{}{
+				"deleted_at": nil,
+				"version":    gorm.Expr("version + 1"),
+				// the guest had arrived or is invited again, their earlier answer is not known anymore
+				"status": gorm.Expr("IF(is_arrived, ?, ?)", domain.GuestStatusArrived, domain.GuestStatusInvited),
+			})
+
+		if result.Error != nil {
+			return fmt.Errorf("failed to restore guest by id (%v) %v", id, result.Error.Error())
+		}
+
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("deleted record not found by id: %v", id)
+		}
+
+		// and come back with them
+		err := tx.Model(&domain.Companion{}).Where("guest_id = ?", id).Update("left_at", nil).Error
+
+		if err != nil {
+			return fmt.Errorf("failed to restore companions of guest (%v) %v", id, err.Error())
+		}
+
+		return appendGuest(tx, domain.EventGuestRestored, id)
+	})
+
+	if err == nil {
+		infra.MarkWrite(ctx)
+	}
+
+	return err
 }
 
 func (m *MysqlGuestAdapter) GetById(ctx context.Context, id int64) (*domain.Guest, error) {
+	return getById(infra.Replica(ctx, m.Conn), id)
+}
+
+func (m *MysqlGuestAdapter) GetLatestById(ctx context.Context, id int64) (*domain.Guest, error) {
+	return getById(infra.Primary(ctx, m.Conn), id)
+}
+
+func getById(conn *gorm.DB, id int64) (*domain.Guest, error) {
 	guest := &domain.Guest{}
-	err := m.Conn.First(guest, id).Error
+	err := conn.First(guest, id).Error
 
 	if errors.Is(err, gorm.ErrRecordNotFound) {
-		return nil, fmt.Errorf("record not found by id: %v", id)
+		return nil, fmt.Errorf("%w by id: %v", domain.ErrNotFound, id)
 	}
 
 	if err != nil {
@@ -73,26 +176,145 @@ func (m *MysqlGuestAdapter) GetById(ctx context.Context, id int64) (*domain.Gues
 }
 
 func (m *MysqlGuestAdapter) Update(ctx context.Context, id int64, guest *domain.Guest) error {
-	if guest.IsArrived {
-		t := time.Now()
-		guest.TimeArrived = &t
+	// the version is bumped in the same statement that checks it, so a concurrent
+	// writer holding the old version matches no rows
+	version := guest.Version
+	guest.Version = version + 1
+
+	err := m.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		wasArrived, err := lockArrived(tx, id)
+		if err != nil {
+			return err
+		}
+
+		result := tx.Model(&domain.Guest{}).Where("id = ? AND version = ?", id, version).Updates(guest)
+
+		if result.Error != nil {
+			return fmt.Errorf("failed to update guest: %v", result.Error.Error())
+		}
+
+		if result.RowsAffected == 0 {
+			return conflictOrMissing(tx, id, version)
+		}
+
+		// zero fields are left out of the update, so are the accompanying guests then
+		if guest.AccompanyingGuests > 0 {
+			if err := keepUnnamed(tx, id, guest.AccompanyingGuests); err != nil {
+				return err
+			}
+		}
+
+		// a guest arrives once, updating them afterwards is an update like any other
+		if guest.IsArrived && !wasArrived {
+			if err := arriveCompanions(tx, id); err != nil {
+				return err
+			}
+
+			return appendGuest(tx, domain.EventGuestArrived, id)
+		}
+
+		return appendGuest(tx, domain.EventGuestUpdated, id)
+	})
+
+	if err == nil {
+		infra.MarkWrite(ctx)
 	}
-	err := m.Conn.Model(&domain.Guest{}).Where("id = ?", id).Updates(guest).Error
 
-	if err != nil {
-		return fmt.Errorf("failed to update guest: %v", err.Error())
+	return err
+}
+
+func (m *MysqlGuestAdapter) Patch(ctx context.Context, id int64, patch port.GuestPatch) error {
+	guest := patch.Guest
+	fields := append([]string{}, patch.Fields...)
+
+	version := guest.Version
+	guest.Version = version + 1
+	fields = append(fields, "version")
+
+	// selecting the columns makes GORM write zero values as well, which is what a patch
+	// that sets a field to false, 0 or null expects
+	err := m.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		wasArrived, err := lockArrived(tx, id)
+		if err != nil {
+			return err
+		}
+
+		result := tx.Model(&domain.Guest{}).Where("id = ? AND version = ?", id, version).Select(fields).Updates(&guest)
+
+		if result.Error != nil {
+			return fmt.Errorf("failed to patch guest: %v", result.Error.Error())
+		}
+
+		if result.RowsAffected == 0 {
+			return conflictOrMissing(tx, id, version)
+		}
+
+		if hasField(fields, "accompanying_guests") {
+			if err := keepUnnamed(tx, id, guest.AccompanyingGuests); err != nil {
+				return err
+			}
+		}
+
+		if guest.IsArrived && hasField(fields, "is_arrived") && !wasArrived {
+			if err := arriveCompanions(tx, id); err != nil {
+				return err
+			}
+
+			return appendGuest(tx, domain.EventGuestArrived, id)
+		}
+
+		return appendGuest(tx, domain.EventGuestUpdated, id)
+	})
+
+	if err == nil {
+		infra.MarkWrite(ctx)
 	}
 
-	return nil
+	return err
 }
 
 func (m *MysqlGuestAdapter) GetAll(ctx context.Context, filter port.GetGuestFilter) ([]*domain.Guest, error) {
 	var guests []*domain.Guest
 
-	conn := m.Conn
+	conn := infra.Replica(ctx, m.Conn)
+	if filter.Latest {
+		conn = infra.Primary(ctx, m.Conn)
+	}
+
+	if filter.IncludeDeleted {
+		conn = conn.Unscoped()
+	}
+
+	if filter.IsArrived != nil {
+		if *filter.IsArrived {
+			conn = conn.Where("is_arrived IS true")
+		} else {
+			conn = conn.Where("is_arrived IS false")
+		}
+	}
+
+	if filter.IDs != nil {
+		conn = conn.Where("id IN ?", filter.IDs)
+	}
 
-	if filter.IsArrived {
-		conn = conn.Where("is_arrived IS true")
+	if filter.Dietary != "" {
+		conn = conn.Where("FIND_IN_SET(?, dietary) > 0", filter.Dietary)
+	}
+
+	if filter.Company != "" {
+		conn = conn.Where("company = ?", filter.Company)
+	}
+
+	if filter.Status != "" {
+		conn = conn.Where("status = ?", filter.Status)
+	}
+
+	if filter.AfterID > 0 {
+		conn = conn.Where("id > ?", filter.AfterID)
+	}
+
+	if filter.Limit > 0 {
+		conn = conn.Order("id").Limit(filter.Limit)
 	}
 
 	err := conn.Find(&guests).Error
@@ -103,3 +325,87 @@ func (m *MysqlGuestAdapter) GetAll(ctx context.Context, filter port.GetGuestFilt
 
 	return guests, nil
 }
+
+// lockArrived tells whether the guest had arrived before the transaction, their row stays locked until it ends
+// so that two concurrent arrivals cannot both see the guest as not arrived yet
+func lockArrived(tx *gorm.DB, id int64) (bool, error) {
+	var arrived []bool
+	err := tx.Model(&domain.Guest{}).Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).Pluck("is_arrived", &arrived).Error
+
+	if err != nil {
+		return false, fmt.Errorf("failed to lock guest (%v) %v", id, err.Error())
+	}
+
+	return len(arrived) > 0 && arrived[0], nil
+}
+
+// appendGuest stores an event carrying the guest as the transaction left them
+func appendGuest(tx *gorm.DB, eventType string, id int64) error {
+	guest := &domain.Guest{}
+	if err := tx.Unscoped().First(guest, id).Error; err != nil {
+		return fmt.Errorf("failed to get guest by id (%v) %v", id, err.Error())
+	}
+
+	return outbox.Append(tx, eventType, domain.NewGuestEvent(guest))
+}
+
+// keepUnnamed splits the accompanying guests just written into the named companions and the unnamed guests
+// on top of them, the companions are counted again whenever they change. Fewer accompanying guests than named
+// companions are rejected, a companion has to be removed first.
+func keepUnnamed(tx *gorm.DB, id int64, accompanying uint16) error {
+	var named int64
+	if err := tx.Model(&domain.Companion{}).Where("guest_id = ?", id).Count(&named).Error; err != nil {
+		return fmt.Errorf("failed to count companions of guest (%v) %v", id, err.Error())
+	}
+
+	if int64(accompanying) < named {
+		return apperrors.NewValidationError(apperrors.FieldError{
+			Field:   "accompanying_guests",
+			Rule:    "companions",
+			Message: fmt.Sprintf("accompanying_guests must be at least the %d named companions, remove them first", named),
+		})
+	}
+
+	err := tx.Model(&domain.Guest{}).Where("id = ?", id).Update("unnamed_guests", int64(accompanying)-named).Error
+
+	if err != nil {
+		return fmt.Errorf("failed to update unnamed guests of guest (%v) %v", id, err.Error())
+	}
+
+	return nil
+}
+
+// arriveCompanions marks the companions of an arriving guest as arrived, they come with them
+func arriveCompanions(tx *gorm.DB, id int64) error {
+	err := tx.Model(&domain.Companion{}).Where("guest_id = ? AND left_at IS NULL", id).Update("is_arrived", true).Error
+
+	if err != nil {
+		return fmt.Errorf("failed to mark companions of guest (%v) as arrived %v", id, err.Error())
+	}
+
+	return nil
+}
+
+func hasField(fields []string, field string) bool {
+	for _, f := range fields {
+		if f == field {
+			return true
+		}
+	}
+
+	return false
+}
+
+// conflictOrMissing tells apart the reasons a versioned write matched no row: the guest is gone, or its version moved on
+func conflictOrMissing(tx *gorm.DB, id int64, version int64) error {
+	var count int64
+	if err := tx.Model(&domain.Guest{}).Where("id = ?", id).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to get guest by id (%v) %v", id, err.Error())
+	}
+
+	if count == 0 {
+		return fmt.Errorf("%w by id: %v", domain.ErrNotFound, id)
+	}
+
+	return apperrors.NewConflictError("guest", id, version)
+}
@@ -3,13 +3,16 @@ package guest
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/eazygood/getground-app/internal/core/domain"
 	"github.com/eazygood/getground-app/internal/core/port"
+	apperrors "github.com/eazygood/getground-app/internal/errors"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -53,7 +56,7 @@ func (u *GuestMysqlRepositorySuite) TearDownTest() {
 }
 
 func (g *GuestMysqlRepositorySuite) TestCreateGuest() {
-	c, cancel := context.WithTimeout(context.Background(), time.Duration(1000))
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
 	guest := &domain.Guest{
@@ -65,21 +68,68 @@ func (g *GuestMysqlRepositorySuite) TestCreateGuest() {
 	rows := sqlmock.NewRows([]string{"id", "name", "accompanying_guests", "time_arrived"}).AddRow(1, "Tere", 0, nil)
 	g.mock.ExpectBegin()
 
-	g.mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `guests` (`name`,`accompanying_guests`,`time_arrived`,`is_arrived`) VALUES (?,?,?,?)")).
-		WithArgs("Tere", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+	g.mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `guests` (`name`,`accompanying_guests`,`unnamed_guests`,`time_arrived`,`is_arrived`,`email`,`phone`,`company`,`dietary`,`dietary_notes`,`accessibility`,`notes`,`status`,`party_size`,`responded_at`,`version`,`deleted_at`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)")).
+		WithArgs("Tere", 0, 0, sqlmock.AnyArg(), sqlmock.AnyArg(), "", "", "", "", "", "", "", domain.GuestStatusInvited, 0, nil, 1, nil).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
+	expectOutbox(g.mock, domain.EventGuestCreated)
 	g.mock.ExpectCommit()
 
-	g.mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `guests` WHERE `guests`.`id` = ? ORDER BY `guests`.`id` LIMIT 1")).WithArgs(1).WillReturnRows(rows)
+	g.mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `guests` WHERE `guests`.`id` = ? AND `guests`.`deleted_at` IS NULL ORDER BY `guests`.`id` LIMIT 1")).WithArgs(1).WillReturnRows(rows)
 
 	_, err := g.mySqlGuestAdapter.Create(c, guest)
 
 	g.NoError(err)
 }
 
+func (g *GuestMysqlRepositorySuite) TestCreateGuestPublishesNoPrivateMembers() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	guest := &domain.Guest{
+		Name:          "Tere",
+		Email:         "tere@example.com",
+		Phone:         "+3725551234",
+		Accessibility: "wheelchair",
+		Notes:         "old friend of the host",
+	}
+
+	g.mock.ExpectBegin()
+	g.mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `guests`")).WillReturnResult(sqlmock.NewResult(1, 1))
+	g.mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `outbox`")).
+		WithArgs(sqlmock.AnyArg(), domain.EventGuestCreated, payloadWithout{"tere@example.com", "+3725551234", "wheelchair", "old friend"},
+			0, sqlmock.AnyArg(), "", sqlmock.AnyArg(), nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	g.mock.ExpectCommit()
+	g.mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `guests`")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Tere"))
+
+	_, err := g.mySqlGuestAdapter.Create(c, guest)
+
+	g.NoError(err)
+	g.NoError(g.mock.ExpectationsWereMet())
+}
+
+// payloadWithout matches an outbox payload naming the guest and carrying none of its values
+type payloadWithout []string
+
+func (p payloadWithout) Match(v driver.Value) bool {
+	payload, ok := v.([]byte)
+	if !ok || !strings.Contains(string(payload), `"name":"Tere"`) {
+		return false
+	}
+
+	for _, value := range p {
+		if strings.Contains(string(payload), value) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (g *GuestMysqlRepositorySuite) TestUpdateGuest() {
-	c, cancel := context.WithTimeout(context.Background(), time.Duration(1000))
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
 	guest := &domain.Guest{
@@ -87,44 +137,233 @@ func (g *GuestMysqlRepositorySuite) TestUpdateGuest() {
 		AccompanyingGuests: 10,
 		TimeArrived:        nil,
 		IsArrived:          false,
+		Version:            3,
 	}
 
 	g.mock.ExpectBegin()
+	expectLockArrived(g.mock, 1, false)
 
 	g.mock.ExpectExec("UPDATE `guests` SET (.+)  WHERE (.+)").
-		WithArgs(guest.Name, guest.AccompanyingGuests, sqlmock.AnyArg()).
+		WithArgs(guest.Name, guest.AccompanyingGuests, 4, 1, 3).
 		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectUnnamed(g.mock, 1, 2, 8)
+	expectReadBack(g.mock, 1)
+	expectOutbox(g.mock, domain.EventGuestUpdated)
 	g.mock.ExpectCommit()
 
 	err := g.mySqlGuestAdapter.Update(c, 1, guest)
 
 	g.NoError(err)
+	g.EqualValues(4, guest.Version)
+}
+
+func (g *GuestMysqlRepositorySuite) TestUpdateGuestRollsBackWithoutEvent() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	guest := &domain.Guest{
+		Name:    "Tere",
+		Version: 3,
+	}
+
+	g.mock.ExpectBegin()
+	expectLockArrived(g.mock, 1, false)
+	g.mock.ExpectExec("UPDATE `guests` SET (.+)  WHERE (.+)").
+		WithArgs(guest.Name, 4, 1, 3).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectReadBack(g.mock, 1)
+	g.mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `outbox`")).WillReturnError(sql.ErrConnDone)
+	g.mock.ExpectRollback()
+
+	err := g.mySqlGuestAdapter.Update(c, 1, guest)
+
+	g.ErrorContains(err, "failed to insert guest.updated event")
+	g.NoError(g.mock.ExpectationsWereMet())
+}
+
+func (g *GuestMysqlRepositorySuite) TestUpdateArrivedGuestKeepsTimeArrived() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	timeArrived := time.Date(2023, 1, 20, 18, 30, 0, 0, time.UTC)
+	guest := &domain.Guest{
+		Name:        "Tere",
+		IsArrived:   true,
+		TimeArrived: &timeArrived,
+		Status:      domain.GuestStatusArrived,
+		Version:     3,
+	}
+
+	g.mock.ExpectBegin()
+	expectLockArrived(g.mock, 1, false)
+	g.mock.ExpectExec("UPDATE `guests` SET (.+)  WHERE (.+)").
+		WithArgs(guest.Name, timeArrived, true, domain.GuestStatusArrived, 4, 1, 3).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectArriveCompanions(g.mock, 1)
+	expectReadBack(g.mock, 1)
+	expectOutbox(g.mock, domain.EventGuestArrived)
+	g.mock.ExpectCommit()
+
+	g.NoError(g.mySqlGuestAdapter.Update(c, 1, guest))
+	g.Equal(timeArrived, *guest.TimeArrived)
+}
+
+func (g *GuestMysqlRepositorySuite) TestUpdateGuestWhoArrivedBefore() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	timeArrived := time.Date(2023, 1, 20, 18, 30, 0, 0, time.UTC)
+	guest := &domain.Guest{
+		Name:        "Tere",
+		IsArrived:   true,
+		TimeArrived: &timeArrived,
+		Status:      domain.GuestStatusArrived,
+		Version:     3,
+	}
+
+	g.mock.ExpectBegin()
+	expectLockArrived(g.mock, 1, true)
+	g.mock.ExpectExec("UPDATE `guests` SET (.+)  WHERE (.+)").
+		WithArgs(guest.Name, timeArrived, true, domain.GuestStatusArrived, 4, 1, 3).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectReadBack(g.mock, 1)
+	expectOutbox(g.mock, domain.EventGuestUpdated)
+	g.mock.ExpectCommit()
+
+	g.NoError(g.mySqlGuestAdapter.Update(c, 1, guest))
+}
+
+func (g *GuestMysqlRepositorySuite) TestUpdateGuestVersionConflict() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	guest := &domain.Guest{
+		Name:    "Tere",
+		Version: 3,
+	}
+
+	g.mock.ExpectBegin()
+	expectLockArrived(g.mock, 1, false)
+
+	g.mock.ExpectExec("UPDATE `guests` SET (.+)  WHERE (.+)").
+		WithArgs(guest.Name, 4, 1, 3).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	expectGuestCount(g.mock, 1, 1)
+	g.mock.ExpectRollback()
+
+	err := g.mySqlGuestAdapter.Update(c, 1, guest)
+
+	var conflict *apperrors.ConflictError
+	g.ErrorAs(err, &conflict)
+	g.EqualValues(3, conflict.Version)
+}
+
+func (g *GuestMysqlRepositorySuite) TestDeleteGuestNotFound() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	g.mock.ExpectBegin()
+	g.mock.ExpectExec(regexp.QuoteMeta("UPDATE `guests` SET `deleted_at`=? WHERE version = ? AND `guests`.`id` = ? AND `guests`.`deleted_at` IS NULL")).
+		WithArgs(sqlmock.AnyArg(), 1, 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	expectGuestCount(g.mock, 1, 0)
+	g.mock.ExpectRollback()
+
+	err := g.mySqlGuestAdapter.Delete(c, 1, 1)
+
+	g.ErrorIs(err, domain.ErrNotFound)
+	g.NoError(g.mock.ExpectationsWereMet())
+}
+
+func (g *GuestMysqlRepositorySuite) TestPatchGuestWritesZeroValues() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	patch := port.GuestPatch{
+		Guest:  domain.Guest{Version: 2},
+		Fields: []string{"accompanying_guests", "is_arrived", "time_arrived"},
+	}
+
+	g.mock.ExpectBegin()
+	expectLockArrived(g.mock, 1, false)
+
+	g.mock.ExpectExec(regexp.QuoteMeta("UPDATE `guests` SET `accompanying_guests`=?,`time_arrived`=?,`is_arrived`=?,`version`=? WHERE (id = ? AND version = ?) AND `guests`.`deleted_at` IS NULL")).
+		WithArgs(0, nil, false, 3, 1, 2).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectUnnamed(g.mock, 1, 0, 0)
+	expectReadBack(g.mock, 1)
+	expectOutbox(g.mock, domain.EventGuestUpdated)
+	g.mock.ExpectCommit()
+
+	err := g.mySqlGuestAdapter.Patch(c, 1, patch)
+
+	g.NoError(err)
+}
+
+func (g *GuestMysqlRepositorySuite) TestPatchFewerAccompanyingGuestsThanCompanions() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	patch := port.GuestPatch{
+		Guest:  domain.Guest{AccompanyingGuests: 1, Version: 2},
+		Fields: []string{"accompanying_guests"},
+	}
+
+	g.mock.ExpectBegin()
+	expectLockArrived(g.mock, 1, false)
+	g.mock.ExpectExec(regexp.QuoteMeta("UPDATE `guests` SET `accompanying_guests`=?,`version`=? WHERE (id = ? AND version = ?) AND `guests`.`deleted_at` IS NULL")).
+		WithArgs(1, 3, 1, 2).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	g.mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `companions` WHERE guest_id = ?")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	g.mock.ExpectRollback()
+
+	err := g.mySqlGuestAdapter.Patch(c, 1, patch)
+
+	var validation *apperrors.ValidationError
+	g.ErrorAs(err, &validation)
+	g.Equal("accompanying_guests", validation.Fields[0].Field)
 }
 
 func (g *GuestMysqlRepositorySuite) TestDeleteGuest() {
-	c, cancel := context.WithTimeout(context.Background(), time.Duration(1000))
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 	id := 1
 
 	g.mock.ExpectBegin()
 
-	g.mock.ExpectExec(("DELETE FROM `guests` WHERE (.+)")).
-		WithArgs(id).
+	g.mock.ExpectExec(regexp.QuoteMeta("UPDATE `guests` SET `deleted_at`=? WHERE version = ? AND `guests`.`id` = ? AND `guests`.`deleted_at` IS NULL")).
+		WithArgs(sqlmock.AnyArg(), 2, id).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	g.mock.ExpectExec(regexp.QuoteMeta("UPDATE `guests` SET `status`=? WHERE id = ?")).
+		WithArgs(domain.GuestStatusLeft, id).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	g.mock.ExpectExec(regexp.QuoteMeta("UPDATE `tables` SET `guest_id`=?,`version`=version + 1 WHERE guest_id = ?")).
+		WithArgs(nil, id).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
+	g.mock.ExpectExec(regexp.QuoteMeta("UPDATE `companions` SET `left_at`=? WHERE guest_id = ? AND left_at IS NULL")).
+		WithArgs(sqlmock.AnyArg(), id).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	expectOutbox(g.mock, domain.EventGuestLeft)
 	g.mock.ExpectCommit()
 
-	err := g.mySqlGuestAdapter.Delete(c, int64(id))
+	err := g.mySqlGuestAdapter.Delete(c, int64(id), 2)
 
 	g.NoError(err)
 }
 
 func (g *GuestMysqlRepositorySuite) TestGetListGuest() {
-	c, cancel := context.WithTimeout(context.Background(), time.Duration(1000))
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
+	arrived := true
 	filters := port.GetGuestFilter{
-		IsArrived: true,
+		IsArrived: &arrived,
 	}
 
 	now := time.Now()
@@ -148,12 +387,50 @@ func (g *GuestMysqlRepositorySuite) TestGetListGuest() {
 	g.EqualValues(expected, guests)
 }
 
+func (g *GuestMysqlRepositorySuite) TestGetListGuestNotArrived() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	arrived := false
+	rows := sqlmock.NewRows([]string{"id", "name", "is_arrived"}).AddRow(2, "Simon", false)
+	g.mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `guests` WHERE is_arrived IS false")).WillReturnRows(rows)
+
+	guests, err := g.mySqlGuestAdapter.GetAll(c, port.GetGuestFilter{IsArrived: &arrived})
+
+	g.NoError(err)
+	g.Len(guests, 1)
+	g.EqualValues(2, guests[0].ID)
+}
+
+func (g *GuestMysqlRepositorySuite) TestGetListGuestPage() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	filters := port.GetGuestFilter{
+		IDs:     []int64{3, 4, 5},
+		AfterID: 3,
+		Limit:   2,
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "name"}).AddRow(4, "Tere").AddRow(5, "Mari")
+	g.mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `guests` WHERE id IN (?,?,?) AND id > ? AND `guests`.`deleted_at` IS NULL ORDER BY id LIMIT 2")).
+		WithArgs(3, 4, 5, 3).
+		WillReturnRows(rows)
+
+	guests, err := g.mySqlGuestAdapter.GetAll(c, filters)
+
+	g.NoError(err)
+	g.Len(guests, 2)
+	g.EqualValues(5, guests[1].ID)
+}
+
 func (g *GuestMysqlRepositorySuite) TestGetListWithOutFilterGuest() {
-	c, cancel := context.WithTimeout(context.Background(), time.Duration(1000))
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
+	arrived := true
 	filters := port.GetGuestFilter{
-		IsArrived: true,
+		IsArrived: &arrived,
 	}
 
 	now := time.Now()
@@ -186,7 +463,7 @@ func (g *GuestMysqlRepositorySuite) TestGetListWithOutFilterGuest() {
 }
 
 func (g *GuestMysqlRepositorySuite) TestGetById() {
-	c, cancel := context.WithTimeout(context.Background(), time.Duration(1000))
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
 	expected := &domain.Guest{
@@ -203,3 +480,152 @@ func (g *GuestMysqlRepositorySuite) TestGetById() {
 	g.NoError(err)
 	g.EqualValues(expected, actual)
 }
+
+func (g *GuestMysqlRepositorySuite) TestGetLatestByIdNotFound() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	g.mock.ExpectQuery("^SELECT (.+) WHERE (.+)").WillReturnError(gorm.ErrRecordNotFound)
+
+	_, err := g.mySqlGuestAdapter.GetLatestById(c, 7)
+
+	g.EqualError(err, "record not found by id: 7")
+}
+
+func (g *GuestMysqlRepositorySuite) TestRestoreGuest() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	g.mock.ExpectBegin()
+
+	g.mock.ExpectExec(regexp.QuoteMeta("UPDATE `guests` SET `deleted_at`=?,`status`=IF(is_arrived, ?, ?),`version`=version + 1 WHERE id = ? AND deleted_at IS NOT NULL")).
+		WithArgs(nil, domain.GuestStatusArrived, domain.GuestStatusInvited, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	g.mock.ExpectExec(regexp.QuoteMeta("UPDATE `companions` SET `left_at`=? WHERE guest_id = ?")).
+		WithArgs(nil, 1).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	expectReadBack(g.mock, 1)
+	expectOutbox(g.mock, domain.EventGuestRestored)
+	g.mock.ExpectCommit()
+
+	err := g.mySqlGuestAdapter.Restore(c, 1)
+
+	g.NoError(err)
+}
+
+func (g *GuestMysqlRepositorySuite) TestGetListIncludeDeleted() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	rows := sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Tere")
+	g.mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `guests`") + "$").WillReturnRows(rows)
+
+	guests, err := g.mySqlGuestAdapter.GetAll(c, port.GetGuestFilter{IncludeDeleted: true})
+
+	g.NoError(err)
+	g.Len(guests, 1)
+}
+
+// expectReadBack expects the guest to be read in the transaction of the mutation, for its event
+func expectReadBack(mock sqlmock.Sqlmock, id int64) {
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `guests` WHERE `guests`.`id` = ? ORDER BY `guests`.`id` LIMIT 1")).
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(id, "Tere"))
+}
+
+// expectArriveCompanions expects the companions of an arriving guest to be marked as arrived
+func expectArriveCompanions(mock sqlmock.Sqlmock, id int64) {
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `companions` SET `is_arrived`=? WHERE guest_id = ? AND left_at IS NULL")).
+		WithArgs(true, id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+// expectOutbox expects an event of eventType to be stored in the transaction of the mutation
+func expectOutbox(mock sqlmock.Sqlmock, eventType string) {
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `outbox` (`event_id`,`event_type`,`payload`,`attempts`,`next_attempt_at`,`last_error`,`created_at`,`published_at`) VALUES (?,?,?,?,?,?,?,?)")).
+		WithArgs(sqlmock.AnyArg(), eventType, sqlmock.AnyArg(), 0, sqlmock.AnyArg(), "", sqlmock.AnyArg(), nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+func (g *GuestMysqlRepositorySuite) TestGetListByDietaryAndCompany() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	filters := port.GetGuestFilter{Dietary: domain.DietaryVegan, Company: "Acme"}
+
+	rows := sqlmock.NewRows([]string{"id", "name", "company", "dietary"}).AddRow(4, "Ada", "Acme", "vegan,nut_allergy")
+	g.mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `guests` WHERE FIND_IN_SET(?, dietary) > 0 AND company = ? AND `guests`.`deleted_at` IS NULL")).
+		WithArgs(domain.DietaryVegan, "Acme").
+		WillReturnRows(rows)
+
+	guests, err := g.mySqlGuestAdapter.GetAll(c, filters)
+
+	g.NoError(err)
+	g.Len(guests, 1)
+	g.Equal(domain.Dietary{domain.DietaryVegan, domain.DietaryNutAllergy}, guests[0].Dietary)
+}
+
+func (g *GuestMysqlRepositorySuite) TestGetListByStatus() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	rows := sqlmock.NewRows([]string{"id", "name", "status", "party_size"}).AddRow(4, "Ada", domain.GuestStatusAccepted, 3)
+	g.mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `guests` WHERE status = ? AND `guests`.`deleted_at` IS NULL")).
+		WithArgs(domain.GuestStatusAccepted).
+		WillReturnRows(rows)
+
+	guests, err := g.mySqlGuestAdapter.GetAll(c, port.GetGuestFilter{Status: domain.GuestStatusAccepted})
+
+	g.NoError(err)
+	g.Len(guests, 1)
+	g.EqualValues(3, guests[0].PartySize)
+}
+
+func (g *GuestMysqlRepositorySuite) TestPatchArrivalMarksGuestArrived() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	now := time.Now().UTC()
+	patch := port.GuestPatch{
+		Guest:  domain.Guest{IsArrived: true, TimeArrived: &now, Status: domain.GuestStatusArrived, Version: 2},
+		Fields: []string{"is_arrived", "time_arrived", "status"},
+	}
+
+	g.mock.ExpectBegin()
+	expectLockArrived(g.mock, 1, false)
+	g.mock.ExpectExec(regexp.QuoteMeta("UPDATE `guests` SET `time_arrived`=?,`is_arrived`=?,`status`=?,`version`=? WHERE (id = ? AND version = ?) AND `guests`.`deleted_at` IS NULL")).
+		WithArgs(now, true, domain.GuestStatusArrived, 3, 1, 2).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectArriveCompanions(g.mock, 1)
+	expectReadBack(g.mock, 1)
+	expectOutbox(g.mock, domain.EventGuestArrived)
+	g.mock.ExpectCommit()
+
+	g.NoError(g.mySqlGuestAdapter.Patch(c, 1, patch))
+}
+
+// expectLockArrived expects the guest to be locked and tells whether they had arrived
+// expectGuestCount expects a write that matched no row to look whether the guest is still there
+func expectGuestCount(mock sqlmock.Sqlmock, id int64, count int64) {
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `guests` WHERE id = ? AND `guests`.`deleted_at` IS NULL")).
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(count))
+}
+
+func expectLockArrived(mock sqlmock.Sqlmock, id int64, arrived bool) {
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `is_arrived` FROM `guests` WHERE id = ? AND `guests`.`deleted_at` IS NULL FOR UPDATE")).
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows([]string{"is_arrived"}).AddRow(arrived))
+}
+
+// expectUnnamed expects the accompanying guests written to be split into named companions and unnamed guests
+func expectUnnamed(mock sqlmock.Sqlmock, id int64, named int64, unnamed int64) {
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `companions` WHERE guest_id = ?")).
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(named))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `guests` SET `unnamed_guests`=? WHERE id = ? AND `guests`.`deleted_at` IS NULL")).
+		WithArgs(unnamed, id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+}
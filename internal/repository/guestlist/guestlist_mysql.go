@@ -7,6 +7,7 @@ import (
 
 	"github.com/eazygood/getground-app/internal/core/domain"
 	"github.com/eazygood/getground-app/internal/core/port"
+	infra "github.com/eazygood/getground-app/internal/infrastructure/db"
 	"gorm.io/gorm"
 )
 
@@ -23,13 +24,14 @@ func NewMysqlGuestListAdapter(Conn *gorm.DB) port.GuesListRepository {
 func (m *MysqlGuestListAdapter) FindAvailableTable(ctx context.Context, filter port.GetGuestListFilter) (*domain.Table, error) {
 	table := domain.Table{}
 
-	result := m.Conn.Where("seats >= ? AND guest_id IS NULL", filter.AccompanyingGuests).Find(&table)
+	// the table is assigned right after, so it is picked from the primary
+	result := infra.Primary(ctx, m.Conn).Where("seats >= ? AND guest_id IS NULL", filter.AccompanyingGuests).Find(&table)
 
 	err := result.Error
 	rows := result.RowsAffected
 
 	if errors.Is(err, gorm.ErrRecordNotFound) || rows < 1 {
-		return nil, fmt.Errorf("no available seats")
+		return nil, domain.ErrNoAvailableSeats
 	}
 
 	if err != nil {
@@ -42,7 +44,7 @@ func (m *MysqlGuestListAdapter) FindAvailableTable(ctx context.Context, filter p
 func (m *MysqlGuestListAdapter) GetOccupiedSeats(ctx context.Context) ([]*domain.Table, error) {
 	var tables []*domain.Table
 
-	err := m.Conn.Preload("Guest").Joins("JOIN guests ON guests.id = guest_id").Where("guest_id IS NOT NULL").Find(&tables).Error
+	err := infra.Replica(ctx, m.Conn).Preload("Guest").Joins("JOIN guests ON guests.id = guest_id AND guests.deleted_at IS NULL").Where("guest_id IS NOT NULL").Find(&tables).Error
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to get list of occupied seats: %v", err.Error())
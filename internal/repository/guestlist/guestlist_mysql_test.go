@@ -52,7 +52,7 @@ func (u *GuestListMysqlRepositorySuite) TearDownTest() {
 }
 
 func (g *GuestListMysqlRepositorySuite) TestGetOccupiedSeats() {
-	c, cancel := context.WithTimeout(context.Background(), time.Duration(1000))
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
 	guest := &domain.Guest{
@@ -81,7 +81,7 @@ func (g *GuestListMysqlRepositorySuite) TestGetOccupiedSeats() {
 }
 
 func (g *GuestListMysqlRepositorySuite) TestFindAvailableTable() {
-	c, cancel := context.WithTimeout(context.Background(), time.Duration(1000))
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
 	expected := &domain.Table{
@@ -95,7 +95,7 @@ func (g *GuestListMysqlRepositorySuite) TestFindAvailableTable() {
 
 	rows := sqlmock.NewRows([]string{"id", "seats", "guest_id"}).AddRow(1, 15, nil)
 
-	g.mock.ExpectQuery("^SELECT (.+) FROM `tables` WHERE (.+)").WillReturnRows(rows)
+	g.mock.ExpectQuery("^SELECT (.+) FROM `tables` WHERE (.+) AND `tables`.`deleted_at` IS NULL").WillReturnRows(rows)
 
 	actual, err := g.mySqlGuestList.FindAvailableTable(c, filter)
 
@@ -104,7 +104,7 @@ func (g *GuestListMysqlRepositorySuite) TestFindAvailableTable() {
 }
 
 func (g *GuestListMysqlRepositorySuite) TestFindAvailableTableNotFound() {
-	c, cancel := context.WithTimeout(context.Background(), time.Duration(1000))
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
 	rows := sqlmock.NewRows([]string{"id", "seats", "guest_id"})
@@ -0,0 +1,40 @@
+package instrumented
+
+import (
+	"context"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+)
+
+const companionRepository = "companion"
+
+type CompanionRepository struct {
+	next port.CompanionRepository
+}
+
+// NewCompanionRepository traces every call made to the wrapped repository and records its latency and errors
+func NewCompanionRepository(next port.CompanionRepository) port.CompanionRepository {
+	return &CompanionRepository{next: next}
+}
+
+func (r *CompanionRepository) GetAll(ctx context.Context, guestIDs ...int64) (companions []*domain.Companion, err error) {
+	ctx, done := observe(ctx, companionRepository, "GetAll")
+	defer func() { done(err) }()
+
+	return r.next.GetAll(ctx, guestIDs...)
+}
+
+func (r *CompanionRepository) Create(ctx context.Context, c *domain.Companion) (companion *domain.Companion, err error) {
+	ctx, done := observe(ctx, companionRepository, "Create")
+	defer func() { done(err) }()
+
+	return r.next.Create(ctx, c)
+}
+
+func (r *CompanionRepository) Delete(ctx context.Context, guestID int64, id int64) (err error) {
+	ctx, done := observe(ctx, companionRepository, "Delete")
+	defer func() { done(err) }()
+
+	return r.next.Delete(ctx, guestID, id)
+}
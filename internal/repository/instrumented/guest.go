@@ -0,0 +1,75 @@
+package instrumented
+
+import (
+	"context"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+)
+
+const guestRepository = "guest"
+
+type GuestRepository struct {
+	next port.GuestRepository
+}
+
+// NewGuestRepository traces every call made to the wrapped repository and records its latency and errors
+func NewGuestRepository(next port.GuestRepository) port.GuestRepository {
+	return &GuestRepository{next: next}
+}
+
+func (r *GuestRepository) GetById(ctx context.Context, id int64) (guest *domain.Guest, err error) {
+	ctx, done := observe(ctx, guestRepository, "GetById")
+	defer func() { done(err) }()
+
+	return r.next.GetById(ctx, id)
+}
+
+func (r *GuestRepository) GetLatestById(ctx context.Context, id int64) (guest *domain.Guest, err error) {
+	ctx, done := observe(ctx, guestRepository, "GetLatestById")
+	defer func() { done(err) }()
+
+	return r.next.GetLatestById(ctx, id)
+}
+
+func (r *GuestRepository) GetAll(ctx context.Context, filter port.GetGuestFilter) (guests []*domain.Guest, err error) {
+	ctx, done := observe(ctx, guestRepository, "GetAll")
+	defer func() { done(err) }()
+
+	return r.next.GetAll(ctx, filter)
+}
+
+func (r *GuestRepository) Create(ctx context.Context, g *domain.Guest) (guest *domain.Guest, err error) {
+	ctx, done := observe(ctx, guestRepository, "Create")
+	defer func() { done(err) }()
+
+	return r.next.Create(ctx, g)
+}
+
+func (r *GuestRepository) Update(ctx context.Context, id int64, guest *domain.Guest) (err error) {
+	ctx, done := observe(ctx, guestRepository, "Update")
+	defer func() { done(err) }()
+
+	return r.next.Update(ctx, id, guest)
+}
+
+func (r *GuestRepository) Patch(ctx context.Context, id int64, patch port.GuestPatch) (err error) {
+	ctx, done := observe(ctx, guestRepository, "Patch")
+	defer func() { done(err) }()
+
+	return r.next.Patch(ctx, id, patch)
+}
+
+func (r *GuestRepository) Delete(ctx context.Context, id int64, version int64) (err error) {
+	ctx, done := observe(ctx, guestRepository, "Delete")
+	defer func() { done(err) }()
+
+	return r.next.Delete(ctx, id, version)
+}
+
+func (r *GuestRepository) Restore(ctx context.Context, id int64) (err error) {
+	ctx, done := observe(ctx, guestRepository, "Restore")
+	defer func() { done(err) }()
+
+	return r.next.Restore(ctx, id)
+}
@@ -0,0 +1,33 @@
+package instrumented
+
+import (
+	"context"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+)
+
+const guestListRepository = "guestlist"
+
+type GuestListRepository struct {
+	next port.GuesListRepository
+}
+
+// NewGuestListRepository traces every call made to the wrapped repository and records its latency and errors
+func NewGuestListRepository(next port.GuesListRepository) port.GuesListRepository {
+	return &GuestListRepository{next: next}
+}
+
+func (r *GuestListRepository) FindAvailableTable(ctx context.Context, filter port.GetGuestListFilter) (table *domain.Table, err error) {
+	ctx, done := observe(ctx, guestListRepository, "FindAvailableTable")
+	defer func() { done(err) }()
+
+	return r.next.FindAvailableTable(ctx, filter)
+}
+
+func (r *GuestListRepository) GetOccupiedSeats(ctx context.Context) (tables []*domain.Table, err error) {
+	ctx, done := observe(ctx, guestListRepository, "GetOccupiedSeats")
+	defer func() { done(err) }()
+
+	return r.next.GetOccupiedSeats(ctx)
+}
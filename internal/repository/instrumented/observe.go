@@ -0,0 +1,26 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"github.com/eazygood/getground-app/internal/infrastructure/metrics"
+	"github.com/eazygood/getground-app/internal/infrastructure/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// observe starts a span for a repository call, the returned func ends it and records the
+// latency and the outcome of the call
+func observe(ctx context.Context, repository string, method string) (context.Context, func(error)) {
+	start := time.Now()
+	ctx, span := tracing.Start(ctx, "repository."+repository+"."+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("db.system", "mysql")),
+	)
+
+	return ctx, func(err error) {
+		metrics.ObserveQuery(repository, method, start, err)
+		tracing.End(span, err)
+	}
+}
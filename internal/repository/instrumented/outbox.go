@@ -0,0 +1,41 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+)
+
+const outboxRepository = "outbox"
+
+type OutboxRepository struct {
+	next port.OutboxRepository
+}
+
+// NewOutboxRepository traces every call made to the wrapped repository and records its latency and errors
+func NewOutboxRepository(next port.OutboxRepository) port.OutboxRepository {
+	return &OutboxRepository{next: next}
+}
+
+func (r *OutboxRepository) Add(ctx context.Context, events ...domain.Event) (err error) {
+	ctx, done := observe(ctx, outboxRepository, "Add")
+	defer func() { done(err) }()
+
+	return r.next.Add(ctx, events...)
+}
+
+func (r *OutboxRepository) GetPending(ctx context.Context, now time.Time, limit int) (messages []*domain.OutboxMessage, err error) {
+	ctx, done := observe(ctx, outboxRepository, "GetPending")
+	defer func() { done(err) }()
+
+	return r.next.GetPending(ctx, now, limit)
+}
+
+func (r *OutboxRepository) Update(ctx context.Context, message *domain.OutboxMessage) (err error) {
+	ctx, done := observe(ctx, outboxRepository, "Update")
+	defer func() { done(err) }()
+
+	return r.next.Update(ctx, message)
+}
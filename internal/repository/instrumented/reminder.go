@@ -0,0 +1,41 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+)
+
+const reminderRepository = "reminder"
+
+type ReminderRepository struct {
+	next port.ReminderRepository
+}
+
+// NewReminderRepository traces every call made to the wrapped repository and records its latency and errors
+func NewReminderRepository(next port.ReminderRepository) port.ReminderRepository {
+	return &ReminderRepository{next: next}
+}
+
+func (r *ReminderRepository) GetPending(ctx context.Context, dueAt time.Time, now time.Time, statuses []string, maxAttempts int, limit int) (guests []*domain.Guest, err error) {
+	ctx, done := observe(ctx, reminderRepository, "GetPending")
+	defer func() { done(err) }()
+
+	return r.next.GetPending(ctx, dueAt, now, statuses, maxAttempts, limit)
+}
+
+func (r *ReminderRepository) Claim(ctx context.Context, guestID int64, dueAt time.Time, now time.Time, maxAttempts int) (attempts int, err error) {
+	ctx, done := observe(ctx, reminderRepository, "Claim")
+	defer func() { done(err) }()
+
+	return r.next.Claim(ctx, guestID, dueAt, now, maxAttempts)
+}
+
+func (r *ReminderRepository) Fail(ctx context.Context, guestID int64, dueAt time.Time, nextAttemptAt time.Time, lastError string) (err error) {
+	ctx, done := observe(ctx, reminderRepository, "Fail")
+	defer func() { done(err) }()
+
+	return r.next.Fail(ctx, guestID, dueAt, nextAttemptAt, lastError)
+}
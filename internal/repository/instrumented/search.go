@@ -0,0 +1,26 @@
+package instrumented
+
+import (
+	"context"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+)
+
+const guestSearcher = "guest_search"
+
+type GuestSearcher struct {
+	next port.GuestSearcher
+}
+
+// NewGuestSearcher traces every call made to the wrapped searcher and records its latency and errors
+func NewGuestSearcher(next port.GuestSearcher) port.GuestSearcher {
+	return &GuestSearcher{next: next}
+}
+
+func (r *GuestSearcher) Search(ctx context.Context, query string, limit int) (matches []*domain.GuestMatch, err error) {
+	ctx, done := observe(ctx, guestSearcher, "Search")
+	defer func() { done(err) }()
+
+	return r.next.Search(ctx, query, limit)
+}
@@ -0,0 +1,75 @@
+package instrumented
+
+import (
+	"context"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+)
+
+const tableRepository = "table"
+
+type TableRepository struct {
+	next port.TableRepository
+}
+
+// NewTableRepository traces every call made to the wrapped repository and records its latency and errors
+func NewTableRepository(next port.TableRepository) port.TableRepository {
+	return &TableRepository{next: next}
+}
+
+func (r *TableRepository) GetById(ctx context.Context, id int64) (table *domain.Table, err error) {
+	ctx, done := observe(ctx, tableRepository, "GetById")
+	defer func() { done(err) }()
+
+	return r.next.GetById(ctx, id)
+}
+
+func (r *TableRepository) GetAll(ctx context.Context, filter port.GetTableFilter) (tables []*domain.Table, err error) {
+	ctx, done := observe(ctx, tableRepository, "GetAll")
+	defer func() { done(err) }()
+
+	return r.next.GetAll(ctx, filter)
+}
+
+func (r *TableRepository) GetEmptySeats(ctx context.Context) (count int64, err error) {
+	ctx, done := observe(ctx, tableRepository, "GetEmptySeats")
+	defer func() { done(err) }()
+
+	return r.next.GetEmptySeats(ctx)
+}
+
+func (r *TableRepository) Create(ctx context.Context, t *domain.Table) (table *domain.Table, err error) {
+	ctx, done := observe(ctx, tableRepository, "Create")
+	defer func() { done(err) }()
+
+	return r.next.Create(ctx, t)
+}
+
+func (r *TableRepository) Update(ctx context.Context, id int64, table domain.Table) (err error) {
+	ctx, done := observe(ctx, tableRepository, "Update")
+	defer func() { done(err) }()
+
+	return r.next.Update(ctx, id, table)
+}
+
+func (r *TableRepository) Patch(ctx context.Context, id int64, patch port.TablePatch) (err error) {
+	ctx, done := observe(ctx, tableRepository, "Patch")
+	defer func() { done(err) }()
+
+	return r.next.Patch(ctx, id, patch)
+}
+
+func (r *TableRepository) Delete(ctx context.Context, id int64, version int64) (err error) {
+	ctx, done := observe(ctx, tableRepository, "Delete")
+	defer func() { done(err) }()
+
+	return r.next.Delete(ctx, id, version)
+}
+
+func (r *TableRepository) Restore(ctx context.Context, id int64) (err error) {
+	ctx, done := observe(ctx, tableRepository, "Restore")
+	defer func() { done(err) }()
+
+	return r.next.Restore(ctx, id)
+}
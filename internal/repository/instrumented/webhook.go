@@ -0,0 +1,76 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+)
+
+const webhookRepository = "webhook"
+
+type WebhookRepository struct {
+	next port.WebhookRepository
+}
+
+// NewWebhookRepository traces every call made to the wrapped repository and records its latency and errors
+func NewWebhookRepository(next port.WebhookRepository) port.WebhookRepository {
+	return &WebhookRepository{next: next}
+}
+
+func (r *WebhookRepository) Create(ctx context.Context, w *domain.Webhook) (webhook *domain.Webhook, err error) {
+	ctx, done := observe(ctx, webhookRepository, "Create")
+	defer func() { done(err) }()
+
+	return r.next.Create(ctx, w)
+}
+
+func (r *WebhookRepository) GetById(ctx context.Context, id int64) (webhook *domain.Webhook, err error) {
+	ctx, done := observe(ctx, webhookRepository, "GetById")
+	defer func() { done(err) }()
+
+	return r.next.GetById(ctx, id)
+}
+
+func (r *WebhookRepository) GetAll(ctx context.Context) (webhooks []*domain.Webhook, err error) {
+	ctx, done := observe(ctx, webhookRepository, "GetAll")
+	defer func() { done(err) }()
+
+	return r.next.GetAll(ctx)
+}
+
+func (r *WebhookRepository) Delete(ctx context.Context, id int64) (err error) {
+	ctx, done := observe(ctx, webhookRepository, "Delete")
+	defer func() { done(err) }()
+
+	return r.next.Delete(ctx, id)
+}
+
+func (r *WebhookRepository) Enqueue(ctx context.Context, event domain.Event) (count int, err error) {
+	ctx, done := observe(ctx, webhookRepository, "Enqueue")
+	defer func() { done(err) }()
+
+	return r.next.Enqueue(ctx, event)
+}
+
+func (r *WebhookRepository) GetDueDeliveries(ctx context.Context, now time.Time, limit int) (deliveries []*domain.WebhookDelivery, err error) {
+	ctx, done := observe(ctx, webhookRepository, "GetDueDeliveries")
+	defer func() { done(err) }()
+
+	return r.next.GetDueDeliveries(ctx, now, limit)
+}
+
+func (r *WebhookRepository) UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) (err error) {
+	ctx, done := observe(ctx, webhookRepository, "UpdateDelivery")
+	defer func() { done(err) }()
+
+	return r.next.UpdateDelivery(ctx, delivery)
+}
+
+func (r *WebhookRepository) GetDeliveries(ctx context.Context, filter port.GetDeliveriesFilter) (deliveries []*domain.WebhookDelivery, err error) {
+	ctx, done := observe(ctx, webhookRepository, "GetDeliveries")
+	defer func() { done(err) }()
+
+	return r.next.GetDeliveries(ctx, filter)
+}
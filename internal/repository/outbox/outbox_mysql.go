@@ -0,0 +1,92 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	infra "github.com/eazygood/getground-app/internal/infrastructure/db"
+	"github.com/eazygood/getground-app/internal/venue"
+	"gorm.io/gorm"
+)
+
+type MysqlOutboxAdapter struct {
+	Conn *gorm.DB
+}
+
+func NewMysqlOutboxAdapter(Conn *gorm.DB) port.OutboxRepository {
+	return &MysqlOutboxAdapter{
+		Conn: Conn,
+	}
+}
+
+// Append stores the events of a mutation with tx, the transaction of the mutation
+func Append(tx *gorm.DB, eventType string, data interface{}) error {
+	event, err := domain.NewEvent(eventType, venue.Now(), data)
+	if err != nil {
+		return fmt.Errorf("failed to build %v event: %v", eventType, err.Error())
+	}
+
+	message, err := domain.NewOutboxMessage(event)
+	if err != nil {
+		return fmt.Errorf("failed to build %v event: %v", eventType, err.Error())
+	}
+
+	if err := tx.Create(message).Error; err != nil {
+		return fmt.Errorf("failed to insert %v event: %v", eventType, err.Error())
+	}
+
+	return nil
+}
+
+func (m *MysqlOutboxAdapter) Add(ctx context.Context, events ...domain.Event) error {
+	messages := make([]*domain.OutboxMessage, 0, len(events))
+	for _, event := range events {
+		message, err := domain.NewOutboxMessage(event)
+		if err != nil {
+			return fmt.Errorf("failed to build outbox message: %v", err.Error())
+		}
+
+		messages = append(messages, message)
+	}
+
+	if len(messages) == 0 {
+		return nil
+	}
+
+	if err := m.Conn.WithContext(ctx).Create(&messages).Error; err != nil {
+		return fmt.Errorf("failed to insert outbox messages: %v", err.Error())
+	}
+
+	infra.MarkWrite(ctx)
+
+	return nil
+}
+
+func (m *MysqlOutboxAdapter) GetPending(ctx context.Context, now time.Time, limit int) ([]*domain.OutboxMessage, error) {
+	var messages []*domain.OutboxMessage
+
+	err := infra.Primary(ctx, m.Conn).
+		Where("published_at IS NULL AND next_attempt_at <= ?", now).
+		Order("id").Limit(limit).Find(&messages).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending outbox messages: %v", err.Error())
+	}
+
+	return messages, nil
+}
+
+func (m *MysqlOutboxAdapter) Update(ctx context.Context, message *domain.OutboxMessage) error {
+	err := m.Conn.WithContext(ctx).Model(message).
+		Select("attempts", "next_attempt_at", "last_error", "published_at").
+		Updates(message).Error
+
+	if err != nil {
+		return fmt.Errorf("failed to update outbox message (%v) %v", message.ID, err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type OutboxMysqlRepositorySuite struct {
+	suite.Suite
+	*require.Assertions
+	DB                 *gorm.DB
+	mock               sqlmock.Sqlmock
+	mySqlOutboxAdapter port.OutboxRepository
+}
+
+func TestOutboxMysqlRepositorySuite(t *testing.T) {
+	suite.Run(t, new(OutboxMysqlRepositorySuite))
+}
+
+func (t *OutboxMysqlRepositorySuite) SetupTest() {
+	var (
+		db  *sql.DB
+		err error
+	)
+
+	t.Assertions = require.New(t.T())
+
+	db, t.mock, err = sqlmock.New()
+	t.NoError(err)
+
+	t.DB, err = gorm.Open(mysql.New(mysql.Config{Conn: db, SkipInitializeWithVersion: true}), &gorm.Config{})
+	t.NoError(err)
+
+	t.mySqlOutboxAdapter = NewMysqlOutboxAdapter(t.DB)
+}
+
+func (t *OutboxMysqlRepositorySuite) TearDownTest() {
+	t.NoError(t.mock.ExpectationsWereMet())
+}
+
+func (t *OutboxMysqlRepositorySuite) TestAdd() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	occurredAt := time.Date(2023, 1, 20, 19, 30, 0, 0, time.UTC)
+	event := domain.Event{ID: "4f1c", Type: domain.EventOccupancyExceeded, OccurredAt: occurredAt, Data: []byte(`{}`)}
+
+	t.mock.ExpectBegin()
+	t.mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `outbox` (`event_id`,`event_type`,`payload`,`attempts`,`next_attempt_at`,`last_error`,`created_at`,`published_at`) VALUES (?,?,?,?,?,?,?,?)")).
+		WithArgs("4f1c", domain.EventOccupancyExceeded, sqlmock.AnyArg(), 0, occurredAt, "", occurredAt, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	t.mock.ExpectCommit()
+
+	t.NoError(t.mySqlOutboxAdapter.Add(c, event))
+}
+
+func (t *OutboxMysqlRepositorySuite) TestAppendInTransaction() {
+	t.mock.ExpectBegin()
+	t.mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `outbox`")).
+		WithArgs(sqlmock.AnyArg(), domain.EventGuestLeft, sqlmock.AnyArg(), 0, sqlmock.AnyArg(), "", sqlmock.AnyArg(), nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	t.mock.ExpectRollback()
+
+	err := t.DB.Transaction(func(tx *gorm.DB) error {
+		if err := Append(tx, domain.EventGuestLeft, domain.GuestLeft{GuestID: 1}); err != nil {
+			return err
+		}
+
+		// the mutation fails after its event was stored, neither is committed
+		return sql.ErrTxDone
+	})
+
+	t.ErrorIs(err, sql.ErrTxDone)
+}
+
+func (t *OutboxMysqlRepositorySuite) TestGetPending() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	now := time.Date(2023, 1, 20, 19, 30, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "event_id", "event_type", "payload", "attempts"}).
+		AddRow(3, "4f1c", domain.EventGuestLeft, []byte(`{"id":"4f1c","type":"guest.left","data":{"guest_id":1}}`), 1)
+
+	t.mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `outbox` WHERE published_at IS NULL AND next_attempt_at <= ? ORDER BY id LIMIT 10")).
+		WithArgs(now).
+		WillReturnRows(rows)
+
+	messages, err := t.mySqlOutboxAdapter.GetPending(c, now, 10)
+
+	t.NoError(err)
+	t.Len(messages, 1)
+
+	event, err := messages[0].Event()
+	t.NoError(err)
+	t.Equal("4f1c", event.ID)
+	t.JSONEq(`{"guest_id":1}`, string(event.Data))
+}
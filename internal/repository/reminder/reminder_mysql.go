@@ -0,0 +1,100 @@
+package reminder
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	infra "github.com/eazygood/getground-app/internal/infrastructure/db"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MysqlReminderAdapter struct {
+	Conn *gorm.DB
+}
+
+func NewMysqlReminderAdapter(Conn *gorm.DB) port.ReminderRepository {
+	return &MysqlReminderAdapter{
+		Conn: Conn,
+	}
+}
+
+// GetPending reads from the primary, a lagging replica would hand out the guests reminded a moment ago again
+func (m *MysqlReminderAdapter) GetPending(ctx context.Context, dueAt time.Time, now time.Time, statuses []string, maxAttempts int, limit int) ([]*domain.Guest, error) {
+	var guests []*domain.Guest
+
+	err := infra.Primary(ctx, m.Conn).
+		Where("status IN ? AND email <> ''", statuses).
+		Where("NOT EXISTS (SELECT 1 FROM guest_reminders WHERE guest_reminders.guest_id = guests.id AND guest_reminders.due_at = ? "+
+			"AND (guest_reminders.sent_at IS NOT NULL OR guest_reminders.attempts >= ? OR guest_reminders.next_attempt_at > ?))", dueAt, maxAttempts, now).
+		Order("id").Limit(limit).Find(&guests).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get guests pending a reminder: %v", err.Error())
+	}
+
+	return guests, nil
+}
+
+func (m *MysqlReminderAdapter) Claim(ctx context.Context, guestID int64, dueAt time.Time, now time.Time, maxAttempts int) (int, error) {
+	var reminders []*domain.GuestReminder
+	err := infra.Primary(ctx, m.Conn).Where("guest_id = ? AND due_at = ?", guestID, dueAt).Find(&reminders).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim reminder of guest (%v) %v", guestID, err.Error())
+	}
+
+	if len(reminders) == 0 {
+		// the primary key on guest_id and due_at keeps a reminder claimed by another instance
+		result := m.Conn.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&domain.GuestReminder{GuestID: guestID, DueAt: dueAt, Attempts: 1, SentAt: &now})
+
+		if result.Error != nil {
+			return 0, fmt.Errorf("failed to claim reminder of guest (%v) %v", guestID, result.Error.Error())
+		}
+
+		infra.MarkWrite(ctx)
+
+		if result.RowsAffected == 0 {
+			return 0, nil
+		}
+
+		return 1, nil
+	}
+
+	reminder := reminders[0]
+	if reminder.SentAt != nil || reminder.Attempts >= maxAttempts || (reminder.NextAttemptAt != nil && reminder.NextAttemptAt.After(now)) {
+		return 0, nil
+	}
+
+	// the attempts read are compared like a version, so that one instance only retries the reminder
+	result := m.Conn.WithContext(ctx).Model(&domain.GuestReminder{}).
+		Where("guest_id = ? AND due_at = ? AND attempts = ? AND sent_at IS NULL", guestID, dueAt, reminder.Attempts).
+		Updates(map[string]interface{}{"attempts": reminder.Attempts + 1, "sent_at": now, "next_attempt_at": nil})
+
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to claim reminder of guest (%v) %v", guestID, result.Error.Error())
+	}
+
+	infra.MarkWrite(ctx)
+
+	if result.RowsAffected == 0 {
+		return 0, nil
+	}
+
+	return reminder.Attempts + 1, nil
+}
+
+func (m *MysqlReminderAdapter) Fail(ctx context.Context, guestID int64, dueAt time.Time, nextAttemptAt time.Time, lastError string) error {
+	err := m.Conn.WithContext(ctx).Model(&domain.GuestReminder{}).
+		Where("guest_id = ? AND due_at = ?", guestID, dueAt).
+		Updates(map[string]interface{}{"sent_at": nil, "next_attempt_at": nextAttemptAt, "last_error": lastError}).Error
+
+	if err != nil {
+		return fmt.Errorf("failed to record failed reminder of guest (%v) %v", guestID, err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+package reminder
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type ReminderMysqlRepositorySuite struct {
+	suite.Suite
+	*require.Assertions
+	DB                   *gorm.DB
+	mock                 sqlmock.Sqlmock
+	mySqlReminderAdapter port.ReminderRepository
+}
+
+func TestReminderMysqlRepositorySuite(t *testing.T) {
+	suite.Run(t, new(ReminderMysqlRepositorySuite))
+}
+
+func (t *ReminderMysqlRepositorySuite) SetupTest() {
+	var (
+		db  *sql.DB
+		err error
+	)
+
+	t.Assertions = require.New(t.T())
+
+	db, t.mock, err = sqlmock.New()
+	t.NoError(err)
+
+	t.DB, err = gorm.Open(mysql.New(mysql.Config{Conn: db, SkipInitializeWithVersion: true}), &gorm.Config{})
+	t.NoError(err)
+
+	t.mySqlReminderAdapter = NewMysqlReminderAdapter(t.DB)
+}
+
+func (t *ReminderMysqlRepositorySuite) TearDownTest() {
+	t.NoError(t.mock.ExpectationsWereMet())
+}
+
+func (t *ReminderMysqlRepositorySuite) TestGetPending() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	dueAt := time.Date(2023, 6, 10, 19, 0, 0, 0, time.UTC)
+	now := dueAt.Add(time.Hour)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "email", "status"}).AddRow(4, "Ada", "ada@example.com", domain.GuestStatusInvited)
+	t.mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `guests` WHERE (status IN (?,?) AND email <> '') AND "+
+		"(NOT EXISTS (SELECT 1 FROM guest_reminders WHERE guest_reminders.guest_id = guests.id AND guest_reminders.due_at = ? "+
+		"AND (guest_reminders.sent_at IS NOT NULL OR guest_reminders.attempts >= ? OR guest_reminders.next_attempt_at > ?))) "+
+		"AND `guests`.`deleted_at` IS NULL ORDER BY id LIMIT 50")).
+		WithArgs(domain.GuestStatusInvited, domain.GuestStatusTentative, dueAt, 5, now).
+		WillReturnRows(rows)
+
+	guests, err := t.mySqlReminderAdapter.GetPending(c, dueAt, now, []string{domain.GuestStatusInvited, domain.GuestStatusTentative}, 5, 50)
+
+	t.NoError(err)
+	t.Len(guests, 1)
+	t.Equal("ada@example.com", guests[0].Email)
+}
+
+func (t *ReminderMysqlRepositorySuite) TestClaim() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	dueAt := time.Date(2023, 6, 10, 19, 0, 0, 0, time.UTC)
+	now := dueAt.Add(time.Hour)
+	insert := regexp.QuoteMeta("INSERT INTO `guest_reminders` (`guest_id`,`due_at`,`attempts`,`sent_at`,`next_attempt_at`,`last_error`) " +
+		"VALUES (?,?,?,?,?,?) ON DUPLICATE KEY UPDATE `guest_id`=`guest_id`")
+
+	expectNoReminder(t.mock, 4, dueAt)
+	t.mock.ExpectBegin()
+	t.mock.ExpectExec(insert).WithArgs(4, dueAt, 1, now, nil, "").WillReturnResult(sqlmock.NewResult(0, 1))
+	t.mock.ExpectCommit()
+
+	attempts, err := t.mySqlReminderAdapter.Claim(c, 4, dueAt, now, 5)
+	t.NoError(err)
+	t.Equal(1, attempts)
+
+	expectNoReminder(t.mock, 4, dueAt)
+	t.mock.ExpectBegin()
+	t.mock.ExpectExec(insert).WithArgs(4, dueAt, 1, now, nil, "").WillReturnResult(sqlmock.NewResult(0, 0))
+	t.mock.ExpectCommit()
+
+	attempts, err = t.mySqlReminderAdapter.Claim(c, 4, dueAt, now, 5)
+	t.NoError(err)
+	t.Zero(attempts)
+}
+
+func (t *ReminderMysqlRepositorySuite) TestClaimFailedReminder() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	dueAt := time.Date(2023, 6, 10, 19, 0, 0, 0, time.UTC)
+	now := dueAt.Add(time.Hour)
+
+	expectFailedReminder(t.mock, 4, dueAt, 2, now.Add(-time.Minute))
+	t.mock.ExpectBegin()
+	t.mock.ExpectExec(regexp.QuoteMeta("UPDATE `guest_reminders` SET `attempts`=?,`next_attempt_at`=?,`sent_at`=? "+
+		"WHERE guest_id = ? AND due_at = ? AND attempts = ? AND sent_at IS NULL")).
+		WithArgs(3, nil, now, 4, dueAt, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	t.mock.ExpectCommit()
+
+	attempts, err := t.mySqlReminderAdapter.Claim(c, 4, dueAt, now, 5)
+	t.NoError(err)
+	t.Equal(3, attempts)
+}
+
+func (t *ReminderMysqlRepositorySuite) TestClaimFailedReminderNotDue() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	dueAt := time.Date(2023, 6, 10, 19, 0, 0, 0, time.UTC)
+	now := dueAt.Add(time.Hour)
+
+	// waiting for the next attempt
+	expectFailedReminder(t.mock, 4, dueAt, 2, now.Add(time.Minute))
+
+	attempts, err := t.mySqlReminderAdapter.Claim(c, 4, dueAt, now, 5)
+	t.NoError(err)
+	t.Zero(attempts)
+
+	// out of attempts
+	expectFailedReminder(t.mock, 4, dueAt, 5, now.Add(-time.Minute))
+
+	attempts, err = t.mySqlReminderAdapter.Claim(c, 4, dueAt, now, 5)
+	t.NoError(err)
+	t.Zero(attempts)
+}
+
+func (t *ReminderMysqlRepositorySuite) TestFail() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	dueAt := time.Date(2023, 6, 10, 19, 0, 0, 0, time.UTC)
+	next := dueAt.Add(time.Hour)
+
+	t.mock.ExpectBegin()
+	t.mock.ExpectExec(regexp.QuoteMeta("UPDATE `guest_reminders` SET `last_error`=?,`next_attempt_at`=?,`sent_at`=? WHERE guest_id = ? AND due_at = ?")).
+		WithArgs("connection refused", next, nil, 4, dueAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	t.mock.ExpectCommit()
+
+	t.NoError(t.mySqlReminderAdapter.Fail(c, 4, dueAt, next, "connection refused"))
+}
+
+// expectNoReminder expects the reminder of the guest to be read before it was ever claimed
+func expectNoReminder(mock sqlmock.Sqlmock, guestID int64, dueAt time.Time) {
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `guest_reminders` WHERE guest_id = ? AND due_at = ?")).
+		WithArgs(guestID, dueAt).
+		WillReturnRows(sqlmock.NewRows([]string{"guest_id", "due_at", "attempts", "sent_at", "next_attempt_at"}))
+}
+
+// expectFailedReminder expects the reminder of the guest to be read after it could not be sent attempts times
+func expectFailedReminder(mock sqlmock.Sqlmock, guestID int64, dueAt time.Time, attempts int, nextAttemptAt time.Time) {
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `guest_reminders` WHERE guest_id = ? AND due_at = ?")).
+		WithArgs(guestID, dueAt).
+		WillReturnRows(sqlmock.NewRows([]string{"guest_id", "due_at", "attempts", "sent_at", "next_attempt_at"}).
+			AddRow(guestID, dueAt, attempts, nil, nextAttemptAt))
+}
@@ -0,0 +1,142 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"golang.org/x/text/unicode/norm"
+)
+
+// minScore is the score under which a name is not considered a match of the query
+const minScore = 0.5
+
+// rank scores the names of guests against query and returns the limit best matches, best first
+func rank(query string, guests []*domain.Guest, limit int) []*domain.GuestMatch {
+	words := normalize(query)
+	matches := make([]*domain.GuestMatch, 0, len(guests))
+
+	for _, guest := range guests {
+		if score := score(words, normalize(guest.Name)); score >= minScore {
+			matches = append(matches, &domain.GuestMatch{Guest: guest, Score: score})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+
+		return matches[i].Guest.ID < matches[j].Guest.ID
+	})
+
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	return matches
+}
+
+// normalize splits s into lower case words stripped of their accents, "Zoë O'Brien" is [zoe o brien]
+func normalize(s string) []string {
+	var b strings.Builder
+
+	for _, r := range norm.NFD.String(s) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// combining accents left by the decomposition
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			b.WriteRune(' ')
+		}
+	}
+
+	return strings.Fields(b.String())
+}
+
+// score is the mean of the similarity of every query word to the closest word of the name,
+// or the similarity of the whole query to the whole name when that is better ("maryann" and "Mary Ann")
+func score(query []string, name []string) float64 {
+	if len(query) == 0 || len(name) == 0 {
+		return 0
+	}
+
+	total := 0.0
+	for _, q := range query {
+		best := 0.0
+		for _, w := range name {
+			if s := similarity(q, w); s > best {
+				best = s
+			}
+		}
+
+		total += best
+	}
+
+	return math.Max(total/float64(len(query)), similarity(strings.Join(query, ""), strings.Join(name, "")))
+}
+
+// similarity is 1 for equal words, 0.9 when q starts w, so "jon" finds "Jonathan" right after "Jon",
+// and otherwise 1 minus the edit distance relative to the longest word, "jon" is 0.75 close to "john"
+func similarity(q, w string) float64 {
+	if q == w {
+		return 1
+	}
+
+	if len(q) > 1 && strings.HasPrefix(w, q) {
+		return 0.9
+	}
+
+	a, b := []rune(q), []rune(w)
+	longest := len(a)
+	if len(b) > longest {
+		longest = len(b)
+	}
+
+	return 1 - float64(distance(a, b))/float64(longest)
+}
+
+// distance is the Damerau-Levenshtein distance of a and b restricted to adjacent transpositions,
+// so "jhon" is one typo away from "john"
+func distance(a, b []rune) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			d[i][j] = smallest(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = smallest(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return d[len(a)][len(b)]
+}
+
+func smallest(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+
+	return m
+}
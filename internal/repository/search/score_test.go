@@ -0,0 +1,46 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	require.Equal(t, []string{"zoe", "o", "brien"}, normalize("  Zoë O'Brien"))
+	require.Equal(t, []string{"jose", "muller"}, normalize("JOSÉ Müller"))
+}
+
+func TestSimilarity(t *testing.T) {
+	require.Equal(t, 1.0, similarity("jon", "jon"))
+	require.Equal(t, 0.9, similarity("jon", "jonathan"))
+	require.Equal(t, 0.75, similarity("jon", "john"))
+	require.Equal(t, 0.75, similarity("jhon", "john"))
+	require.Less(t, similarity("jon", "tom"), minScore)
+}
+
+func TestRank(t *testing.T) {
+	guests := []*domain.Guest{
+		{ID: 1, Name: "Tom Jones"},
+		{ID: 2, Name: "John Smith"},
+		{ID: 3, Name: "Jon Snow"},
+		{ID: 4, Name: "Jonathan Harker"},
+		{ID: 5, Name: "Mary Ann"},
+	}
+
+	matches := rank("JON", guests, 10)
+
+	ids := make([]int64, 0, len(matches))
+	for _, match := range matches {
+		ids = append(ids, match.Guest.ID)
+	}
+
+	// Jones is a prefix match as well, it ties with Jonathan and comes first by id
+	require.Equal(t, []int64{3, 1, 4, 2}, ids)
+	require.Equal(t, 1.0, matches[0].Score)
+
+	require.Len(t, rank("jon", guests, 2), 2)
+	require.Equal(t, int64(5), rank("maryann", guests, 10)[0].Guest.ID)
+	require.Empty(t, rank("zebedee", guests, 10))
+}
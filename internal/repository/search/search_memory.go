@@ -0,0 +1,30 @@
+package search
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+)
+
+type MemoryGuestSearchAdapter struct {
+	repository port.GuestRepository
+}
+
+// NewMemoryGuestSearchAdapter scores every guest of repository in process, it needs no index and
+// suits the tests and the parties small enough to be listed on every search
+func NewMemoryGuestSearchAdapter(repository port.GuestRepository) port.GuestSearcher {
+	return &MemoryGuestSearchAdapter{
+		repository: repository,
+	}
+}
+
+func (m *MemoryGuestSearchAdapter) Search(ctx context.Context, query string, limit int) ([]*domain.GuestMatch, error) {
+	guests, err := m.repository.GetAll(ctx, port.GetGuestFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search guests: %v", err.Error())
+	}
+
+	return rank(query, guests, limit), nil
+}
@@ -0,0 +1,88 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	infra "github.com/eazygood/getground-app/internal/infrastructure/db"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// candidates is how many guests the FULLTEXT index is asked for per match returned, they are
+// scored again the same way the in-process adapter does
+const candidates = 5
+
+type MysqlGuestSearchAdapter struct {
+	Conn *gorm.DB
+}
+
+// NewMysqlGuestSearchAdapter looks the candidates up in the ngram FULLTEXT index of the guest names,
+// a name sharing a few bigrams with the query is found despite a typo, whatever its case and accents.
+// The index is asked for a bounded number of candidates, the guests are never scanned.
+func NewMysqlGuestSearchAdapter(Conn *gorm.DB) port.GuestSearcher {
+	return &MysqlGuestSearchAdapter{
+		Conn: Conn,
+	}
+}
+
+func (m *MysqlGuestSearchAdapter) Search(ctx context.Context, query string, limit int) ([]*domain.GuestMatch, error) {
+	var guests []*domain.Guest
+
+	against := bigrams(query)
+	if against == "" {
+		return []*domain.GuestMatch{}, nil
+	}
+
+	// in boolean mode the rows are not sorted, the most relevant are asked for explicitly
+	err := infra.Replica(ctx, m.Conn).
+		Where("MATCH (name) AGAINST (? IN BOOLEAN MODE)", against).
+		Clauses(clause.OrderBy{Expression: clause.Expr{SQL: "MATCH (name) AGAINST (? IN BOOLEAN MODE) DESC", Vars: []interface{}{against}}}).
+		Limit(limit * candidates).Find(&guests).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to search guests: %v", err.Error())
+	}
+
+	return rank(query, guests, limit), nil
+}
+
+// bigrams is the boolean mode query matching a name that shares any bigram with a word of query, or with
+// that word after swapping two adjacent letters: "jhon" shares none with "John" but "jhon" swapped to "john"
+// does. A word of one letter is matched as a prefix.
+func bigrams(query string) string {
+	seen := make(map[string]bool)
+	terms := make([]string, 0)
+	add := func(term string) {
+		if !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+
+	for _, word := range normalize(query) {
+		letters := []rune(word)
+		if len(letters) == 1 {
+			add(word + "*")
+			continue
+		}
+
+		variants := [][]rune{letters}
+		for i := 0; i+1 < len(letters); i++ {
+			swapped := append([]rune(nil), letters...)
+			swapped[i], swapped[i+1] = swapped[i+1], swapped[i]
+			variants = append(variants, swapped)
+		}
+
+		for _, variant := range variants {
+			for i := 0; i+1 < len(variant); i++ {
+				add(string(variant[i : i+2]))
+			}
+		}
+	}
+
+	return strings.Join(terms, " ")
+}
@@ -0,0 +1,84 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	ports "github.com/eazygood/getground-app/mocks/core/port"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestMysqlSearchRanksFullTextCandidates(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	conn, err := gorm.Open(mysql.New(mysql.Config{Conn: db, SkipInitializeWithVersion: true}), &gorm.Config{})
+	require.NoError(t, err)
+
+	rows := sqlmock.NewRows([]string{"id", "name"}).AddRow(2, "John Smith").AddRow(3, "Jon Snow").AddRow(6, "Joan Wild")
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `guests` WHERE MATCH (name) AGAINST (? IN BOOLEAN MODE) AND `guests`.`deleted_at` IS NULL ORDER BY MATCH (name) AGAINST (? IN BOOLEAN MODE) DESC LIMIT 10")).
+		WithArgs("jo on oj jn no", "jo on oj jn no").
+		WillReturnRows(rows)
+
+	matches, err := NewMysqlGuestSearchAdapter(conn).Search(context.Background(), "jon", 2)
+
+	require.NoError(t, err)
+	require.Len(t, matches, 2)
+	require.Equal(t, int64(3), matches[0].Guest.ID)
+	require.Equal(t, int64(2), matches[1].Guest.ID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlSearchFails(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	conn, err := gorm.Open(mysql.New(mysql.Config{Conn: db, SkipInitializeWithVersion: true}), &gorm.Config{})
+	require.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `guests`")).WillReturnError(errors.New("connection refused"))
+
+	_, err = NewMysqlGuestSearchAdapter(conn).Search(context.Background(), "jon", 2)
+
+	require.EqualError(t, err, "failed to search guests: connection refused")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBigramsCoverTranspositions(t *testing.T) {
+	// "jhon" shares no bigram with "John", swapping its "h" and "o" gives "jo", "oh" and "hn"
+	require.Equal(t, "jh ho on hj jo oh hn no", bigrams("jhon"))
+	require.Equal(t, "zo oe oz ze eo o*", bigrams("Zoë O'"))
+	require.Equal(t, "", bigrams("--"))
+}
+
+func TestMemorySearch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repository := ports.NewMockGuestRepository(ctrl)
+
+	repository.EXPECT().GetAll(gomock.Any(), port.GetGuestFilter{}).
+		Return([]*domain.Guest{{ID: 1, Name: "Zoë Brien"}, {ID: 2, Name: "Simon"}}, nil).Times(1)
+
+	matches, err := NewMemoryGuestSearchAdapter(repository).Search(context.Background(), "zoe", 10)
+
+	require.NoError(t, err)
+	require.Equal(t, []*domain.GuestMatch{{Guest: &domain.Guest{ID: 1, Name: "Zoë Brien"}, Score: 1}}, matches)
+}
+
+func TestMemorySearchFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repository := ports.NewMockGuestRepository(ctrl)
+
+	repository.EXPECT().GetAll(gomock.Any(), port.GetGuestFilter{}).Return(nil, errors.New("connection refused")).Times(1)
+
+	_, err := NewMemoryGuestSearchAdapter(repository).Search(context.Background(), "zoe", 10)
+
+	require.EqualError(t, err, "failed to search guests: connection refused")
+}
@@ -7,6 +7,9 @@ import (
 
 	"github.com/eazygood/getground-app/internal/core/domain"
 	"github.com/eazygood/getground-app/internal/core/port"
+	apperrors "github.com/eazygood/getground-app/internal/errors"
+	infra "github.com/eazygood/getground-app/internal/infrastructure/db"
+	"github.com/eazygood/getground-app/internal/repository/outbox"
 	v "github.com/eazygood/getground-app/internal/validator"
 	"gorm.io/gorm"
 )
@@ -26,14 +29,27 @@ func (m *MysqlTableAdapter) Create(ctx context.Context, table *domain.Table) (*d
 		return nil, fmt.Errorf("failed to insert guest due to validation: %v", err)
 	}
 
-	err := m.Conn.Create(table).Error
+	if table.Version == 0 {
+		table.Version = 1
+	}
+
+	err := m.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(table).Error; err != nil {
+			return fmt.Errorf("failed to table guest: %v", err.Error())
+		}
+
+		return outbox.Append(tx, domain.EventTableCreated, table)
+	})
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to table guest: %v", err.Error())
+		return nil, err
 	}
 
+	infra.MarkWrite(ctx)
+
+	// read back from the primary, a replica may not have the new row yet
 	t := &domain.Table{}
-	err = m.Conn.First(t, table.ID).Error
+	err = infra.Primary(ctx, m.Conn).First(t, table.ID).Error
 
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, fmt.Errorf("record not found by id: %v", table.ID)
@@ -47,29 +63,107 @@ func (m *MysqlTableAdapter) Create(ctx context.Context, table *domain.Table) (*d
 }
 
 func (m *MysqlTableAdapter) Update(ctx context.Context, id int64, table domain.Table) error {
-	err := m.Conn.Model(&domain.Table{}).Where("id = ?", id).Updates(table).Error
+	version := table.Version
+	table.Version = version + 1
 
-	if err != nil {
-		return fmt.Errorf("failed to update table: %v", err.Error())
+	err := m.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Table{}).Where("id = ? AND version = ?", id, version).Updates(table)
+
+		if result.Error != nil {
+			return fmt.Errorf("failed to update table: %v", result.Error.Error())
+		}
+
+		if result.RowsAffected == 0 {
+			return conflictOrMissing(tx, id, version)
+		}
+
+		return appendTable(tx, domain.EventTableUpdated, id)
+	})
+
+	if err == nil {
+		infra.MarkWrite(ctx)
 	}
 
-	return nil
+	return err
 }
 
-func (m *MysqlTableAdapter) Delete(ctx context.Context, id int64) error {
-	err := m.Conn.Delete(&domain.Table{}, id).Error
+func (m *MysqlTableAdapter) Patch(ctx context.Context, id int64, patch port.TablePatch) error {
+	table := patch.Table
 
-	if err != nil {
-		return fmt.Errorf("failed to delete table by id (%v) %v", id, err.Error())
+	version := table.Version
+	table.Version = version + 1
+	fields := append(append([]string{}, patch.Fields...), "version")
+
+	err := m.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Table{}).Where("id = ? AND version = ?", id, version).Select(fields).Updates(&table)
+
+		if result.Error != nil {
+			return fmt.Errorf("failed to patch table: %v", result.Error.Error())
+		}
+
+		if result.RowsAffected == 0 {
+			return conflictOrMissing(tx, id, version)
+		}
+
+		return appendTable(tx, domain.EventTableUpdated, id)
+	})
+
+	if err == nil {
+		infra.MarkWrite(ctx)
 	}
 
-	return nil
+	return err
+}
+
+func (m *MysqlTableAdapter) Delete(ctx context.Context, id int64, version int64) error {
+	err := m.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("version = ?", version).Delete(&domain.Table{}, id)
+
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete table by id (%v) %v", id, result.Error.Error())
+		}
+
+		if result.RowsAffected == 0 {
+			return conflictOrMissing(tx, id, version)
+		}
+
+		return outbox.Append(tx, domain.EventTableDeleted, domain.TableDeleted{TableID: id})
+	})
+
+	if err == nil {
+		infra.MarkWrite(ctx)
+	}
+
+	return err
+}
+
+func (m *MysqlTableAdapter) Restore(ctx context.Context, id int64) error {
+	err := m.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Model(&domain.Table{}).Where("id = ? AND deleted_at IS NOT NULL", id).
+			Updates(map[string]interface{}{"deleted_at": nil, "version": gorm.Expr("version + 1")})
+
+		if result.Error != nil {
+			return fmt.Errorf("failed to restore table by id (%v) %v", id, result.Error.Error())
+		}
+
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("deleted record not found by id: %v", id)
+		}
+
+		return appendTable(tx, domain.EventTableRestored, id)
+	})
+
+	if err == nil {
+		infra.MarkWrite(ctx)
+	}
+
+	return err
 }
 
 func (m *MysqlTableAdapter) GetEmptySeats(ctx context.Context) (int64, error) {
 	var sum int64
 
-	err := m.Conn.Model(&domain.Table{}).Where("guest_id IS NULL").Select("sum(seats) as count").Row().Scan(&sum)
+	err := infra.Replica(ctx, m.Conn).Model(&domain.Table{}).Where("guest_id IS NULL").Select("sum(seats) as count").Row().Scan(&sum)
 
 	if err != nil {
 		return 0, nil
@@ -81,7 +175,52 @@ func (m *MysqlTableAdapter) GetEmptySeats(ctx context.Context) (int64, error) {
 func (m *MysqlTableAdapter) GetOccupiedSeats(ctx context.Context) ([]*domain.Table, error) {
 	var tables []*domain.Table
 
-	err := m.Conn.Joins("JOIN guests ON guests.id = tables.guest_id").Where("tables.guest_id IS NOT NULL").Error
+	err := infra.Replica(ctx, m.Conn).Joins("JOIN guests ON guests.id = tables.guest_id AND guests.deleted_at IS NULL").Where("tables.guest_id IS NOT NULL").Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get list of tables: %v", err.Error())
+	}
+
+	return tables, nil
+}
+
+func (m *MysqlTableAdapter) GetAll(ctx context.Context, filter port.GetTableFilter) ([]*domain.Table, error) {
+	var tables []*domain.Table
+
+	conn := infra.Replica(ctx, m.Conn)
+	if filter.Latest {
+		conn = infra.Primary(ctx, m.Conn)
+	}
+
+	if filter.Occupied != nil {
+		if *filter.Occupied {
+			conn = conn.Where("guest_id IS NOT NULL")
+		} else {
+			conn = conn.Where("guest_id IS NULL")
+		}
+	}
+
+	if filter.MinSeats > 0 {
+		conn = conn.Where("seats >= ?", filter.MinSeats)
+	}
+
+	if filter.IDs != nil {
+		conn = conn.Where("id IN ?", filter.IDs)
+	}
+
+	if filter.GuestIDs != nil {
+		conn = conn.Where("guest_id IN ?", filter.GuestIDs)
+	}
+
+	if filter.AfterID > 0 {
+		conn = conn.Where("id > ?", filter.AfterID)
+	}
+
+	if filter.Limit > 0 {
+		conn = conn.Order("id").Limit(filter.Limit)
+	}
+
+	err := conn.Find(&tables).Error
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to get list of tables: %v", err.Error())
@@ -92,10 +231,10 @@ func (m *MysqlTableAdapter) GetOccupiedSeats(ctx context.Context) ([]*domain.Tab
 
 func (m *MysqlTableAdapter) GetById(ctx context.Context, id int64) (*domain.Table, error) {
 	table := &domain.Table{}
-	err := m.Conn.First(table, id).Error
+	err := infra.Replica(ctx, m.Conn).First(table, id).Error
 
 	if errors.Is(err, gorm.ErrRecordNotFound) {
-		return nil, fmt.Errorf("record not found by id: %v", id)
+		return nil, fmt.Errorf("%w by id: %v", domain.ErrNotFound, id)
 	}
 
 	if err != nil {
@@ -104,3 +243,27 @@ func (m *MysqlTableAdapter) GetById(ctx context.Context, id int64) (*domain.Tabl
 
 	return table, nil
 }
+
+// appendTable stores an event carrying the table as the transaction left it
+func appendTable(tx *gorm.DB, eventType string, id int64) error {
+	table := &domain.Table{}
+	if err := tx.First(table, id).Error; err != nil {
+		return fmt.Errorf("failed to get table by id (%v) %v", id, err.Error())
+	}
+
+	return outbox.Append(tx, eventType, table)
+}
+
+// conflictOrMissing tells apart the reasons a versioned write matched no row: the table is gone, or its version moved on
+func conflictOrMissing(tx *gorm.DB, id int64, version int64) error {
+	var count int64
+	if err := tx.Model(&domain.Table{}).Where("id = ?", id).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to get table by id (%v) %v", id, err.Error())
+	}
+
+	if count == 0 {
+		return fmt.Errorf("%w by id: %v", domain.ErrNotFound, id)
+	}
+
+	return apperrors.NewConflictError("table", id, version)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/eazygood/getground-app/internal/core/domain"
 	"github.com/eazygood/getground-app/internal/core/port"
+	apperrors "github.com/eazygood/getground-app/internal/errors"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -54,7 +55,7 @@ func (u *TableMysqlRepositorySuite) TearDownTest() {
 }
 
 func (t *TableMysqlRepositorySuite) TestCreateTable() {
-	c, cancel := context.WithTimeout(context.Background(), time.Duration(1000))
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
 	table := &domain.Table{
@@ -64,12 +65,13 @@ func (t *TableMysqlRepositorySuite) TestCreateTable() {
 	rows := sqlmock.NewRows([]string{"id", "seat", "guest_id"}).AddRow(1, 15, nil)
 	t.mock.ExpectBegin()
 
-	t.mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `tables` (`seats`,`guest_id`) VALUES (?,?)")).
-		WithArgs(15, nil).
+	t.mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `tables` (`seats`,`guest_id`,`version`,`deleted_at`) VALUES (?,?,?,?)")).
+		WithArgs(15, nil, 1, nil).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
+	expectOutbox(t.mock, domain.EventTableCreated)
 	t.mock.ExpectCommit()
-	t.mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `tables` WHERE `tables`.`id` = ? ORDER BY `tables`.`id` LIMIT 1")).WithArgs(1).WillReturnRows(rows)
+	t.mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `tables` WHERE `tables`.`id` = ? AND `tables`.`deleted_at` IS NULL ORDER BY `tables`.`id` LIMIT 1")).WithArgs(1).WillReturnRows(rows)
 
 	_, err := t.mySqlTableAdapter.Create(c, table)
 
@@ -77,7 +79,7 @@ func (t *TableMysqlRepositorySuite) TestCreateTable() {
 }
 
 func (t *TableMysqlRepositorySuite) TestCreateWithGuestIdTable() {
-	c, cancel := context.WithTimeout(context.Background(), time.Duration(1000))
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
 	guest := &domain.Guest{
@@ -90,15 +92,16 @@ func (t *TableMysqlRepositorySuite) TestCreateWithGuestIdTable() {
 		GuestID: &guest.ID,
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "seats", "guest_id"}).AddRow(1, 15, 1)
+	rows := sqlmock.NewRows([]string{"id", "seats", "guest_id", "version"}).AddRow(1, 15, 1, 1)
 
 	t.mock.ExpectBegin()
-	t.mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `tables` (`seats`,`guest_id`) VALUES (?,?)")).
-		WithArgs(15, 1).
+	t.mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `tables` (`seats`,`guest_id`,`version`,`deleted_at`) VALUES (?,?,?,?)")).
+		WithArgs(15, 1, 1, nil).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
+	expectOutbox(t.mock, domain.EventTableCreated)
 	t.mock.ExpectCommit()
-	t.mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `tables` WHERE `tables`.`id` = ? ORDER BY `tables`.`id` LIMIT 1")).WithArgs(1).WillReturnRows(rows)
+	t.mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `tables` WHERE `tables`.`id` = ? AND `tables`.`deleted_at` IS NULL ORDER BY `tables`.`id` LIMIT 1")).WithArgs(1).WillReturnRows(rows)
 
 	actual, err := t.mySqlTableAdapter.Create(c, table)
 
@@ -108,12 +111,12 @@ func (t *TableMysqlRepositorySuite) TestCreateWithGuestIdTable() {
 }
 
 func (t *TableMysqlRepositorySuite) TestGetEmptySeats() {
-	c, cancel := context.WithTimeout(context.Background(), time.Duration(1000))
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
 	rows := sqlmock.NewRows([]string{"count"}).AddRow(15)
 
-	t.mock.ExpectQuery(regexp.QuoteMeta("SELECT sum(seats) as count FROM `tables` WHERE guest_id IS NULL")).WillReturnRows(rows)
+	t.mock.ExpectQuery(regexp.QuoteMeta("SELECT sum(seats) as count FROM `tables` WHERE guest_id IS NULL AND `tables`.`deleted_at` IS NULL")).WillReturnRows(rows)
 
 	actual, err := t.mySqlTableAdapter.GetEmptySeats(c)
 
@@ -122,8 +125,33 @@ func (t *TableMysqlRepositorySuite) TestGetEmptySeats() {
 	t.EqualValues(15, actual)
 }
 
+func (t *TableMysqlRepositorySuite) TestGetAllTables() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	occupied := true
+	filter := port.GetTableFilter{
+		Occupied: &occupied,
+		MinSeats: 4,
+		GuestIDs: []int64{7, 8},
+		AfterID:  2,
+		Limit:    10,
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "seats", "guest_id"}).AddRow(3, 4, 7)
+	t.mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `tables` WHERE guest_id IS NOT NULL AND seats >= ? AND guest_id IN (?,?) AND id > ? AND `tables`.`deleted_at` IS NULL ORDER BY id LIMIT 10")).
+		WithArgs(4, 7, 8, 2).
+		WillReturnRows(rows)
+
+	tables, err := t.mySqlTableAdapter.GetAll(c, filter)
+
+	t.NoError(err)
+	t.Len(tables, 1)
+	t.EqualValues(7, *tables[0].GuestID)
+}
+
 func (t *TableMysqlRepositorySuite) TestUpdateGuest() {
-	c, cancel := context.WithTimeout(context.Background(), time.Duration(1000))
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
 	tableId := 1
@@ -138,13 +166,16 @@ func (t *TableMysqlRepositorySuite) TestUpdateGuest() {
 	table := &domain.Table{
 		Seats:   10,
 		GuestID: &guest.ID,
+		Version: 1,
 	}
 
 	t.mock.ExpectBegin()
 
 	t.mock.ExpectExec("UPDATE `tables` SET (.+) WHERE (.+)").
-		WithArgs(table.Seats, guest.ID, tableId).
+		WithArgs(table.Seats, guest.ID, 2, tableId, 1).
 		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectReadBack(t.mock, 1)
+	expectOutbox(t.mock, domain.EventTableUpdated)
 	t.mock.ExpectCommit()
 
 	err := t.mySqlTableAdapter.Update(c, int64(tableId), *table)
@@ -152,21 +183,123 @@ func (t *TableMysqlRepositorySuite) TestUpdateGuest() {
 	t.NoError(err)
 }
 
+func (t *TableMysqlRepositorySuite) TestPatchTableClearsGuest() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	patch := port.TablePatch{
+		Table:  domain.Table{Version: 1},
+		Fields: []string{"guest_id"},
+	}
+
+	t.mock.ExpectBegin()
+
+	t.mock.ExpectExec(regexp.QuoteMeta("UPDATE `tables` SET `guest_id`=?,`version`=? WHERE (id = ? AND version = ?) AND `tables`.`deleted_at` IS NULL")).
+		WithArgs(nil, 2, 1, 1).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectReadBack(t.mock, 1)
+	expectOutbox(t.mock, domain.EventTableUpdated)
+	t.mock.ExpectCommit()
+
+	err := t.mySqlTableAdapter.Patch(c, 1, patch)
+
+	t.NoError(err)
+}
+
 func (t *TableMysqlRepositorySuite) TestDeleteGuest() {
-	c, cancel := context.WithTimeout(context.Background(), time.Duration(1000))
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
 	tableId := 1
 
 	t.mock.ExpectBegin()
 
-	t.mock.ExpectExec(("DELETE FROM `tables` WHERE (.+)")).
-		WithArgs(tableId).
+	t.mock.ExpectExec(regexp.QuoteMeta("UPDATE `tables` SET `deleted_at`=? WHERE version = ? AND `tables`.`id` = ? AND `tables`.`deleted_at` IS NULL")).
+		WithArgs(sqlmock.AnyArg(), 1, tableId).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
+	expectOutbox(t.mock, domain.EventTableDeleted)
 	t.mock.ExpectCommit()
 
-	err := t.mySqlTableAdapter.Delete(c, int64(tableId))
+	err := t.mySqlTableAdapter.Delete(c, int64(tableId), 1)
 
 	t.NoError(err)
 }
+
+func (t *TableMysqlRepositorySuite) TestDeleteTableVersionConflict() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	tableId := 1
+
+	t.mock.ExpectBegin()
+
+	t.mock.ExpectExec(regexp.QuoteMeta("UPDATE `tables` SET `deleted_at`=? WHERE version = ? AND `tables`.`id` = ? AND `tables`.`deleted_at` IS NULL")).
+		WithArgs(sqlmock.AnyArg(), 1, tableId).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	expectTableCount(t.mock, int64(tableId), 1)
+
+	t.mock.ExpectRollback()
+
+	err := t.mySqlTableAdapter.Delete(c, int64(tableId), 1)
+
+	var conflict *apperrors.ConflictError
+	t.ErrorAs(err, &conflict)
+}
+
+func (t *TableMysqlRepositorySuite) TestDeleteTableNotFound() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	t.mock.ExpectBegin()
+
+	t.mock.ExpectExec(regexp.QuoteMeta("UPDATE `tables` SET `deleted_at`=? WHERE version = ? AND `tables`.`id` = ? AND `tables`.`deleted_at` IS NULL")).
+		WithArgs(sqlmock.AnyArg(), 1, 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	expectTableCount(t.mock, 1, 0)
+
+	t.mock.ExpectRollback()
+
+	err := t.mySqlTableAdapter.Delete(c, 1, 1)
+
+	t.ErrorIs(err, domain.ErrNotFound)
+	t.NoError(t.mock.ExpectationsWereMet())
+}
+
+func (t *TableMysqlRepositorySuite) TestRestoreTableNotDeleted() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	t.mock.ExpectBegin()
+
+	t.mock.ExpectExec(regexp.QuoteMeta("UPDATE `tables` SET `deleted_at`=?,`version`=version + 1 WHERE id = ? AND deleted_at IS NOT NULL")).
+		WithArgs(nil, 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	t.mock.ExpectCommit()
+
+	err := t.mySqlTableAdapter.Restore(c, 1)
+
+	t.ErrorContains(err, "deleted record not found by id: 1")
+}
+
+// expectTableCount expects a write that matched no row to look whether the table is still there
+func expectTableCount(mock sqlmock.Sqlmock, id int64, count int64) {
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `tables` WHERE id = ? AND `tables`.`deleted_at` IS NULL")).
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(count))
+}
+
+// expectReadBack expects the table to be read in the transaction of the mutation, for its event
+func expectReadBack(mock sqlmock.Sqlmock, id int64) {
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `tables` WHERE `tables`.`id` = ? AND `tables`.`deleted_at` IS NULL ORDER BY `tables`.`id` LIMIT 1")).
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "seats"}).AddRow(id, 10))
+}
+
+// expectOutbox expects an event of eventType to be stored in the transaction of the mutation
+func expectOutbox(mock sqlmock.Sqlmock, eventType string) {
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `outbox` (`event_id`,`event_type`,`payload`,`attempts`,`next_attempt_at`,`last_error`,`created_at`,`published_at`) VALUES (?,?,?,?,?,?,?,?)")).
+		WithArgs(sqlmock.AnyArg(), eventType, sqlmock.AnyArg(), 0, sqlmock.AnyArg(), "", sqlmock.AnyArg(), nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
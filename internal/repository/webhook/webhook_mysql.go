@@ -0,0 +1,167 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	infra "github.com/eazygood/getground-app/internal/infrastructure/db"
+	"github.com/eazygood/getground-app/internal/venue"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MysqlWebhookAdapter struct {
+	Conn *gorm.DB
+}
+
+func NewMysqlWebhookAdapter(Conn *gorm.DB) port.WebhookRepository {
+	return &MysqlWebhookAdapter{
+		Conn: Conn,
+	}
+}
+
+func (m *MysqlWebhookAdapter) Create(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error) {
+	webhook.CreatedAt = venue.Now()
+
+	if err := m.Conn.WithContext(ctx).Create(webhook).Error; err != nil {
+		return nil, fmt.Errorf("failed to insert webhook: %v", err.Error())
+	}
+
+	infra.MarkWrite(ctx)
+
+	return webhook, nil
+}
+
+func (m *MysqlWebhookAdapter) GetById(ctx context.Context, id int64) (*domain.Webhook, error) {
+	webhook := &domain.Webhook{}
+	err := infra.Replica(ctx, m.Conn).First(webhook, id).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("record not found by id: %v", id)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook by id (%v) %v", id, err.Error())
+	}
+
+	return webhook, nil
+}
+
+func (m *MysqlWebhookAdapter) GetAll(ctx context.Context) ([]*domain.Webhook, error) {
+	var webhooks []*domain.Webhook
+
+	if err := infra.Replica(ctx, m.Conn).Find(&webhooks).Error; err != nil {
+		return nil, fmt.Errorf("failed to get list of webhooks: %v", err.Error())
+	}
+
+	return webhooks, nil
+}
+
+func (m *MysqlWebhookAdapter) Delete(ctx context.Context, id int64) error {
+	result := m.Conn.WithContext(ctx).Delete(&domain.Webhook{}, id)
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete webhook by id (%v) %v", id, result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("record not found by id: %v", id)
+	}
+
+	infra.MarkWrite(ctx)
+
+	return nil
+}
+
+func (m *MysqlWebhookAdapter) Enqueue(ctx context.Context, event domain.Event) (int, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode event %v: %v", event.ID, err.Error())
+	}
+
+	var webhooks []*domain.Webhook
+	if err := infra.Primary(ctx, m.Conn).Find(&webhooks).Error; err != nil {
+		return 0, fmt.Errorf("failed to get list of webhooks: %v", err.Error())
+	}
+
+	now := venue.Now()
+	var deliveries []*domain.WebhookDelivery
+	for _, webhook := range webhooks {
+		if !webhook.Subscribes(event.Type) {
+			continue
+		}
+
+		deliveries = append(deliveries, &domain.WebhookDelivery{
+			WebhookID:     webhook.ID,
+			EventID:       event.ID,
+			EventType:     event.Type,
+			Payload:       payload,
+			Status:        domain.DeliveryPending,
+			NextAttemptAt: &now,
+			CreatedAt:     now,
+		})
+	}
+
+	if len(deliveries) == 0 {
+		return 0, nil
+	}
+
+	// the unique key on webhook_id and event_id drops the deliveries of an event published twice
+	result := m.Conn.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&deliveries)
+
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to enqueue event %v: %v", event.ID, result.Error.Error())
+	}
+
+	infra.MarkWrite(ctx)
+
+	return int(result.RowsAffected), nil
+}
+
+func (m *MysqlWebhookAdapter) GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*domain.WebhookDelivery, error) {
+	var deliveries []*domain.WebhookDelivery
+
+	err := infra.Primary(ctx, m.Conn).
+		Where("status = ? AND next_attempt_at <= ?", domain.DeliveryPending, now).
+		Order("next_attempt_at, id").Limit(limit).Find(&deliveries).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due webhook deliveries: %v", err.Error())
+	}
+
+	return deliveries, nil
+}
+
+func (m *MysqlWebhookAdapter) UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	// selecting the columns writes the cleared ones as well
+	err := m.Conn.WithContext(ctx).Model(delivery).
+		Select("status", "attempts", "next_attempt_at", "last_error", "response_status", "delivered_at").
+		Updates(delivery).Error
+
+	if err != nil {
+		return fmt.Errorf("failed to update webhook delivery (%v) %v", delivery.ID, err.Error())
+	}
+
+	return nil
+}
+
+func (m *MysqlWebhookAdapter) GetDeliveries(ctx context.Context, filter port.GetDeliveriesFilter) ([]*domain.WebhookDelivery, error) {
+	var deliveries []*domain.WebhookDelivery
+
+	conn := infra.Replica(ctx, m.Conn).Where("webhook_id = ?", filter.WebhookID).Order("id DESC")
+
+	if filter.Limit > 0 {
+		conn = conn.Limit(filter.Limit)
+	}
+
+	if err := conn.Find(&deliveries).Error; err != nil {
+		return nil, fmt.Errorf("failed to get webhook deliveries: %v", err.Error())
+	}
+
+	return deliveries, nil
+}
@@ -0,0 +1,123 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/core/port"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type WebhookMysqlRepositorySuite struct {
+	suite.Suite
+	*require.Assertions
+	DB                  *gorm.DB
+	mock                sqlmock.Sqlmock
+	mySqlWebhookAdapter port.WebhookRepository
+}
+
+func TestWebhookMysqlRepositorySuite(t *testing.T) {
+	suite.Run(t, new(WebhookMysqlRepositorySuite))
+}
+
+func (t *WebhookMysqlRepositorySuite) SetupTest() {
+	var (
+		db  *sql.DB
+		err error
+	)
+
+	t.Assertions = require.New(t.T())
+
+	db, t.mock, err = sqlmock.New()
+	t.NoError(err)
+
+	t.DB, err = gorm.Open(mysql.New(mysql.Config{Conn: db, SkipInitializeWithVersion: true}), &gorm.Config{})
+	t.NoError(err)
+
+	t.mySqlWebhookAdapter = NewMysqlWebhookAdapter(t.DB)
+}
+
+func (t *WebhookMysqlRepositorySuite) TearDownTest() {
+	t.NoError(t.mock.ExpectationsWereMet())
+}
+
+func (t *WebhookMysqlRepositorySuite) TestEnqueueForSubscribedWebhooks() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	event := domain.Event{ID: "4f1c", Type: domain.EventGuestArrived, Data: []byte(`{"id":1}`)}
+
+	rows := sqlmock.NewRows([]string{"id", "url", "secret", "event_types"}).
+		AddRow(1, "https://hr.example.com/hooks", "secret", `["guest.arrived"]`).
+		AddRow(2, "https://catering.example.com/hooks", "secret", `["occupancy.threshold_crossed"]`)
+
+	t.mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `webhooks` WHERE `webhooks`.`deleted_at` IS NULL")).WillReturnRows(rows)
+	t.mock.ExpectBegin()
+	t.mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `webhook_deliveries` (`webhook_id`,`event_id`,`event_type`,`payload`,`status`,`attempts`,`next_attempt_at`,`last_error`,`response_status`,`created_at`,`delivered_at`) VALUES (?,?,?,?,?,?,?,?,?,?,?) ON DUPLICATE KEY UPDATE `id`=`id`")).
+		WithArgs(1, "4f1c", domain.EventGuestArrived, sqlmock.AnyArg(), domain.DeliveryPending, 0, sqlmock.AnyArg(), "", 0, sqlmock.AnyArg(), nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	t.mock.ExpectCommit()
+
+	enqueued, err := t.mySqlWebhookAdapter.Enqueue(c, event)
+
+	t.NoError(err)
+	t.Equal(1, enqueued)
+}
+
+func (t *WebhookMysqlRepositorySuite) TestEnqueueWithoutSubscribers() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	rows := sqlmock.NewRows([]string{"id", "url", "secret", "event_types"}).
+		AddRow(1, "https://hr.example.com/hooks", "secret", `["guest.arrived"]`)
+
+	t.mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `webhooks` WHERE `webhooks`.`deleted_at` IS NULL")).WillReturnRows(rows)
+
+	enqueued, err := t.mySqlWebhookAdapter.Enqueue(c, domain.Event{ID: "4f1c", Type: domain.EventGuestLeft})
+
+	t.NoError(err)
+	t.Zero(enqueued)
+}
+
+func (t *WebhookMysqlRepositorySuite) TestGetDueDeliveries() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	now := time.Date(2023, 1, 20, 19, 30, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "webhook_id", "event_id", "status", "attempts"}).
+		AddRow(3, 1, "4f1c", domain.DeliveryPending, 1)
+
+	t.mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `webhook_deliveries` WHERE status = ? AND next_attempt_at <= ? ORDER BY next_attempt_at, id LIMIT 10")).
+		WithArgs(domain.DeliveryPending, now).
+		WillReturnRows(rows)
+
+	deliveries, err := t.mySqlWebhookAdapter.GetDueDeliveries(c, now, 10)
+
+	t.NoError(err)
+	t.Len(deliveries, 1)
+	t.Equal(int64(3), deliveries[0].ID)
+	t.Equal(1, deliveries[0].Attempts)
+}
+
+func (t *WebhookMysqlRepositorySuite) TestDeleteNotFound() {
+	c, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	t.mock.ExpectBegin()
+	t.mock.ExpectExec(regexp.QuoteMeta("UPDATE `webhooks` SET `deleted_at`=? WHERE `webhooks`.`id` = ? AND `webhooks`.`deleted_at` IS NULL")).
+		WithArgs(sqlmock.AnyArg(), 5).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	t.mock.ExpectCommit()
+
+	err := t.mySqlWebhookAdapter.Delete(c, 5)
+
+	t.EqualError(err, "record not found by id: 5")
+}
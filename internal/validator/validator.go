@@ -1,9 +1,221 @@
-package db
+package validator
 
 import (
-	"github.com/go-playground/validator"
+	stderrors "errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/eazygood/getground-app/internal/config"
+	"github.com/eazygood/getground-app/internal/core/domain"
+	"github.com/eazygood/getground-app/internal/errors"
+	"github.com/eazygood/getground-app/internal/venue"
+	playground "github.com/go-playground/validator/v10"
+	"github.com/go-playground/validator/v10/non-standard/validators"
+)
+
+const (
+	partySizeRule = "party_size"
+	timestampRule = "timestamp"
+	httpURLRule   = "http_url"
+	eventTypeRule = "event_type"
+	phoneRule     = "phone"
+	dietaryRule   = "dietary"
 )
 
-func GetValidator() *validator.Validate {
-	return validator.New()
+var instance = New(config.Venue{})
+
+// Init configures the validator shared by the controllers and repositories, it is called once on startup
+func Init(cfg config.Venue) {
+	instance = New(cfg)
+}
+
+func GetValidator() *Validator {
+	return instance
+}
+
+type Validator struct {
+	validate     *playground.Validate
+	maxPartySize int
+}
+
+func New(cfg config.Venue) *Validator {
+	v := &Validator{
+		validate:     playground.New(),
+		maxPartySize: cfg.MaxPartySize,
+	}
+
+	// errors name the fields the way clients send them
+	v.validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			return field.Name
+		}
+
+		return name
+	})
+
+	_ = v.validate.RegisterValidation("notblank", validators.NotBlank)
+	_ = v.validate.RegisterValidation(partySizeRule, v.partySize)
+	_ = v.validate.RegisterValidation(timestampRule, timestamp)
+	_ = v.validate.RegisterValidation(httpURLRule, httpURL)
+	_ = v.validate.RegisterValidation(eventTypeRule, eventType)
+	_ = v.validate.RegisterValidation(phoneRule, phone)
+	_ = v.validate.RegisterValidation(dietaryRule, dietary)
+
+	return v
+}
+
+// Struct checks the validate tags of s, every broken rule is reported in an errors.ValidationError
+func (v *Validator) Struct(s interface{}) error {
+	return v.validationError(v.validate.Struct(s))
+}
+
+// StructPartial checks the validate tags of the given fields of s only, named as in Go, e.g. "Name"
+func (v *Validator) StructPartial(s interface{}, fields ...string) error {
+	return v.validationError(v.validate.StructPartial(s, fields...))
+}
+
+func (v *Validator) validationError(err error) error {
+	var fieldErrors playground.ValidationErrors
+	if !stderrors.As(err, &fieldErrors) {
+		return err
+	}
+
+	fields := make([]errors.FieldError, 0, len(fieldErrors))
+	for _, fieldError := range fieldErrors {
+		fields = append(fields, errors.FieldError{
+			Field:   fieldError.Field(),
+			Rule:    fieldError.Tag(),
+			Message: v.message(fieldError),
+		})
+	}
+
+	return errors.NewValidationError(fields...)
+}
+
+// partySize accepts accompanying guests as long as the guest and their entourage fit the maximum party size
+func (v *Validator) partySize(field playground.FieldLevel) bool {
+	if v.maxPartySize <= 0 {
+		return true
+	}
+
+	switch field.Field().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return field.Field().Int()+1 <= int64(v.maxPartySize)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return field.Field().Uint()+1 <= uint64(v.maxPartySize)
+	default:
+		return false
+	}
+}
+
+// timestamp accepts the strings venue.ParseTime can read
+func timestamp(field playground.FieldLevel) bool {
+	_, err := venue.ParseTime(field.Field().String())
+
+	return err == nil
+}
+
+// httpURL accepts absolute http and https URLs, the ones the server can call back
+func httpURL(field playground.FieldLevel) bool {
+	u, err := url.Parse(field.Field().String())
+	if err != nil {
+		return false
+	}
+
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// eventType accepts the types of the events published by the outbox
+func eventType(field playground.FieldLevel) bool {
+	for _, t := range domain.EventTypes {
+		if field.Field().String() == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+// phone accepts international and local numbers of 7 to 15 digits, the usual separators are ignored
+func phone(field playground.FieldLevel) bool {
+	number := strings.TrimPrefix(field.Field().String(), "+")
+
+	digits := 0
+	for _, r := range number {
+		switch {
+		case r >= '0' && r <= '9':
+			digits++
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+		default:
+			return false
+		}
+	}
+
+	return digits >= 7 && digits <= 15
+}
+
+// dietary accepts the dietary requirements the kitchen caters for
+func dietary(field playground.FieldLevel) bool {
+	for _, requirement := range domain.DietaryRequirements {
+		if field.Field().String() == requirement {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TimestampMessage explains the timestamps accepted for field
+func TimestampMessage(field string) string {
+	return fmt.Sprintf("%s must be an RFC 3339 timestamp such as 2023-01-20T19:30:00+01:00", field)
+}
+
+func (v *Validator) message(fieldError playground.FieldError) string {
+	field := fieldError.Field()
+	isString := fieldError.Kind() == reflect.String
+	isList := fieldError.Kind() == reflect.Slice
+
+	switch fieldError.Tag() {
+	case "required":
+		return fmt.Sprintf("%s is required", field)
+	case "notblank":
+		return fmt.Sprintf("%s must not be blank", field)
+	case "min":
+		if isString {
+			return fmt.Sprintf("%s must be at least %s characters long", field, fieldError.Param())
+		}
+
+		if isList {
+			return fmt.Sprintf("%s must have at least %s items", field, fieldError.Param())
+		}
+
+		return fmt.Sprintf("%s must be at least %s", field, fieldError.Param())
+	case "max":
+		if isString {
+			return fmt.Sprintf("%s must be at most %s characters long", field, fieldError.Param())
+		}
+
+		return fmt.Sprintf("%s must be at most %s", field, fieldError.Param())
+	case "oneof":
+		return fmt.Sprintf("%s must be one of %s", field, strings.Join(strings.Fields(fieldError.Param()), ", "))
+	case eventTypeRule:
+		return fmt.Sprintf("%s must be one of %s", field, strings.Join(domain.EventTypes, ", "))
+	case dietaryRule:
+		return fmt.Sprintf("%s must be one of %s", field, strings.Join(domain.DietaryRequirements, ", "))
+	case phoneRule:
+		return fmt.Sprintf("%s must be a phone number such as +44 20 7946 0000", field)
+	case "email":
+		return fmt.Sprintf("%s must be an email address", field)
+	case httpURLRule:
+		return fmt.Sprintf("%s must be an absolute http or https URL", field)
+	case timestampRule:
+		return TimestampMessage(field)
+	case partySizeRule:
+		return fmt.Sprintf("%s must be at most %d, a party is limited to %d people", field, v.maxPartySize-1, v.maxPartySize)
+	default:
+		return fmt.Sprintf("%s does not satisfy %s", field, fieldError.Tag())
+	}
 }
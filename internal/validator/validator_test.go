@@ -0,0 +1,126 @@
+package validator
+
+import (
+	stderrors "errors"
+	"testing"
+
+	"github.com/eazygood/getground-app/internal/config"
+	"github.com/eazygood/getground-app/internal/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type party struct {
+	Name               string `json:"name" validate:"required,notblank,max=5"`
+	AccompanyingGuests uint16 `json:"accompanying_guests" validate:"party_size"`
+	Seats              int    `json:"seats" validate:"min=1"`
+}
+
+func TestStructReportsEveryField(t *testing.T) {
+	err := New(config.Venue{MaxPartySize: 4}).Struct(party{Name: "   ", AccompanyingGuests: 4})
+
+	var validation *errors.ValidationError
+	require.True(t, stderrors.As(err, &validation))
+	require.Equal(t, []errors.FieldError{
+		{Field: "name", Rule: "notblank", Message: "name must not be blank"},
+		{Field: "accompanying_guests", Rule: "party_size", Message: "accompanying_guests must be at most 3, a party is limited to 4 people"},
+		{Field: "seats", Rule: "min", Message: "seats must be at least 1"},
+	}, validation.Fields)
+}
+
+func TestStructMessages(t *testing.T) {
+	err := New(config.Venue{}).Struct(party{Name: "Simon Says", Seats: 1})
+
+	var validation *errors.ValidationError
+	require.True(t, stderrors.As(err, &validation))
+	require.Equal(t, []errors.FieldError{
+		{Field: "name", Rule: "max", Message: "name must be at most 5 characters long"},
+	}, validation.Fields)
+
+	err = New(config.Venue{}).Struct(party{Seats: 1})
+
+	require.True(t, stderrors.As(err, &validation))
+	require.Equal(t, "required", validation.Fields[0].Rule)
+}
+
+func TestStructPartialChecksTheGivenFields(t *testing.T) {
+	err := New(config.Venue{MaxPartySize: 4}).StructPartial(party{AccompanyingGuests: 4}, "AccompanyingGuests")
+
+	var validation *errors.ValidationError
+	require.True(t, stderrors.As(err, &validation))
+	require.Equal(t, []errors.FieldError{
+		{Field: "accompanying_guests", Rule: "party_size", Message: "accompanying_guests must be at most 3, a party is limited to 4 people"},
+	}, validation.Fields)
+
+	require.NoError(t, New(config.Venue{}).StructPartial(party{Name: "Simon"}, "Name"))
+}
+
+func TestPartySizeWithoutLimit(t *testing.T) {
+	require.NoError(t, New(config.Venue{}).Struct(party{Name: "Simon", AccompanyingGuests: 9999, Seats: 1}))
+}
+
+func TestPartySizeAtLimit(t *testing.T) {
+	require.NoError(t, New(config.Venue{MaxPartySize: 4}).Struct(party{Name: "Simon", AccompanyingGuests: 3, Seats: 1}))
+}
+
+type subscription struct {
+	URL    string   `json:"url" validate:"required,http_url"`
+	Events []string `json:"events" validate:"required,min=1,dive,oneof=a b"`
+}
+
+func TestHTTPURLAndLists(t *testing.T) {
+	require.NoError(t, New(config.Venue{}).Struct(subscription{URL: "https://hr.example.com/hooks?team=vip", Events: []string{"a"}}))
+
+	err := New(config.Venue{}).Struct(subscription{URL: "ftp://example.com", Events: []string{"a", "c"}})
+
+	var validation *errors.ValidationError
+	require.True(t, stderrors.As(err, &validation))
+	require.Equal(t, []errors.FieldError{
+		{Field: "url", Rule: "http_url", Message: "url must be an absolute http or https URL"},
+		{Field: "events[1]", Rule: "oneof", Message: "events[1] must be one of a, b"},
+	}, validation.Fields)
+
+	err = New(config.Venue{}).Struct(subscription{URL: "/relative", Events: []string{}})
+
+	require.True(t, stderrors.As(err, &validation))
+	require.Equal(t, []errors.FieldError{
+		{Field: "url", Rule: "http_url", Message: "url must be an absolute http or https URL"},
+		{Field: "events", Rule: "min", Message: "events must have at least 1 items"},
+	}, validation.Fields)
+}
+
+func TestEventType(t *testing.T) {
+	type subscription struct {
+		EventTypes []string `json:"event_types" validate:"dive,event_type"`
+	}
+
+	require.NoError(t, New(config.Venue{}).Struct(subscription{EventTypes: []string{"guest.arrived", "table.deleted"}}))
+
+	err := New(config.Venue{}).Struct(subscription{EventTypes: []string{"guest.sneezed"}})
+
+	var validation *errors.ValidationError
+	require.True(t, stderrors.As(err, &validation))
+	require.Equal(t, "event_type", validation.Fields[0].Rule)
+}
+
+func TestContactDetails(t *testing.T) {
+	type profile struct {
+		Email   string   `json:"email" validate:"omitempty,email"`
+		Phone   string   `json:"phone" validate:"omitempty,phone"`
+		Dietary []string `json:"dietary" validate:"dive,dietary"`
+	}
+
+	require.NoError(t, New(config.Venue{}).Struct(profile{}))
+	require.NoError(t, New(config.Venue{}).Struct(profile{Email: "ada@example.com", Phone: "+44 (20) 7946-0000", Dietary: []string{"vegan", "nut_allergy"}}))
+
+	err := New(config.Venue{}).Struct(profile{Email: "ada", Phone: "call me", Dietary: []string{"vegan", "carnivore"}})
+
+	var validation *errors.ValidationError
+	require.True(t, stderrors.As(err, &validation))
+	require.Equal(t, []errors.FieldError{
+		{Field: "email", Rule: "email", Message: "email must be an email address"},
+		{Field: "phone", Rule: "phone", Message: "phone must be a phone number such as +44 20 7946 0000"},
+		{Field: "dietary[1]", Rule: "dietary", Message: "dietary[1] must be one of vegetarian, vegan, pescatarian, gluten_free, dairy_free, nut_allergy, halal, kosher"},
+	}, validation.Fields)
+
+	require.Error(t, New(config.Venue{}).Struct(profile{Phone: "12345"}))
+}
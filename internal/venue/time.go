@@ -0,0 +1,60 @@
+package venue
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/eazygood/getground-app/internal/config"
+)
+
+// FallbackLayouts are accepted when a timestamp is not RFC 3339. Layouts without
+// an offset are read in the venue timezone.
+var FallbackLayouts = []string{
+	time.RFC1123Z,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"02.01.2006 15:04:05",
+	"02/01/2006 15:04:05",
+	"2006/01/02 15:04:05",
+}
+
+var location = time.UTC
+
+// Init sets the venue timezone, it is called once on startup. An empty timezone means UTC.
+func Init(cfg config.Venue) error {
+	loc, err := time.LoadLocation(cfg.Timezone)
+	if err != nil {
+		return fmt.Errorf("invalid venue timezone %q: %w", cfg.Timezone, err)
+	}
+
+	location = loc
+
+	return nil
+}
+
+// Location is the timezone of the venue, timestamps are rendered in it unless the client asks for another one
+func Location() *time.Location {
+	return location
+}
+
+// Now is the current time in UTC, the way timestamps are stored
+func Now() time.Time {
+	return time.Now().UTC()
+}
+
+// ParseTime reads an RFC 3339 timestamp, or one of the FallbackLayouts, and returns it in UTC
+func ParseTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+
+	for _, layout := range FallbackLayouts {
+		if t, err := time.ParseInLocation(layout, value, location); err == nil {
+			return t.UTC(), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("%q is not an RFC 3339 timestamp", value)
+}
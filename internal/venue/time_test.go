@@ -0,0 +1,40 @@
+package venue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eazygood/getground-app/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTime(t *testing.T) {
+	require.NoError(t, Init(config.Venue{Timezone: "Europe/Berlin"}))
+	defer func() { location = time.UTC }()
+
+	want := time.Date(2023, 1, 20, 18, 30, 0, 0, time.UTC)
+
+	for _, value := range []string{
+		"2023-01-20T18:30:00Z",
+		"2023-01-20T20:30:00+02:00",
+		"Fri, 20 Jan 2023 19:30:00 +0100",
+		"2023-01-20 19:30:00",
+		"20.01.2023 19:30:00",
+	} {
+		got, err := ParseTime(value)
+		require.NoError(t, err, value)
+		require.Equal(t, want, got, value)
+		require.Equal(t, time.UTC, got.Location(), value)
+	}
+}
+
+func TestParseTimeRejectsUnknownLayouts(t *testing.T) {
+	_, err := ParseTime("20th of January")
+
+	require.EqualError(t, err, `"20th of January" is not an RFC 3339 timestamp`)
+}
+
+func TestInitRejectsUnknownTimezone(t *testing.T) {
+	require.Error(t, Init(config.Venue{Timezone: "Mars/Olympus"}))
+	require.Equal(t, time.UTC, Location())
+}
@@ -52,17 +52,17 @@ func (mr *MockGuestServiceMockRecorder) Create(ctx, g interface{}) *gomock.Call
 }
 
 // Delete mocks base method.
-func (m *MockGuestService) Delete(ctx context.Context, id int64) error {
+func (m *MockGuestService) Delete(ctx context.Context, id, version int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockGuestServiceMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+func (mr *MockGuestServiceMockRecorder) Delete(ctx, id, version interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockGuestService)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockGuestService)(nil).Delete), ctx, id, version)
 }
 
 // GetById mocks base method.
@@ -95,6 +95,64 @@ func (mr *MockGuestServiceMockRecorder) GetList(ctx, filter interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockGuestService)(nil).GetList), ctx, filter)
 }
 
+// MarkNoShow mocks base method.
+func (m *MockGuestService) MarkNoShow(ctx context.Context, id int64) (*domain.Guest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNoShow", ctx, id)
+	ret0, _ := ret[0].(*domain.Guest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkNoShow indicates an expected call of MarkNoShow.
+func (mr *MockGuestServiceMockRecorder) MarkNoShow(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNoShow", reflect.TypeOf((*MockGuestService)(nil).MarkNoShow), ctx, id)
+}
+
+// Patch mocks base method.
+func (m *MockGuestService) Patch(ctx context.Context, id int64, patch port.GuestPatch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Patch", ctx, id, patch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *MockGuestServiceMockRecorder) Patch(ctx, id, patch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockGuestService)(nil).Patch), ctx, id, patch)
+}
+
+// RSVP mocks base method.
+func (m *MockGuestService) RSVP(ctx context.Context, id int64, rsvp domain.RSVP) (*domain.Guest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RSVP", ctx, id, rsvp)
+	ret0, _ := ret[0].(*domain.Guest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RSVP indicates an expected call of RSVP.
+func (mr *MockGuestServiceMockRecorder) RSVP(ctx, id, rsvp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RSVP", reflect.TypeOf((*MockGuestService)(nil).RSVP), ctx, id, rsvp)
+}
+
+// Restore mocks base method.
+func (m *MockGuestService) Restore(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockGuestServiceMockRecorder) Restore(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockGuestService)(nil).Restore), ctx, id)
+}
+
 // Update mocks base method.
 func (m *MockGuestService) Update(ctx context.Context, id int64, u *domain.Guest) error {
 	m.ctrl.T.Helper()
@@ -109,6 +167,111 @@ func (mr *MockGuestServiceMockRecorder) Update(ctx, id, u interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockGuestService)(nil).Update), ctx, id, u)
 }
 
+// MockCompanionService is a mock of CompanionService interface.
+type MockCompanionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCompanionServiceMockRecorder
+}
+
+// MockCompanionServiceMockRecorder is the mock recorder for MockCompanionService.
+type MockCompanionServiceMockRecorder struct {
+	mock *MockCompanionService
+}
+
+// NewMockCompanionService creates a new mock instance.
+func NewMockCompanionService(ctrl *gomock.Controller) *MockCompanionService {
+	mock := &MockCompanionService{ctrl: ctrl}
+	mock.recorder = &MockCompanionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCompanionService) EXPECT() *MockCompanionServiceMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockCompanionService) Add(ctx context.Context, companion *domain.Companion) (*domain.Companion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, companion)
+	ret0, _ := ret[0].(*domain.Companion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockCompanionServiceMockRecorder) Add(ctx, companion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockCompanionService)(nil).Add), ctx, companion)
+}
+
+// GetList mocks base method.
+func (m *MockCompanionService) GetList(ctx context.Context, guestID int64) ([]*domain.Companion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList", ctx, guestID)
+	ret0, _ := ret[0].([]*domain.Companion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockCompanionServiceMockRecorder) GetList(ctx, guestID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockCompanionService)(nil).GetList), ctx, guestID)
+}
+
+// Remove mocks base method.
+func (m *MockCompanionService) Remove(ctx context.Context, guestID, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", ctx, guestID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockCompanionServiceMockRecorder) Remove(ctx, guestID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockCompanionService)(nil).Remove), ctx, guestID, id)
+}
+
+// MockGuestSearchService is a mock of GuestSearchService interface.
+type MockGuestSearchService struct {
+	ctrl     *gomock.Controller
+	recorder *MockGuestSearchServiceMockRecorder
+}
+
+// MockGuestSearchServiceMockRecorder is the mock recorder for MockGuestSearchService.
+type MockGuestSearchServiceMockRecorder struct {
+	mock *MockGuestSearchService
+}
+
+// NewMockGuestSearchService creates a new mock instance.
+func NewMockGuestSearchService(ctrl *gomock.Controller) *MockGuestSearchService {
+	mock := &MockGuestSearchService{ctrl: ctrl}
+	mock.recorder = &MockGuestSearchServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGuestSearchService) EXPECT() *MockGuestSearchServiceMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *MockGuestSearchService) Search(ctx context.Context, query string, limit int) ([]*domain.GuestMatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, query, limit)
+	ret0, _ := ret[0].([]*domain.GuestMatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockGuestSearchServiceMockRecorder) Search(ctx, query, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockGuestSearchService)(nil).Search), ctx, query, limit)
+}
+
 // MockGuestListService is a mock of GuestListService interface.
 type MockGuestListService struct {
 	ctrl     *gomock.Controller
@@ -162,6 +325,44 @@ func (mr *MockGuestListServiceMockRecorder) GetOccupiedSeats(ctx interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOccupiedSeats", reflect.TypeOf((*MockGuestListService)(nil).GetOccupiedSeats), ctx)
 }
 
+// MockCheckinService is a mock of CheckinService interface.
+type MockCheckinService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCheckinServiceMockRecorder
+}
+
+// MockCheckinServiceMockRecorder is the mock recorder for MockCheckinService.
+type MockCheckinServiceMockRecorder struct {
+	mock *MockCheckinService
+}
+
+// NewMockCheckinService creates a new mock instance.
+func NewMockCheckinService(ctrl *gomock.Controller) *MockCheckinService {
+	mock := &MockCheckinService{ctrl: ctrl}
+	mock.recorder = &MockCheckinServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCheckinService) EXPECT() *MockCheckinServiceMockRecorder {
+	return m.recorder
+}
+
+// CheckIn mocks base method.
+func (m *MockCheckinService) CheckIn(ctx context.Context, guestID int64, accompanyingGuests uint16) (*domain.Table, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckIn", ctx, guestID, accompanyingGuests)
+	ret0, _ := ret[0].(*domain.Table)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckIn indicates an expected call of CheckIn.
+func (mr *MockCheckinServiceMockRecorder) CheckIn(ctx, guestID, accompanyingGuests interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckIn", reflect.TypeOf((*MockCheckinService)(nil).CheckIn), ctx, guestID, accompanyingGuests)
+}
+
 // MockTableService is a mock of TableService interface.
 type MockTableService struct {
 	ctrl     *gomock.Controller
@@ -201,17 +402,17 @@ func (mr *MockTableServiceMockRecorder) Create(ctx, table interface{}) *gomock.C
 }
 
 // Delete mocks base method.
-func (m *MockTableService) Delete(ctx context.Context, id int64) error {
+func (m *MockTableService) Delete(ctx context.Context, id, version int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockTableServiceMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+func (mr *MockTableServiceMockRecorder) Delete(ctx, id, version interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTableService)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTableService)(nil).Delete), ctx, id, version)
 }
 
 // GetById mocks base method.
@@ -244,6 +445,49 @@ func (mr *MockTableServiceMockRecorder) GetEmptySeats(ctx interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmptySeats", reflect.TypeOf((*MockTableService)(nil).GetEmptySeats), ctx)
 }
 
+// GetList mocks base method.
+func (m *MockTableService) GetList(ctx context.Context, filter port.GetTableFilter) ([]*domain.Table, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList", ctx, filter)
+	ret0, _ := ret[0].([]*domain.Table)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockTableServiceMockRecorder) GetList(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockTableService)(nil).GetList), ctx, filter)
+}
+
+// Patch mocks base method.
+func (m *MockTableService) Patch(ctx context.Context, id int64, patch port.TablePatch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Patch", ctx, id, patch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *MockTableServiceMockRecorder) Patch(ctx, id, patch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockTableService)(nil).Patch), ctx, id, patch)
+}
+
+// Restore mocks base method.
+func (m *MockTableService) Restore(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockTableServiceMockRecorder) Restore(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockTableService)(nil).Restore), ctx, id)
+}
+
 // Update mocks base method.
 func (m *MockTableService) Update(ctx context.Context, id int64, table domain.Table) error {
 	m.ctrl.T.Helper()
@@ -257,3 +501,546 @@ func (mr *MockTableServiceMockRecorder) Update(ctx, id, table interface{}) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTableService)(nil).Update), ctx, id, table)
 }
+
+// MockWebhookService is a mock of WebhookService interface.
+type MockWebhookService struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookServiceMockRecorder
+}
+
+// MockWebhookServiceMockRecorder is the mock recorder for MockWebhookService.
+type MockWebhookServiceMockRecorder struct {
+	mock *MockWebhookService
+}
+
+// NewMockWebhookService creates a new mock instance.
+func NewMockWebhookService(ctrl *gomock.Controller) *MockWebhookService {
+	mock := &MockWebhookService{ctrl: ctrl}
+	mock.recorder = &MockWebhookServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookService) EXPECT() *MockWebhookServiceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockWebhookService) Delete(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWebhookServiceMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWebhookService)(nil).Delete), ctx, id)
+}
+
+// DeliverDue mocks base method.
+func (m *MockWebhookService) DeliverDue(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliverDue", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeliverDue indicates an expected call of DeliverDue.
+func (mr *MockWebhookServiceMockRecorder) DeliverDue(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliverDue", reflect.TypeOf((*MockWebhookService)(nil).DeliverDue), ctx)
+}
+
+// GetById mocks base method.
+func (m *MockWebhookService) GetById(ctx context.Context, id int64) (*domain.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*domain.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockWebhookServiceMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockWebhookService)(nil).GetById), ctx, id)
+}
+
+// GetDeliveries mocks base method.
+func (m *MockWebhookService) GetDeliveries(ctx context.Context, filter port.GetDeliveriesFilter) ([]*domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", ctx, filter)
+	ret0, _ := ret[0].([]*domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries.
+func (mr *MockWebhookServiceMockRecorder) GetDeliveries(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*MockWebhookService)(nil).GetDeliveries), ctx, filter)
+}
+
+// GetList mocks base method.
+func (m *MockWebhookService) GetList(ctx context.Context) ([]*domain.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList", ctx)
+	ret0, _ := ret[0].([]*domain.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockWebhookServiceMockRecorder) GetList(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockWebhookService)(nil).GetList), ctx)
+}
+
+// Publish mocks base method.
+func (m *MockWebhookService) Publish(ctx context.Context, event domain.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockWebhookServiceMockRecorder) Publish(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockWebhookService)(nil).Publish), ctx, event)
+}
+
+// Register mocks base method.
+func (m *MockWebhookService) Register(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Register", ctx, webhook)
+	ret0, _ := ret[0].(*domain.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockWebhookServiceMockRecorder) Register(ctx, webhook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockWebhookService)(nil).Register), ctx, webhook)
+}
+
+// MockWebhookSender is a mock of WebhookSender interface.
+type MockWebhookSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookSenderMockRecorder
+}
+
+// MockWebhookSenderMockRecorder is the mock recorder for MockWebhookSender.
+type MockWebhookSenderMockRecorder struct {
+	mock *MockWebhookSender
+}
+
+// NewMockWebhookSender creates a new mock instance.
+func NewMockWebhookSender(ctrl *gomock.Controller) *MockWebhookSender {
+	mock := &MockWebhookSender{ctrl: ctrl}
+	mock.recorder = &MockWebhookSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookSender) EXPECT() *MockWebhookSenderMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockWebhookSender) Check(ctx context.Context, url string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, url)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockWebhookSenderMockRecorder) Check(ctx, url interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockWebhookSender)(nil).Check), ctx, url)
+}
+
+// Send mocks base method.
+func (m *MockWebhookSender) Send(ctx context.Context, webhook *domain.Webhook, delivery *domain.WebhookDelivery) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, webhook, delivery)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockWebhookSenderMockRecorder) Send(ctx, webhook, delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockWebhookSender)(nil).Send), ctx, webhook, delivery)
+}
+
+// MockOutboxService is a mock of OutboxService interface.
+type MockOutboxService struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxServiceMockRecorder
+}
+
+// MockOutboxServiceMockRecorder is the mock recorder for MockOutboxService.
+type MockOutboxServiceMockRecorder struct {
+	mock *MockOutboxService
+}
+
+// NewMockOutboxService creates a new mock instance.
+func NewMockOutboxService(ctrl *gomock.Controller) *MockOutboxService {
+	mock := &MockOutboxService{ctrl: ctrl}
+	mock.recorder = &MockOutboxServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxService) EXPECT() *MockOutboxServiceMockRecorder {
+	return m.recorder
+}
+
+// Dispatch mocks base method.
+func (m *MockOutboxService) Dispatch(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Dispatch", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Dispatch indicates an expected call of Dispatch.
+func (mr *MockOutboxServiceMockRecorder) Dispatch(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dispatch", reflect.TypeOf((*MockOutboxService)(nil).Dispatch), ctx)
+}
+
+// Publish mocks base method.
+func (m *MockOutboxService) Publish(ctx context.Context, event domain.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockOutboxServiceMockRecorder) Publish(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockOutboxService)(nil).Publish), ctx, event)
+}
+
+// MockEventSink is a mock of EventSink interface.
+type MockEventSink struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventSinkMockRecorder
+}
+
+// MockEventSinkMockRecorder is the mock recorder for MockEventSink.
+type MockEventSinkMockRecorder struct {
+	mock *MockEventSink
+}
+
+// NewMockEventSink creates a new mock instance.
+func NewMockEventSink(ctrl *gomock.Controller) *MockEventSink {
+	mock := &MockEventSink{ctrl: ctrl}
+	mock.recorder = &MockEventSinkMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventSink) EXPECT() *MockEventSinkMockRecorder {
+	return m.recorder
+}
+
+// Name mocks base method.
+func (m *MockEventSink) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockEventSinkMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockEventSink)(nil).Name))
+}
+
+// Publish mocks base method.
+func (m *MockEventSink) Publish(ctx context.Context, event domain.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockEventSinkMockRecorder) Publish(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockEventSink)(nil).Publish), ctx, event)
+}
+
+// MockTicketService is a mock of TicketService interface.
+type MockTicketService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTicketServiceMockRecorder
+}
+
+// MockTicketServiceMockRecorder is the mock recorder for MockTicketService.
+type MockTicketServiceMockRecorder struct {
+	mock *MockTicketService
+}
+
+// NewMockTicketService creates a new mock instance.
+func NewMockTicketService(ctrl *gomock.Controller) *MockTicketService {
+	mock := &MockTicketService{ctrl: ctrl}
+	mock.recorder = &MockTicketServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTicketService) EXPECT() *MockTicketServiceMockRecorder {
+	return m.recorder
+}
+
+// Issue mocks base method.
+func (m *MockTicketService) Issue(guestID int64) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Issue", guestID)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Issue indicates an expected call of Issue.
+func (mr *MockTicketServiceMockRecorder) Issue(guestID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Issue", reflect.TypeOf((*MockTicketService)(nil).Issue), guestID)
+}
+
+// Verify mocks base method.
+func (m *MockTicketService) Verify(token string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", token)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockTicketServiceMockRecorder) Verify(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockTicketService)(nil).Verify), token)
+}
+
+// MockReportService is a mock of ReportService interface.
+type MockReportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportServiceMockRecorder
+}
+
+// MockReportServiceMockRecorder is the mock recorder for MockReportService.
+type MockReportServiceMockRecorder struct {
+	mock *MockReportService
+}
+
+// NewMockReportService creates a new mock instance.
+func NewMockReportService(ctrl *gomock.Controller) *MockReportService {
+	mock := &MockReportService{ctrl: ctrl}
+	mock.recorder = &MockReportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportService) EXPECT() *MockReportServiceMockRecorder {
+	return m.recorder
+}
+
+// Capacity mocks base method.
+func (m *MockReportService) Capacity(ctx context.Context) (*domain.CapacityReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Capacity", ctx)
+	ret0, _ := ret[0].(*domain.CapacityReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Capacity indicates an expected call of Capacity.
+func (mr *MockReportServiceMockRecorder) Capacity(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Capacity", reflect.TypeOf((*MockReportService)(nil).Capacity), ctx)
+}
+
+// Dietary mocks base method.
+func (m *MockReportService) Dietary(ctx context.Context) (*domain.DietaryReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Dietary", ctx)
+	ret0, _ := ret[0].(*domain.DietaryReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Dietary indicates an expected call of Dietary.
+func (mr *MockReportServiceMockRecorder) Dietary(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dietary", reflect.TypeOf((*MockReportService)(nil).Dietary), ctx)
+}
+
+// Forecast mocks base method.
+func (m *MockReportService) Forecast(ctx context.Context) (*domain.Forecast, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Forecast", ctx)
+	ret0, _ := ret[0].(*domain.Forecast)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Forecast indicates an expected call of Forecast.
+func (mr *MockReportServiceMockRecorder) Forecast(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Forecast", reflect.TypeOf((*MockReportService)(nil).Forecast), ctx)
+}
+
+// LatestForecast mocks base method.
+func (m *MockReportService) LatestForecast(ctx context.Context) (*domain.Forecast, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestForecast", ctx)
+	ret0, _ := ret[0].(*domain.Forecast)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LatestForecast indicates an expected call of LatestForecast.
+func (mr *MockReportServiceMockRecorder) LatestForecast(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestForecast", reflect.TypeOf((*MockReportService)(nil).LatestForecast), ctx)
+}
+
+// MockMailService is a mock of MailService interface.
+type MockMailService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMailServiceMockRecorder
+}
+
+// MockMailServiceMockRecorder is the mock recorder for MockMailService.
+type MockMailServiceMockRecorder struct {
+	mock *MockMailService
+}
+
+// NewMockMailService creates a new mock instance.
+func NewMockMailService(ctrl *gomock.Controller) *MockMailService {
+	mock := &MockMailService{ctrl: ctrl}
+	mock.recorder = &MockMailServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMailService) EXPECT() *MockMailServiceMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockMailService) Send(ctx context.Context, guestID int64, kind string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, guestID, kind)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockMailServiceMockRecorder) Send(ctx, guestID, kind interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockMailService)(nil).Send), ctx, guestID, kind)
+}
+
+// SendDueReminders mocks base method.
+func (m *MockMailService) SendDueReminders(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendDueReminders", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendDueReminders indicates an expected call of SendDueReminders.
+func (mr *MockMailServiceMockRecorder) SendDueReminders(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendDueReminders", reflect.TypeOf((*MockMailService)(nil).SendDueReminders), ctx)
+}
+
+// MockMailer is a mock of Mailer interface.
+type MockMailer struct {
+	ctrl     *gomock.Controller
+	recorder *MockMailerMockRecorder
+}
+
+// MockMailerMockRecorder is the mock recorder for MockMailer.
+type MockMailerMockRecorder struct {
+	mock *MockMailer
+}
+
+// NewMockMailer creates a new mock instance.
+func NewMockMailer(ctrl *gomock.Controller) *MockMailer {
+	mock := &MockMailer{ctrl: ctrl}
+	mock.recorder = &MockMailerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMailer) EXPECT() *MockMailerMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockMailer) Send(ctx context.Context, email domain.Email) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockMailerMockRecorder) Send(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockMailer)(nil).Send), ctx, email)
+}
+
+// MockMailTemplates is a mock of MailTemplates interface.
+type MockMailTemplates struct {
+	ctrl     *gomock.Controller
+	recorder *MockMailTemplatesMockRecorder
+}
+
+// MockMailTemplatesMockRecorder is the mock recorder for MockMailTemplates.
+type MockMailTemplatesMockRecorder struct {
+	mock *MockMailTemplates
+}
+
+// NewMockMailTemplates creates a new mock instance.
+func NewMockMailTemplates(ctrl *gomock.Controller) *MockMailTemplates {
+	mock := &MockMailTemplates{ctrl: ctrl}
+	mock.recorder = &MockMailTemplatesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMailTemplates) EXPECT() *MockMailTemplatesMockRecorder {
+	return m.recorder
+}
+
+// Render mocks base method.
+func (m *MockMailTemplates) Render(kind string, data domain.EmailData) (*domain.Email, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Render", kind, data)
+	ret0, _ := ret[0].(*domain.Email)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Render indicates an expected call of Render.
+func (mr *MockMailTemplatesMockRecorder) Render(kind, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Render", reflect.TypeOf((*MockMailTemplates)(nil).Render), kind, data)
+}